@@ -68,14 +68,17 @@ func (s ActionSlice) each(f actionFunc) error {
 func (s ActionSlice) Prepare() error {
 	var prepare = func(seed data.Seed, fee data.Value, keyType data.KeyType, tx data.Transaction, txType data.TransactionType) error {
 		var (
-			sequence uint32
+			sequence *uint32 // Ed25519 keys have no account family
 			key      = seed.Key(keyType)
 			base     = tx.GetBase()
 		)
+		if keyType == data.ECDSA {
+			sequence = new(uint32)
+		}
 		base.TransactionType = txType
 		base.Fee = fee
-		base.Account = seed.AccountId(keyType, &sequence)
-		return data.Sign(tx, key, &sequence)
+		base.Account = seed.AccountId(keyType, sequence)
+		return data.Sign(tx, key, sequence)
 	}
 	return s.each(prepare)
 }
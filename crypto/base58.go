@@ -82,6 +82,9 @@ func Base58Decode(b, alphabet string) ([]byte, error) {
 		}
 	}
 	flen := numZeros + len(tmpval)
+	if flen < 5 {
+		return nil, fmt.Errorf("Base58 string too short: %s", b)
+	}
 	val := make([]byte, flen)
 	copy(val[numZeros:], tmpval)
 
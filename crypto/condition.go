@@ -0,0 +1,63 @@
+package crypto
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+)
+
+// PREIMAGE-SHA-256 crypto-conditions as described in
+// draft-thomas-crypto-conditions, which is what rippled uses for escrows.
+
+const (
+	preimageConditionTag   = 0xA0
+	preimageFulfillmentTag = 0xA0
+	fingerprintTag         = 0x80
+	costTag                = 0x81
+	preimageTag            = 0x80
+)
+
+// Returns the DER encoded PREIMAGE-SHA-256 condition for preimage
+func PreimageSha256Condition(preimage []byte) []byte {
+	fingerprint := sha256.Sum256(preimage)
+	body := derEncode(fingerprintTag, fingerprint[:])
+	body = append(body, derEncode(costTag, derInteger(uint64(len(preimage))))...)
+	return derEncode(preimageConditionTag, body)
+}
+
+// Returns the DER encoded PREIMAGE-SHA-256 fulfillment for preimage
+func PreimageSha256Fulfillment(preimage []byte) []byte {
+	return derEncode(preimageFulfillmentTag, derEncode(preimageTag, preimage))
+}
+
+func derEncode(tag byte, content []byte) []byte {
+	b := append([]byte{tag}, derLength(len(content))...)
+	return append(b, content...)
+}
+
+func derLength(n int) []byte {
+	if n < 0x80 {
+		return []byte{byte(n)}
+	}
+	length := derUint(uint64(n))
+	return append([]byte{0x80 | byte(len(length))}, length...)
+}
+
+// Minimal unsigned integer, with a leading zero if the high bit is set
+func derInteger(n uint64) []byte {
+	b := derUint(n)
+	if b[0]&0x80 != 0 {
+		return append([]byte{0}, b...)
+	}
+	return b
+}
+
+// Minimal big endian encoding, zero is a single byte
+func derUint(n uint64) []byte {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], n)
+	i := 0
+	for i < 7 && b[i] == 0 {
+		i++
+	}
+	return b[i:]
+}
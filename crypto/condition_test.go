@@ -0,0 +1,34 @@
+package crypto
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+
+	. "gopkg.in/check.v1"
+)
+
+type ConditionSuite struct{}
+
+var _ = Suite(&ConditionSuite{})
+
+func (s *ConditionSuite) TestPreimageSha256(c *C) {
+	c.Check(strings.ToUpper(hex.EncodeToString(PreimageSha256Condition(nil))), Equals, "A0258020E3B0C44298FC1C149AFBF4C8996FB92427AE41E4649B934CA495991B7852B855810100")
+	c.Check(strings.ToUpper(hex.EncodeToString(PreimageSha256Fulfillment(nil))), Equals, "A0028000")
+
+	preimage := bytes.Repeat([]byte{0xAA}, 32)
+	condition := PreimageSha256Condition(preimage)
+	c.Check(condition, HasLen, 39)
+	c.Check(condition[len(condition)-3:], DeepEquals, []byte{0x81, 0x01, 0x20})
+	fulfillment := PreimageSha256Fulfillment(preimage)
+	c.Check(fulfillment[:4], DeepEquals, []byte{0xA0, 0x22, 0x80, 0x20})
+	c.Check(fulfillment[4:], DeepEquals, preimage)
+
+	// Lengths and costs over 127 need the long forms
+	preimage = bytes.Repeat([]byte{0xAA}, 200)
+	condition = PreimageSha256Condition(preimage)
+	c.Check(condition[len(condition)-4:], DeepEquals, []byte{0x81, 0x02, 0x00, 0xC8})
+	fulfillment = PreimageSha256Fulfillment(preimage)
+	c.Check(fulfillment[:6], DeepEquals, []byte{0xA0, 0x81, 0xCB, 0x80, 0x81, 0xC8})
+	c.Check(fulfillment, HasLen, 206)
+}
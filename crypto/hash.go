@@ -1,6 +1,8 @@
 package crypto
 
 import (
+	"bytes"
+	"crypto/rand"
 	"fmt"
 	"math/big"
 )
@@ -30,9 +32,15 @@ func NewRippleHashCheck(s string, version HashVersion) (Hash, error) {
 	}
 	if hash.Version() != version {
 		want := hashTypes[version].Description
-		got := hashTypes[hash.Version()].Description
+		got := fmt.Sprintf("version %d", hash.Version())
+		if v := hash.Version(); int(v) < len(hashTypes) && hashTypes[v].Description != "" {
+			got = hashTypes[v].Description
+		}
 		return nil, fmt.Errorf("Bad version for: %s expected: %s got: %s ", s, want, got)
 	}
+	if n := hashTypes[version].Payload; len(hash.Payload()) != n {
+		return nil, fmt.Errorf("Bad length for: %s expected: %d got: %d", s, n, len(hash.Payload()))
+	}
 	return hash, nil
 }
 
@@ -40,6 +48,18 @@ func NewAccountId(b []byte) (Hash, error) {
 	return newHash(b, RIPPLE_ACCOUNT_ID)
 }
 
+// AccountFromPublicKey returns the account id, which encodes as an
+// r-address, of a 33 byte secp256k1 or 0xED prefixed ed25519 public key
+func AccountFromPublicKey(publicKey []byte) (Hash, error) {
+	switch {
+	case len(publicKey) != 33:
+		return nil, fmt.Errorf("Wrong public key length: %d", len(publicKey))
+	case publicKey[0] != 0x02 && publicKey[0] != 0x03 && publicKey[0] != 0xED:
+		return nil, fmt.Errorf("Unknown public key format")
+	}
+	return NewAccountId(Sha256RipeMD160(publicKey))
+}
+
 func NewAccountPublicKey(b []byte) (Hash, error) {
 	return newHash(b, RIPPLE_ACCOUNT_PUBLIC)
 }
@@ -84,6 +104,48 @@ func GenerateFamilySeed(password string) (Hash, error) {
 	return NewFamilySeed(Sha512Quarter([]byte(password)))
 }
 
+// GenerateSeed returns a new family seed from a secure random source, which
+// can derive a key of either type
+func GenerateSeed() (Hash, error) {
+	b := make([]byte, hashTypes[RIPPLE_FAMILY_SEED].Payload)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+	return NewFamilySeed(b)
+}
+
+// Ed25519 family seeds carry a three byte version so that they encode
+// as "sEd..." and cannot be mistaken for secp256k1 seeds
+var ed25519SeedVersion = []byte{0x01, 0xE1, 0x4B}
+
+// EncodeEd25519Seed returns the "sEd..." form of an ed25519 family seed
+func EncodeEd25519Seed(seed []byte) (string, error) {
+	if n := hashTypes[RIPPLE_FAMILY_SEED].Payload; len(seed) != n {
+		return "", fmt.Errorf("Seed is wrong size, expected: %d got: %d", n, len(seed))
+	}
+	b := append(append([]byte(nil), ed25519SeedVersion...), seed...)
+	return Base58Encode(b, ALPHABET), nil
+}
+
+// DecodeSeed accepts either form of family seed and reports
+// whether it is an ed25519 seed
+func DecodeSeed(s string) ([]byte, bool, error) {
+	decoded, err := Base58Decode(s, ALPHABET)
+	if err != nil {
+		return nil, false, err
+	}
+	decoded = decoded[:len(decoded)-4]
+	n := hashTypes[RIPPLE_FAMILY_SEED].Payload
+	switch {
+	case len(decoded) == len(ed25519SeedVersion)+n && bytes.HasPrefix(decoded, ed25519SeedVersion):
+		return decoded[len(ed25519SeedVersion):], true, nil
+	case len(decoded) == 1+n && decoded[0] == byte(RIPPLE_FAMILY_SEED):
+		return decoded[1:], false, nil
+	default:
+		return nil, false, fmt.Errorf("Not a family seed: %s", s)
+	}
+}
+
 func newHash(b []byte, version HashVersion) (Hash, error) {
 	n := hashTypes[version].Payload
 	if len(b) > n {
@@ -1,6 +1,7 @@
 package crypto
 
 import (
+	"encoding/hex"
 	"testing"
 
 	. "github.com/rubblelabs/ripple/testing"
@@ -49,3 +50,22 @@ func accountCheck(v interface{}) Hash {
 func (s *HashSuite) TestHashes(c *C) {
 	accountTests.Test(c)
 }
+
+func (s *HashSuite) TestAccountFromPublicKey(c *C) {
+	for _, test := range []struct {
+		publicKey, account string
+	}{
+		{"0330E7FC9D56BB25D6893BA3F317AE5BCF33B3291BD63DB32654A313222F7FD020", "rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh"},
+		{"EDAAC3F98BB94F451804EF5993C847DAAA4E6154F455635659D88AA5C80F156303", "rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf"},
+	} {
+		publicKey, err := hex.DecodeString(test.publicKey)
+		c.Assert(err, IsNil)
+		account, err := AccountFromPublicKey(publicKey)
+		c.Assert(err, IsNil)
+		c.Check(account.String(), Equals, test.account)
+	}
+	_, err := AccountFromPublicKey(make([]byte, 32))
+	c.Check(err, ErrorMatches, "Wrong public key length: 32")
+	_, err = AccountFromPublicKey(make([]byte, 33))
+	c.Check(err, ErrorMatches, "Unknown public key format")
+}
@@ -96,6 +96,20 @@ func (s *KeySuite) TestEd25119(c *C) {
 	c.Check(checkHash(AccountPublicKey(key, nil)), Equals, "aKGheSBjmCsKJVuLNKRAKpZXT6wpk2FCuEZAXJupXgdAxX5THCqR")
 	// c.Check(checkHash(AccountPrivateKey(key, nil)), Equals, "p9JfM6HHi64m6mvB6v5k7G2b1cXzGmYiCNJf6GHPKvFTWdeRVjh") //Needs a new version encoding
 
+	encoded, err := EncodeEd25519Seed(seed.Payload())
+	c.Check(err, IsNil)
+	c.Check(encoded, Equals, "sEdVQ4wvD1AaTG6JA54qt38TengAuiz")
+	decoded, ed25519, err := DecodeSeed(encoded)
+	c.Check(err, IsNil)
+	c.Check(ed25519, Equals, true)
+	c.Check(decoded, DeepEquals, seed.Payload())
+	decoded, ed25519, err = DecodeSeed(seed.String())
+	c.Check(err, IsNil)
+	c.Check(ed25519, Equals, false)
+	c.Check(decoded, DeepEquals, seed.Payload())
+	_, _, err = DecodeSeed("rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf")
+	c.Check(err, NotNil)
+
 	other, err := NewEd25519Key(nil)
 	c.Check(err, IsNil)
 
@@ -107,3 +121,60 @@ func (s *KeySuite) TestEd25119(c *C) {
 	c.Check(checkSignature(c, key.Private(nil), other.Public(nil), hash, msg), Equals, false)
 	c.Check(checkSignature(c, other.Private(nil), key.Public(nil), hash, msg), Equals, false)
 }
+
+func (s *KeySuite) TestWallet(c *C) {
+	seed, err := GenerateFamilySeed("masterpassphrase")
+	c.Assert(err, IsNil)
+	wallet, err := NewWallet("", seed.Payload())
+	c.Assert(err, IsNil)
+	c.Check(*wallet, DeepEquals, Wallet{
+		AccountId:     "rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh",
+		KeyType:       "secp256k1",
+		MasterSeed:    "snoPBrXtMeMyMHUVTgbuqAfg1SUTb",
+		MasterSeedHex: "DEDCE9CE67B451D852FD4E846FCDE31C",
+		PublicKey:     "aBQG8RQAzjs1eTKFEAQXr2gS4utcDiEC9wmi7pfUPTi27VCahwgw",
+		PublicKeyHex:  "0330E7FC9D56BB25D6893BA3F317AE5BCF33B3291BD63DB32654A313222F7FD020",
+	})
+
+	wallet, err = NewWallet("ed25519", seed.Payload())
+	c.Assert(err, IsNil)
+	c.Check(wallet.AccountId, Equals, "rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf")
+	c.Check(wallet.KeyType, Equals, "ed25519")
+	c.Check(wallet.MasterSeed, Equals, "snoPBrXtMeMyMHUVTgbuqAfg1SUTb")
+	c.Check(wallet.PublicKey, Equals, "aKGheSBjmCsKJVuLNKRAKpZXT6wpk2FCuEZAXJupXgdAxX5THCqR")
+	c.Check(wallet.PublicKeyHex, Matches, "ED[0-9A-F]{64}")
+
+	_, err = NewWallet("rsa", seed.Payload())
+	c.Check(err, ErrorMatches, "Unknown key type: rsa")
+
+	for _, algorithm := range []string{"secp256k1", "ed25519"} {
+		wallet, err := Propose(algorithm)
+		c.Assert(err, IsNil)
+		decoded, _, err := DecodeSeed(wallet.MasterSeed)
+		c.Assert(err, IsNil)
+		again, err := NewWallet(algorithm, decoded)
+		c.Assert(err, IsNil)
+		c.Check(again, DeepEquals, wallet)
+	}
+}
+
+// GenerateSeed encodes its random payload with NewFamilySeed, as rippled
+// encodes the seed of "masterpassphrase"
+func (s *KeySuite) TestGenerateSeed(c *C) {
+	seed, err := NewFamilySeed(h2b("DEDCE9CE67B451D852FD4E846FCDE31C"))
+	c.Assert(err, IsNil)
+	c.Check(seed.String(), Equals, "snoPBrXtMeMyMHUVTgbuqAfg1SUTb")
+	key, err := NewECDSAKey(seed.Payload())
+	c.Assert(err, IsNil)
+	var sequenceZero uint32
+	c.Check(checkHash(AccountId(key, &sequenceZero)), Equals, "rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh")
+	ed, err := NewEd25519Key(seed.Payload())
+	c.Assert(err, IsNil)
+	c.Check(checkHash(AccountId(ed, nil)), Equals, "rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf")
+
+	random, err := GenerateSeed()
+	c.Assert(err, IsNil)
+	c.Check(random.Version(), Equals, RIPPLE_FAMILY_SEED)
+	c.Check(random.Payload(), HasLen, 16)
+	c.Check(random.String()[0], Equals, byte('s'))
+}
@@ -1,6 +1,7 @@
 package crypto
 
 import (
+	"bytes"
 	"crypto/ed25519"
 	"fmt"
 
@@ -20,6 +21,9 @@ func Sign(privateKey, hash, msg []byte) ([]byte, error) {
 }
 
 func Verify(publicKey, hash, msg, signature []byte) (bool, error) {
+	if len(publicKey) == 0 {
+		return false, fmt.Errorf("Missing public key")
+	}
 	switch publicKey[0] {
 	case 0xED:
 		return verifyEd25519(publicKey, signature, msg)
@@ -30,6 +34,14 @@ func Verify(publicKey, hash, msg, signature []byte) (bool, error) {
 	}
 }
 
+// VerifyMessage reports whether signature is a signature of msg by
+// publicKey. Keys with the 0xED prefix are ed25519 keys, which sign msg
+// itself, and others secp256k1 keys, which sign its SHA-512Half.
+func VerifyMessage(publicKey, msg, signature []byte) bool {
+	ok, err := Verify(publicKey, Sha512Half(msg), msg, signature)
+	return err == nil && ok
+}
+
 func signEd25519(privateKey, msg []byte) ([]byte, error) {
 	return ed25519.Sign(privateKey, msg)[:], nil
 }
@@ -47,7 +59,24 @@ func verifyEd25519(pubKey, signature, msg []byte) (bool, error) {
 	}
 }
 
-// Returns DER encoded signature from input hash
+// Returns the DER encoded ECDSA signature with S in the lower half of the
+// curve order. rippled considers any other form of a signature malleable.
+func CanonicalizeSignature(signature []byte) ([]byte, error) {
+	sig, err := ecdsa.ParseDERSignature(signature)
+	if err != nil {
+		return nil, err
+	}
+	return sig.Serialize(), nil
+}
+
+// Returns true if signature is a fully canonical DER encoded ECDSA signature
+func IsCanonicalSignature(signature []byte) bool {
+	canonical, err := CanonicalizeSignature(signature)
+	return err == nil && bytes.Equal(canonical, signature)
+}
+
+// Returns DER encoded signature from input hash.
+// The signature is always canonical, see CanonicalizeSignature.
 func signECDSA(privateKey, hash []byte) ([]byte, error) {
 	priv, _ := btcec.PrivKeyFromBytes(privateKey)
 	sig := ecdsa.Sign(priv, hash)
@@ -0,0 +1,102 @@
+package crypto
+
+import (
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/ecdsa"
+	. "gopkg.in/check.v1"
+)
+
+type SignatureSuite struct{}
+
+var _ = Suite(&SignatureSuite{})
+
+// Returns the DER encoding of r and s without normalising s
+func derSignature(r, s *btcec.ModNScalar) []byte {
+	rb, sb := r.Bytes(), s.Bytes()
+	body := derEncode(0x02, derInteger32(rb[:]))
+	body = append(body, derEncode(0x02, derInteger32(sb[:]))...)
+	return derEncode(0x30, body)
+}
+
+func derScalar(b []byte) *btcec.ModNScalar {
+	for len(b) > 0 && b[0] == 0 {
+		b = b[1:]
+	}
+	var n btcec.ModNScalar
+	n.SetByteSlice(b)
+	return &n
+}
+
+func derInteger32(b []byte) []byte {
+	for len(b) > 1 && b[0] == 0 {
+		b = b[1:]
+	}
+	if b[0]&0x80 != 0 {
+		return append([]byte{0}, b...)
+	}
+	return b
+}
+
+func (s *SignatureSuite) TestCanonicalSignature(c *C) {
+	seed, err := GenerateFamilySeed("masterpassphrase")
+	c.Assert(err, IsNil)
+	key, err := NewECDSAKey(seed.Payload())
+	c.Assert(err, IsNil)
+	var sequence uint32
+	msg := []byte("Hello, nurse!")
+	hash := Sha512Half(msg)
+	low, err := Sign(key.Private(&sequence), hash, msg)
+	c.Assert(err, IsNil)
+	c.Check(IsCanonicalSignature(low), Equals, true)
+
+	// The same signature with S replaced by N-S still verifies
+	sig, err := ecdsa.ParseDERSignature(low)
+	c.Assert(err, IsNil)
+	rLen := int(low[3])
+	r, sValue := derScalar(low[4:4+rLen]), derScalar(low[4+rLen+2:])
+	c.Assert(ecdsa.NewSignature(r, sValue).IsEqual(sig), Equals, true)
+	c.Assert(sValue.IsOverHalfOrder(), Equals, false)
+	high := derSignature(r, sValue.Negate())
+	c.Check(high, Not(DeepEquals), low)
+	c.Check(IsCanonicalSignature(high), Equals, false)
+	ok, err := Verify(key.Public(&sequence), hash, msg, high)
+	c.Assert(err, IsNil)
+	c.Check(ok, Equals, true)
+
+	canonical, err := CanonicalizeSignature(high)
+	c.Assert(err, IsNil)
+	c.Check(canonical, DeepEquals, low)
+	canonical, err = CanonicalizeSignature(low)
+	c.Assert(err, IsNil)
+	c.Check(canonical, DeepEquals, low)
+
+	_, err = CanonicalizeSignature([]byte{0x30, 0x00})
+	c.Check(err, NotNil)
+	c.Check(IsCanonicalSignature(nil), Equals, false)
+}
+
+func (s *SignatureSuite) TestVerifyMessage(c *C) {
+	seed, err := GenerateFamilySeed("masterpassphrase")
+	c.Assert(err, IsNil)
+	ecdsaKey, err := NewECDSAKey(seed.Payload())
+	c.Assert(err, IsNil)
+	ed25519Key, err := NewEd25519Key(seed.Payload())
+	c.Assert(err, IsNil)
+	var sequence uint32
+	msg := []byte("Hello, nurse!")
+	for _, test := range []struct {
+		key      Key
+		sequence *uint32
+	}{
+		{ecdsaKey, &sequence},
+		{ed25519Key, nil},
+	} {
+		public := test.key.Public(test.sequence)
+		sig, err := Sign(test.key.Private(test.sequence), Sha512Half(msg), msg)
+		c.Assert(err, IsNil)
+		c.Check(VerifyMessage(public, msg, sig), Equals, true)
+		c.Check(VerifyMessage(public, []byte("Hello, nurse?"), sig), Equals, false)
+		c.Check(VerifyMessage(public, msg, sig[1:]), Equals, false)
+		c.Check(VerifyMessage(nil, msg, sig), Equals, false)
+	}
+}
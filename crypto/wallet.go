@@ -0,0 +1,67 @@
+package crypto
+
+import "fmt"
+
+// Wallet has the fields of a response to rippled's wallet_propose. The
+// deprecated master_key, the seed as RFC 1751 words, is not included.
+type Wallet struct {
+	AccountId     string `json:"account_id"`
+	KeyType       string `json:"key_type"`
+	MasterSeed    string `json:"master_seed"`
+	MasterSeedHex string `json:"master_seed_hex"`
+	PublicKey     string `json:"public_key"`
+	PublicKeyHex  string `json:"public_key_hex"`
+}
+
+// Propose generates a wallet from a new random seed, as wallet_propose does,
+// for algorithm "secp256k1" or "ed25519". An empty algorithm is secp256k1.
+func Propose(algorithm string) (*Wallet, error) {
+	seed, err := GenerateSeed()
+	if err != nil {
+		return nil, err
+	}
+	return NewWallet(algorithm, seed.Payload())
+}
+
+// NewWallet returns the wallet of seed for algorithm. Like rippled, the
+// master seed is encoded the same way whatever the algorithm, so the key
+// type has to be given along with it.
+func NewWallet(algorithm string, seed []byte) (*Wallet, error) {
+	var (
+		key      Key
+		sequence *uint32
+		err      error
+	)
+	switch algorithm {
+	case "", "secp256k1":
+		algorithm, sequence = "secp256k1", new(uint32)
+		key, err = NewECDSAKey(seed)
+	case "ed25519":
+		key, err = NewEd25519Key(seed)
+	default:
+		return nil, fmt.Errorf("Unknown key type: %s", algorithm)
+	}
+	if err != nil {
+		return nil, err
+	}
+	masterSeed, err := NewFamilySeed(seed)
+	if err != nil {
+		return nil, err
+	}
+	account, err := AccountId(key, sequence)
+	if err != nil {
+		return nil, err
+	}
+	public, err := AccountPublicKey(key, sequence)
+	if err != nil {
+		return nil, err
+	}
+	return &Wallet{
+		AccountId:     account.String(),
+		KeyType:       algorithm,
+		MasterSeed:    masterSeed.String(),
+		MasterSeedHex: fmt.Sprintf("%X", seed),
+		PublicKey:     public.String(),
+		PublicKeyHex:  fmt.Sprintf("%X", key.Public(sequence)),
+	}, nil
+}
@@ -0,0 +1,154 @@
+package data
+
+import (
+	"github.com/rubblelabs/ripple/crypto"
+)
+
+// The names of the amendments rippled has known, in the order they were added
+var amendmentNames = []string{
+	"MultiSign", "TrustSetAuth", "FeeEscalation", "OwnerPaysFee", "PayChan",
+	"Flow", "CryptoConditions", "TickSize", "fix1368", "Escrow",
+	"CryptoConditionsSuite", "fix1373", "EnforceInvariants", "FlowCross",
+	"SortedDirectories", "fix1201", "fix1512", "fix1513", "fix1523", "fix1528",
+	"DepositAuth", "Checks", "fix1571", "fix1543", "fix1623", "DepositPreauth",
+	"fix1515", "fix1578", "MultiSignReserve", "fixTakerDryOfferRemoval",
+	"fixMasterKeyAsRegularKey", "fixCheckThreading",
+	"fixPayChanRecipientOwnerDir", "DeletableAccounts", "fixQualityUpperBound",
+	"RequireFullyCanonicalSig", "fix1781", "HardenedValidations",
+	"fixAmendmentMajorityCalc", "NegativeUNL", "TicketBatch", "FlowSortStrands",
+	"fixSTAmountCanonicalize", "fixRmSmallIncreasedQOffers",
+	"CheckCashMakesTrustLine", "ExpandedSignerList", "NonFungibleTokensV1",
+	"fixNFTokenDirV1", "fixNFTokenNegOffer", "NonFungibleTokensV1_1",
+	"fixTrustLinesToSelf",
+	"fixRemoveNFTokenAutoTrustLine", "ImmediateOfferKilled", "DisallowIncoming",
+	"XRPFees", "fixUniversalNumber", "fixNonFungibleTokensV1_2",
+	"fixNFTokenRemint", "fixReducedOffersV1", "Clawback", "AMM", "XChainBridge",
+	"fixDisallowIncomingV1", "DID", "fixFillOrKill", "fixNFTokenReserve",
+	"fixInnerObjTemplate", "fixAMMOverflowOffer", "PriceOracle", "fixEmptyDID",
+	"fixXChainRewardRounding", "fixPreviousTxnID", "fixAMMv1_1",
+	"NFTokenMintOffer", "fixReducedOffersV2", "fixEnforceNFTokenTrustline",
+	"fixInnerObjTemplate2", "fixNFTokenPageLinks", "Credentials", "AMMClawback",
+	"MPTokensV1", "DynamicNFT", "PermissionedDomains",
+}
+
+var knownFeatures = make(map[Hash256]string, len(amendmentNames))
+
+func init() {
+	for _, name := range amendmentNames {
+		knownFeatures[NewFeature(name).Id] = name
+	}
+}
+
+// Feature is an amendment to the protocol, which is identified in
+// transactions and ledger entries by the SHA512Half of its name. The Name of
+// an amendment this package does not know is empty.
+type Feature struct {
+	Id   Hash256
+	Name string
+}
+
+// NewFeature returns the amendment with name
+func NewFeature(name string) Feature {
+	var id Hash256
+	copy(id[:], crypto.Sha512Half([]byte(name)))
+	return Feature{Id: id, Name: name}
+}
+
+// LookupFeature returns the amendment with id, along with its name if known
+func LookupFeature(id Hash256) Feature {
+	return Feature{Id: id, Name: knownFeatures[id]}
+}
+
+// KnownFeatures returns every amendment this package has the name of
+func KnownFeatures() []Feature {
+	features := make([]Feature, len(amendmentNames))
+	for i, name := range amendmentNames {
+		features[i] = NewFeature(name)
+	}
+	return features
+}
+
+// Returns the name of the amendment, or its id if the name is not known
+func (f Feature) String() string {
+	if f.Name != "" {
+		return f.Name
+	}
+	return f.Id.String()
+}
+
+// AmendmentChange is what an EnableAmendment pseudo-transaction records
+type AmendmentChange uint8
+
+const (
+	AmendmentEnabled AmendmentChange = iota
+	AmendmentGotMajority
+	AmendmentLostMajority
+)
+
+func (c AmendmentChange) String() string {
+	switch c {
+	case AmendmentEnabled:
+		return "Enabled"
+	case AmendmentGotMajority:
+		return "GotMajority"
+	case AmendmentLostMajority:
+		return "LostMajority"
+	default:
+		return "Unknown"
+	}
+}
+
+// Feature returns the amendment the pseudo-transaction is about
+func (a *Amendment) Feature() Feature {
+	return LookupFeature(a.Amendment)
+}
+
+// Change returns whether the amendment was enabled, or gained or lost the
+// support of a majority of validators
+func (a *Amendment) Change() AmendmentChange {
+	switch {
+	case a.Flags == nil:
+		return AmendmentEnabled
+	case *a.Flags&TxGotMajority != 0:
+		return AmendmentGotMajority
+	case *a.Flags&TxLostMajority != 0:
+		return AmendmentLostMajority
+	default:
+		return AmendmentEnabled
+	}
+}
+
+// FeatureMajority is an amendment which has had the support of a majority of
+// validators since CloseTime, and will be enabled if it keeps it long enough
+type FeatureMajority struct {
+	Feature
+	CloseTime RippleTime
+}
+
+// Enabled returns the amendments which are enabled
+func (a *Amendments) Enabled() []Feature {
+	if a.Amendments == nil {
+		return nil
+	}
+	features := make([]Feature, len(*a.Amendments))
+	for i, id := range *a.Amendments {
+		features[i] = LookupFeature(id)
+	}
+	return features
+}
+
+// Pending returns the amendments which have a majority but are not enabled
+func (a *Amendments) Pending() []FeatureMajority {
+	var pending []FeatureMajority
+	for _, m := range a.Majorities {
+		if m.Amendment == nil {
+			continue
+		}
+		majority := FeatureMajority{Feature: LookupFeature(*m.Amendment)}
+		if m.CloseTime != nil {
+			majority.CloseTime = *m.CloseTime
+		}
+		pending = append(pending, majority)
+	}
+	return pending
+}
@@ -0,0 +1,59 @@
+package data
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+type AmendmentSuite struct{}
+
+var _ = Suite(&AmendmentSuite{})
+
+func (s *AmendmentSuite) TestFeature(c *C) {
+	multiSign := NewFeature("MultiSign")
+	c.Check(multiSign.Id.String(), Equals, "4C97EBA926031A7CF7D7B36FDE3ED66DDA5421192D63DE53FFB46E43B9DC8373")
+	c.Check(LookupFeature(multiSign.Id), Equals, multiSign)
+	c.Check(multiSign.String(), Equals, "MultiSign")
+
+	for name, id := range map[string]string{
+		"fixNFTokenDirV1":    "0285B7E5E08E1A8E4C15636F0591D87F73CB6A7B6452A932AD72BBC8E5D1CBE3",
+		"fixNFTokenNegOffer": "36799EA497B1369B170805C078AEFE6188345F9B3E324C21E9CA3FF574E3C3D6",
+	} {
+		hash, err := NewHash256(id)
+		c.Assert(err, IsNil)
+		c.Check(LookupFeature(*hash).Name, Equals, name)
+	}
+
+	unknown := LookupFeature(NewFeature("Unknown").Id)
+	c.Check(unknown.Name, Equals, "")
+	c.Check(unknown.String(), Equals, unknown.Id.String())
+
+	features := KnownFeatures()
+	c.Check(features[0], Equals, multiSign)
+	c.Check(len(features), Equals, len(knownFeatures))
+}
+
+func (s *AmendmentSuite) TestEnableAmendment(c *C) {
+	// The amendment of the commented out "Amendment" ledger entry fixture
+	id, err := NewHash256("42426C4D4F1009EE67080A9B7965B44656D7714D104A72F9B4369F97ABF044EE")
+	c.Assert(err, IsNil)
+	tx := &Amendment{Amendment: *id}
+	c.Check(tx.Feature().Name, Equals, "FeeEscalation")
+	c.Check(tx.Change(), Equals, AmendmentEnabled)
+	flag := TxGotMajority
+	tx.Flags = &flag
+	c.Check(tx.Change().String(), Equals, "GotMajority")
+	flag = TxLostMajority
+	c.Check(tx.Change().String(), Equals, "LostMajority")
+}
+
+func (s *AmendmentSuite) TestAmendments(c *C) {
+	multiSign, payChan := NewFeature("MultiSign"), NewFeature("PayChan")
+	closeTime := NewRippleTime(500000000)
+	le := &Amendments{
+		Amendments: &Vector256{multiSign.Id},
+		Majorities: []Majority{{Amendment: &payChan.Id, CloseTime: closeTime}},
+	}
+	c.Check(le.Enabled(), DeepEquals, []Feature{multiSign})
+	c.Check(le.Pending(), DeepEquals, []FeatureMajority{{payChan, *closeTime}})
+	c.Check((&Amendments{}).Enabled(), IsNil)
+}
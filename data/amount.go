@@ -5,8 +5,6 @@ import (
 	"encoding/binary"
 	"fmt"
 	"strings"
-
-	"github.com/rubblelabs/ripple/crypto"
 )
 
 type Amount struct {
@@ -25,7 +23,22 @@ func newAmount(value *Value, currency Currency, issuer Account) *Amount {
 	}
 }
 
-// Requires v to be in computer parsable form
+// NewXRPAmount returns a native Amount of drops.
+// If the amount is out of range an error is returned.
+func NewXRPAmount(drops int64) (*Amount, error) {
+	value, err := NewNativeValue(drops)
+	if err != nil {
+		return nil, err
+	}
+	return newAmount(value, zeroCurrency, zeroAccount), nil
+}
+
+// NewAmount accepts an int64 of drops, or a string in the computer parsable
+// form Machine returns, which is value[/currency[/issuer]]. A value alone,
+// or with the currency XRP, is native, and in drops unless it has a decimal
+// point or the currency is given, so "1000000", "1.0" and "1/XRP" are the
+// same. The currency is either a 3 character code or 40 character hex, see
+// NewCurrency, and the issuer is an address.
 func NewAmount(v interface{}) (*Amount, error) {
 	switch n := v.(type) {
 	case int64:
@@ -33,38 +46,49 @@ func NewAmount(v interface{}) (*Amount, error) {
 			Value: newValue(true, n < 0, abs(n), 0),
 		}, nil
 	case string:
-		var err error
-		amount := new(Amount)
-		parts := strings.Split(strings.TrimSpace(n), "/")
-		native := false
-		switch {
-		case len(parts) == 1:
-			native = true
-		case len(parts) > 1 && parts[1] == "XRP":
-			native = true
-			if !strings.Contains(parts[0], ".") {
-				parts[0] = parts[0] + "."
-			}
+		amount, err := parseAmount(strings.TrimSpace(n))
+		if err != nil {
+			return nil, fmt.Errorf("Bad amount: %s: %s", n, err)
 		}
-		if amount.Value, err = NewValue(parts[0], native); err != nil {
+		return amount, nil
+	default:
+		return nil, fmt.Errorf("Bad type: %+v", v)
+	}
+}
+
+func parseAmount(s string) (*Amount, error) {
+	var err error
+	amount := new(Amount)
+	parts := strings.Split(s, "/")
+	if len(parts) > 3 {
+		return nil, fmt.Errorf("Too many parts")
+	}
+	native := len(parts) == 1 || parts[1] == "XRP"
+	if native && len(parts) > 1 && !strings.Contains(parts[0], ".") {
+		parts[0] = parts[0] + "."
+	}
+	if amount.Value, err = NewValue(parts[0], native); err != nil {
+		return nil, err
+	}
+	if len(parts) > 1 {
+		if amount.Currency, err = NewCurrency(parts[1]); err != nil {
 			return nil, err
 		}
-		if len(parts) > 1 {
-			if amount.Currency, err = NewCurrency(parts[1]); err != nil {
-				return nil, err
-			}
+		if !native && amount.Currency.IsNative() {
+			return nil, fmt.Errorf("The native currency must be given as XRP")
 		}
-		if len(parts) > 2 {
-			if issuer, err := crypto.NewRippleHash(parts[2]); err != nil {
-				return nil, err
-			} else {
-				copy(amount.Issuer[:], issuer.Payload())
-			}
+	}
+	if len(parts) > 2 {
+		if native {
+			return nil, fmt.Errorf("XRP has no issuer")
 		}
-		return amount, nil
-	default:
-		return nil, fmt.Errorf("Bad type: %+v", v)
+		issuer, err := NewAccountFromAddress(parts[2])
+		if err != nil {
+			return nil, fmt.Errorf("Bad issuer: %s", parts[2])
+		}
+		amount.Issuer = *issuer
 	}
+	return amount, nil
 }
 
 func (a Amount) MarshalBinary() ([]byte, error) {
@@ -114,7 +138,11 @@ func (a Amount) Abs() *Amount {
 	return clone
 }
 
+// Add returns the sum of a and b, which must have the same currency and issuer
 func (a Amount) Add(b *Amount) (*Amount, error) {
+	if !a.IsNative() && !b.IsNative() && (a.Currency != b.Currency || a.Issuer != b.Issuer) {
+		return nil, fmt.Errorf("Cannot add amounts with different currencies or issuers: %s %s", a, b)
+	}
 	sum, err := a.Value.Add(*b.Value)
 	if err != nil {
 		return nil, err
@@ -122,6 +150,7 @@ func (a Amount) Add(b *Amount) (*Amount, error) {
 	return newAmount(sum, a.Currency, a.Issuer), nil
 }
 
+// Subtract returns a less b, which must have the same currency and issuer
 func (a Amount) Subtract(b *Amount) (*Amount, error) {
 	return a.Add(b.Negate())
 }
@@ -240,21 +269,15 @@ func (a Amount) Asset() *Asset {
 	}
 }
 
+// NewExchangeRate returns the rate a/b as stored in the ExchangeRate of a
+// book directory
 func NewExchangeRate(a, b *Amount) (ExchangeRate, error) {
-	if b.IsZero() {
-		return 0, nil
-	}
-	rate, err := a.Divide(b)
-	if err != nil {
-		return 0, err
-	}
-	if rate.IsZero() {
-		return 0, nil
-	}
-	if rate.offset >= -100 || rate.offset <= 155 {
-		panic("Impossible Rate")
-	}
-	return ExchangeRate(uint64(rate.offset+100)<<54 | uint64(rate.num)), nil
+	quality, err := NewQuality(*a, *b)
+	return ExchangeRate(quality), err
+}
+
+func (e ExchangeRate) Quality() Quality {
+	return Quality(e)
 }
 
 func (e *ExchangeRate) Bytes() []byte {
@@ -46,6 +46,12 @@ var amountTests = TestSlice{
 	{addCheck("0/USD", "1/USD").String(), Equals, "1/USD", "Add 0 USD to 1 USD"},
 	{ErrorCheck(amountCheck("1/XRP").Add(amountCheck("1/USD"))), ErrorMatches, "Cannot add.*", "Add 1 XRP to 1 USD"},
 	{subCheck("150.02/USD/rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh", "50.5/USD/rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh").String(), Equals, "99.52/USD/rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh", "Subtract USD from USD"},
+	{ErrorCheck(amountCheck("1/USD/rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh").Add(amountCheck("1/EUR/rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh"))), ErrorMatches, "Cannot add amounts with different currencies.*", "Add 1 USD to 1 EUR"},
+	{ErrorCheck(amountCheck("1/USD/rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh").Subtract(amountCheck("1/USD/rNDKeo9RrCiRdfsMG8AdoZvNZxHASGzbZL"))), ErrorMatches, "Cannot add amounts with different currencies or issuers.*", "Subtract USD with issuer mismatch"},
+	{ErrorCheck(amountCheck("9000000000000000000").Add(amountCheck("9000000000000000000"))), ErrorMatches, "Native value overflow.*", "Add XRP overflow"},
+	{subCheck("1/USD/rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh", "0.9999999999999999/USD/rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh").String(), Equals, "0/USD/rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh", "Subtract USD leaving dust"},
+	{subCheck("1/USD/rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh", "0.99999999999/USD/rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh").String(), Equals, "1e-11/USD/rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh", "Subtract USD at precision"},
+	{subCheck("1", "1").String(), Equals, "0/XRP", "Subtract XRP from XRP"},
 	{mulCheck("0", "0").String(), Equals, "0/XRP", "Multiply 0 XRP with 0 XRP"},
 	{mulCheck("0/USD/rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh", "0").String(), Equals, "0/USD/rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh", "Multiply 0 USD with 0 XRP"},
 	{mulCheck("0", "0/USD/rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh").String(), Equals, "0/XRP", "Multiply 0 XRP with 0 USD"},
@@ -108,9 +114,13 @@ var amountTests = TestSlice{
 	{equalCheck("1", "1/USD/rNDKeo9RrCiRdfsMG8AdoZvNZxHASGzbZL"), Equals, false, "1 XRP != 1 USD"},
 	{ErrorCheck(amountCheck("1").Divide(amountCheck("0"))), ErrorMatches, "Division by zero", "Divide one by zero"},
 	{amountCheck("-1/XRP").Abs().String(), Equals, "1/XRP", "Abs -1"},
-	// {ErrorCheck(NewAmount("xx")), ErrorMatches, "Bad amount:.*", "IsValid xx"},
+	{ErrorCheck(NewAmount("xx")), ErrorMatches, "Bad amount:.*", "IsValid xx"},
 	{ErrorCheck(NewAmount(nil)), ErrorMatches, "Bad type:.*", "IsValid nil"},
 	{ErrorCheck(NewAmount(int(1))), ErrorMatches, "Bad type:.*", "IsValid int(0)"},
+	{xrpAmountCheck(1500000).String(), Equals, "1.5/XRP", "NewXRPAmount 1500000"},
+	{xrpAmountCheck(-1).String(), Equals, "-0.000001/XRP", "NewXRPAmount -1"},
+	{xrpAmountCheck(1500000).Equals(*amountCheck("1.5/XRP")), Equals, true, "NewXRPAmount equals 1.5/XRP"},
+	{ErrorCheck(NewXRPAmount(9000000000000000001)), ErrorMatches, "Native amount out of range.*", "NewXRPAmount > max"},
 
 	{checkBinaryMarshal(amountCheck("0/XRP")).String(), Equals, "0/XRP", "Binary Marshal 0/XRP"},
 	{checkBinaryMarshal(amountCheck("0.1/XRP")).String(), Equals, "0.1/XRP", "Binary Marshal 0.1/XRP"},
@@ -168,6 +178,46 @@ func (s *AmountSuite) TestAmount(c *C) {
 	amountTests.Test(c)
 }
 
+func (s *AmountSuite) TestParseAmount(c *C) {
+	for _, test := range []struct {
+		input, parsed string
+	}{
+		{"1000000", "1/XRP"},
+		{"1.5", "1.5/XRP"},
+		{"1/XRP", "1/XRP"},
+		{" -0.25/XRP ", "-0.25/XRP"},
+		{"1.5/USD/rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh", "1.5/USD/rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh"},
+		{"-2e3/USD/rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh", "-2000/USD/rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh"},
+		{"1/0000000000000000000000005553440000000000/rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh", "1/USD/rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh"},
+		{"10/815841551A748AD2C1F76FF6ECB0CCCD00000000/rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh", "10/815841551A748AD2C1F76FF6ECB0CCCD00000000/rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh"},
+		{"1.5/USD", "1.5/USD"},
+	} {
+		amount, err := NewAmount(test.input)
+		c.Assert(err, IsNil, Commentf("%s", test.input))
+		c.Check(amount.String(), Equals, test.parsed)
+		again, err := NewAmount(amount.String())
+		c.Assert(err, IsNil)
+		c.Check(again.Equals(*amount), Equals, true, Commentf("%s", test.input))
+	}
+
+	for _, test := range []struct {
+		input, err string
+	}{
+		{"", "Bad amount: : Invalid Number: "},
+		{"1/", "Bad amount: 1/: Bad Currency: "},
+		{"/USD", "Bad amount: /USD: Invalid Number: "},
+		{"1/USD/rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh/x", "Bad amount: .*: Too many parts"},
+		{"1/XRP/rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh", "Bad amount: .*: XRP has no issuer"},
+		{"1/USD/snoPBrXtMeMyMHUVTgbuqAfg1SUTb", "Bad amount: .*: Bad issuer: snoPBrXtMeMyMHUVTgbuqAfg1SUTb"},
+		{"1/0000000000000000000000000000000000000000", "Bad amount: .*: The native currency must be given as XRP"},
+		{"1/xrp/rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh", "Bad amount: .*: Bad Currency: xrp"},
+		{"100000000000000000/XRP", "Bad amount: .*: Native amount out of range.*"},
+	} {
+		_, err := NewAmount(test.input)
+		c.Check(err, ErrorMatches, test.err, Commentf("%s", test.input))
+	}
+}
+
 func ExampleValue_Add() {
 	v1, _ := NewValue("100", false)
 	v2, _ := NewValue("200.199", false)
@@ -196,3 +246,11 @@ func checkBinaryMarshal(v1 *Amount) *Amount {
 
 	return v2
 }
+
+func xrpAmountCheck(drops int64) *Amount {
+	if a, err := NewXRPAmount(drops); err != nil {
+		panic(err)
+	} else {
+		return a
+	}
+}
@@ -147,3 +147,77 @@ func (txm *TransactionWithMetaData) Balances() (BalanceMap, error) {
 	}
 	return balanceMap, nil
 }
+
+// BalanceChange is the change to the balance of Account held in Currency
+// issued by Issuer. Issuer is the counterparty of the trust line, and is
+// zero for XRP.
+type BalanceChange struct {
+	Account  Account
+	Currency Currency
+	Issuer   Account
+	Change   Value
+}
+
+func (b BalanceChange) String() string {
+	return fmt.Sprintf("Account: %-34s Currency: %s Issuer: %-34s Change: %20s", b.Account, b.Currency, b.Issuer, b.Change)
+}
+
+// BalanceChanges returns the XRP and trust line balance changes made by the
+// transaction, in AffectedNodes order. A trust line gives a change for both
+// of its accounts, low account first. The change to the sending account's
+// XRP balance includes the fee it paid.
+func (m *MetaData) BalanceChanges() ([]BalanceChange, error) {
+	var changes []BalanceChange
+	for i := range m.AffectedNodes {
+		_, final, previous, state := m.AffectedNodes[i].AffectedNode()
+		switch final := final.(type) {
+		case *AccountRoot:
+			previous := previous.(*AccountRoot)
+			if final.Account == nil || final.Balance == nil {
+				continue
+			}
+			before := &zeroNative
+			if state != Created {
+				if previous.Balance == nil {
+					// Only other fields changed
+					continue
+				}
+				before = previous.Balance
+			}
+			change, err := final.Balance.Subtract(*before)
+			if err != nil {
+				return nil, err
+			}
+			if !change.IsZero() {
+				changes = append(changes, BalanceChange{*final.Account, zeroCurrency, zeroAccount, *change})
+			}
+		case *RippleState:
+			previous := previous.(*RippleState)
+			if final.Balance == nil || final.LowLimit == nil || final.HighLimit == nil {
+				continue
+			}
+			before := final.Balance.ZeroClone()
+			if state != Created {
+				if previous.Balance == nil {
+					// Only limits or flags changed
+					continue
+				}
+				before = previous.Balance
+			}
+			change, err := final.Balance.Subtract(before)
+			if err != nil {
+				return nil, err
+			}
+			if change.IsZero() {
+				continue
+			}
+			// The balance is held from the point of view of the low account
+			low, high := final.LowLimit.Issuer, final.HighLimit.Issuer
+			changes = append(changes,
+				BalanceChange{low, final.Balance.Currency, high, *change.Value},
+				BalanceChange{high, final.Balance.Currency, low, *change.Value.Negate()},
+			)
+		}
+	}
+	return changes, nil
+}
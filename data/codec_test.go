@@ -1,7 +1,9 @@
 package data
 
 import (
+	"encoding/hex"
 	"encoding/json"
+	"testing"
 
 	internal "github.com/rubblelabs/ripple/testing"
 	. "gopkg.in/check.v1"
@@ -93,3 +95,115 @@ func (s *CodecSuite) TestParseMetaData(c *C) {
 		}
 	}
 }
+
+// Every transaction and ledger entry in the test data, in the form Decode
+// accepts
+func roundTripCorpus(c *C) [][]byte {
+	var corpus [][]byte
+	for _, test := range internal.Transactions {
+		corpus = append(corpus, test.Bytes())
+	}
+	for _, test := range internal.Nodes {
+		nodeId, err := NewHash256(test.NodeId())
+		c.Assert(err, IsNil)
+		n, err := ReadPrefix(test.Reader(), *nodeId)
+		c.Assert(err, IsNil)
+		switch v := n.(type) {
+		case *TransactionWithMetaData:
+			b, err := Encode(v.Transaction)
+			c.Assert(err, IsNil)
+			corpus = append(corpus, b)
+		case LedgerEntry:
+			b, err := Encode(v)
+			c.Assert(err, IsNil)
+			corpus = append(corpus, b)
+		}
+	}
+	return corpus
+}
+
+func (s *CodecSuite) TestRoundTrip(c *C) {
+	for _, b := range roundTripCorpus(c) {
+		h, err := Decode(b)
+		c.Assert(err, IsNil)
+		encoded, err := Encode(h)
+		c.Assert(err, IsNil)
+		c.Check(string(b2h(encoded)), Equals, string(b2h(b)))
+		c.Check(CheckRoundTrip(h), IsNil)
+	}
+}
+
+func (s *CodecSuite) TestDecodeErrors(c *C) {
+	for _, test := range []struct {
+		hex string
+		err string
+	}{
+		{"", "EOF"},
+		{"2400000001", "Cannot decode object starting with: Sequence"},
+		{"1200FF", "Unknown TransactionType: 255"},
+		{"110000", "Unknown LedgerEntryType: 0"},
+		{"120000120003", "Field out of order: TransactionType"},
+		{"12000024000000012200000000", "Field out of order: Flags"},
+		{"12000001124200", "Bad path entry: 42"},
+		{"1200000112FF", "Empty path"},
+		{"120000EA", "Unexpected object: .* for field: Memo"},
+		{"120000F4", "Missing array: SignerEntries.*"},
+		{"1100615602CE52E3E46AD340B1C7900F86AFB959AE0C246916E3463905EDD61DE26FFFDD", "Unexpected field: LedgerIndex"},
+	} {
+		b, err := hex.DecodeString(test.hex)
+		c.Assert(err, IsNil)
+		_, err = Decode(b)
+		c.Check(err, ErrorMatches, test.err)
+	}
+	_, err := Encode(&Ledger{})
+	c.Check(err, ErrorMatches, "Cannot encode: .*")
+}
+
+func (s *CodecSuite) TestRoundTripAmounts(c *C) {
+	// An Amount which was never set is left out rather than encoded
+	c.Check(CheckRoundTrip(&Payment{TxBase: TxBase{TransactionType: PAYMENT}}), IsNil)
+
+	for _, amount := range []string{
+		"0",
+		"-1",
+		"100000000000",
+		"0/USD/rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B",
+		"-1.5/USD/rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B",
+		"1e-81/USD/rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B",
+		"9999999999999999e80/USD/rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B",
+		"1/0158415500000000C1F76FF6ECB0BAC600000000/rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B",
+		"1/5553440000000000000000000000000000000000/rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B",
+		"1/0000000000000000000000005553440100000000/rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B",
+		"1/0000000000000000000000005852500000000000/rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B",
+		"1/000000000000000000000000FF53440000000000/rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B",
+	} {
+		a, err := NewAmount(amount)
+		c.Assert(err, IsNil)
+		payment := &Payment{TxBase: TxBase{TransactionType: PAYMENT}, Amount: *a}
+		c.Check(CheckRoundTrip(payment), IsNil, Commentf(amount))
+
+		// The machine readable form must parse back to the same currency
+		parsed, err := NewAmount(a.Machine())
+		c.Assert(err, IsNil, Commentf(amount))
+		c.Check(parsed.Currency, Equals, a.Currency, Commentf(amount))
+		c.Check(parsed.Value.Equals(*a.Value), Equals, true, Commentf(amount))
+	}
+}
+
+// Anything which decodes must encode to bytes which decode to the same
+// thing, even if the input was not canonical. Run with:
+// go test -run=XXX -fuzz=FuzzRoundTrip
+func FuzzRoundTrip(f *testing.F) {
+	for _, test := range internal.Transactions {
+		f.Add(test.Bytes())
+	}
+	f.Fuzz(func(t *testing.T, b []byte) {
+		h, err := Decode(b)
+		if err != nil {
+			return
+		}
+		if err := CheckRoundTrip(h); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
@@ -6,8 +6,8 @@ import (
 	"encoding/hex"
 	"fmt"
 	"math"
+	"sort"
 	"strings"
-	"unicode"
 )
 
 type Currency [20]byte
@@ -23,8 +23,13 @@ const (
 
 var zeroCurrency Currency
 
-// Accepts currency as either a 3 character code
-// or a 40 character hex string
+// The characters rippled allows in a 3 character code
+const isoCharSet = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789<>(){}[]|?!@#$%^&*"
+
+// Accepts currency as either a 3 character code or a 40 character hex
+// string, which is taken as it is. Codes are case sensitive, as they are to
+// rippled, so "usd" is not "USD". "XRP" is the native currency, and other
+// spellings of it are rejected rather than taken for a different currency.
 func NewCurrency(s string) (Currency, error) {
 	if s == "XRP" {
 		return zeroCurrency, nil
@@ -32,6 +37,9 @@ func NewCurrency(s string) (Currency, error) {
 	var currency Currency
 	switch len(s) {
 	case 3:
+		if !isIsoCode(s) {
+			return currency, fmt.Errorf("Bad Currency: %s", s)
+		}
 		copy(currency[12:], []byte(s))
 		return currency, nil
 	case 40:
@@ -46,6 +54,18 @@ func NewCurrency(s string) (Currency, error) {
 	}
 }
 
+// isIsoCode is true for a 3 character code which is not a spelling of XRP
+func isIsoCode(code string) bool {
+	for _, r := range code {
+		if !strings.ContainsRune(isoCharSet, r) {
+			return false
+		}
+	}
+	return !strings.EqualFold(code, "XRP")
+}
+
+// Compare orders currencies by their 160 bit representation, which is the
+// ordering rippled uses. XRP always sorts first.
 func (a Currency) Compare(b Currency) int {
 	return bytes.Compare(a[:], b[:])
 }
@@ -54,6 +74,14 @@ func (a Currency) Less(b Currency) bool {
 	return a.Compare(b) < 0
 }
 
+// CurrencySlice sorts currencies in canonical order
+type CurrencySlice []Currency
+
+func (s CurrencySlice) Len() int           { return len(s) }
+func (s CurrencySlice) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s CurrencySlice) Less(i, j int) bool { return s[i].Less(s[j]) }
+func (s CurrencySlice) Sort()              { sort.Sort(s) }
+
 func (c Currency) Equals(other Currency) bool {
 	return c == other
 }
@@ -75,13 +103,32 @@ func (c Currency) IsNative() bool {
 	return c == zeroCurrency
 }
 
+// IsHex returns true if the currency can only be represented
+// as a 40 character hex string rather than a 3 character code
+func (c Currency) IsHex() bool {
+	switch c.Type() {
+	case CT_XRP:
+		return false
+	case CT_STANDARD:
+		return !c.isPrintable()
+	default:
+		return true
+	}
+}
+
+// isPrintable is true for a standard code which reads back as the same
+// currency. That excludes "XRP", which would read back as the native currency.
+func (c Currency) isPrintable() bool {
+	return isIsoCode(string(c[12:15]))
+}
+
 func (c Currency) Type() CurrencyType {
 	switch {
 	case c.IsNative():
 		return CT_XRP
 	case c[0] == 0x00:
 		for i, b := range c {
-			if (i < 12 || i > 15) && b != 0 {
+			if (i < 12 || i > 14) && b != 0 {
 				return CT_UNKNOWN
 			}
 		}
@@ -117,27 +164,63 @@ func (c Currency) String() string {
 	return fmt.Sprintf("%s (%0.2f%%pa)", string(c[1:4]), c.Rate(secondsInYear)*100)
 }
 
-// Currency in computer parsable form
+// Currency in computer parsable form, which is the 3 character code of a
+// standard currency that has one, and otherwise the 40 character hex string
 func (c Currency) Machine() string {
 	switch c.Type() {
 	case CT_XRP:
 		return "XRP"
 	case CT_STANDARD:
-		// Check for unprintable characters
-		for _, r := range string(c[12:15]) {
-			if !unicode.IsPrint(r) {
-				return string(b2h(c[:]))
-			}
+		if c.isPrintable() {
+			return string(c[12:15])
 		}
-		return string(c[12:15])
-	case CT_UNKNOWN:
-		return strings.Map(func(r rune) rune {
-			if unicode.IsPrint(r) {
-				return r
-			}
-			return -1
-		}, string(c[:]))
-	default:
-		return string(b2h(c[:]))
 	}
+	return c.MachineString()
+}
+
+// MachineString returns the 40 character hex string of the currency,
+// whatever its type. That of XRP is all zeros.
+func (c Currency) MachineString() string {
+	return string(b2h(c[:]))
+}
+
+// Issue identifies an asset without an amount, as used by the AMM
+// transactions. XRP is represented by the zero currency and no issuer.
+type Issue struct {
+	Currency Currency
+	Issuer   Account
+}
+
+// Accepts "XRP" or currency/issuer, for example USD/rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B
+func NewIssue(s string) (*Issue, error) {
+	if s == "XRP" {
+		return &Issue{}, nil
+	}
+	parts := strings.Split(s, "/")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("Bad Issue: %s", s)
+	}
+	currency, err := NewCurrency(parts[0])
+	if err != nil {
+		return nil, err
+	}
+	if currency.IsNative() {
+		return nil, fmt.Errorf("Bad Issue: %s", s)
+	}
+	issuer, err := NewAccountFromAddress(parts[1])
+	if err != nil {
+		return nil, err
+	}
+	return &Issue{Currency: currency, Issuer: *issuer}, nil
+}
+
+func (i Issue) IsNative() bool {
+	return i.Currency.IsNative()
+}
+
+func (i Issue) String() string {
+	if i.IsNative() {
+		return "XRP"
+	}
+	return i.Currency.Machine() + "/" + i.Issuer.String()
 }
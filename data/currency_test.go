@@ -46,4 +46,104 @@ func (s *CurrencySuite) TestCurrencyTypes(c *C) {
 	c.Assert(wtf.Machine(), Equals, "0000000000000000000000007F80010000000000")
 	c.Assert(wtf.String(), Equals, "0000000000000000000000007F80010000000000")
 	c.Assert(wtf.Type(), Equals, CT_STANDARD)
+
+	// Codes which would not parse back as the same currency are hex
+	for _, code := range []string{
+		"5553440000000000000000000000000000000000",
+		"0000000000000000000000005553440100000000",
+		"0000000000000000000000005852500000000000",
+		"000000000000000000000000FF53440000000000",
+	} {
+		odd, err := NewCurrency(code)
+		c.Assert(err, IsNil)
+		c.Check(odd.Machine(), Equals, code)
+		c.Check(odd.IsHex(), Equals, true)
+	}
+}
+
+func (s *CurrencySuite) TestCurrencyOrdering(c *C) {
+	var currencies CurrencySlice
+	for _, code := range []string{
+		"815841551A748AD2C1F76FF6ECB0CCCD00000000",
+		"USD",
+		"015841551A748AD2C1F76FF6ECB0CCCD00000000",
+		"XRP",
+		"EUR",
+		"0000000000000000000000007F80010000000000",
+		"BTC",
+	} {
+		currency, err := NewCurrency(code)
+		c.Assert(err, IsNil)
+		currencies = append(currencies, currency)
+	}
+	currencies.Sort()
+	var sorted []string
+	for _, currency := range currencies {
+		sorted = append(sorted, currency.Machine())
+	}
+	c.Assert(sorted, DeepEquals, []string{
+		"XRP",
+		"BTC",
+		"EUR",
+		"USD",
+		"0000000000000000000000007F80010000000000",
+		"015841551A748AD2C1F76FF6ECB0CCCD00000000",
+		"815841551A748AD2C1F76FF6ECB0CCCD00000000",
+	})
+	c.Assert(currencies[1].Less(currencies[2]), Equals, true)
+	c.Assert(currencies[2].Less(currencies[1]), Equals, false)
+	c.Assert(currencies[0].Compare(currencies[0]), Equals, 0)
+
+	isHex := []bool{false, false, false, false, true, true, true}
+	for i, currency := range currencies {
+		c.Check(currency.IsHex(), Equals, isHex[i], Commentf("%s", currency))
+		c.Check(currency.IsNative(), Equals, i == 0, Commentf("%s", currency))
+	}
+}
+
+func (s *CurrencySuite) TestNewCurrency(c *C) {
+	usd, err := NewCurrency("USD")
+	c.Assert(err, IsNil)
+	c.Check(usd.MachineString(), Equals, "0000000000000000000000005553440000000000")
+	hexUsd, err := NewCurrency("0000000000000000000000005553440000000000")
+	c.Assert(err, IsNil)
+	c.Check(hexUsd, Equals, usd)
+	upper, err := NewCurrency("815841551A748AD2C1F76FF6ECB0CCCD00000000")
+	c.Assert(err, IsNil)
+	lower, err := NewCurrency("815841551a748ad2c1f76ff6ecb0cccd00000000")
+	c.Assert(err, IsNil)
+	c.Check(lower, Equals, upper)
+
+	// Codes are case sensitive
+	usdLower, err := NewCurrency("usd")
+	c.Assert(err, IsNil)
+	c.Check(usdLower, Not(Equals), usd)
+	c.Check(usdLower.Machine(), Equals, "usd")
+
+	xrp, err := NewCurrency("XRP")
+	c.Assert(err, IsNil)
+	c.Check(xrp.MachineString(), Equals, "0000000000000000000000000000000000000000")
+	zero, err := NewCurrency("0000000000000000000000000000000000000000")
+	c.Assert(err, IsNil)
+	c.Check(zero.IsNative(), Equals, true)
+
+	// A hex code is taken as it is, even when it looks like a short one
+	short, err := NewCurrency("5553440000000000000000000000000000000000")
+	c.Assert(err, IsNil)
+	c.Check(short, Not(Equals), usd)
+	c.Check(short.MachineString(), Equals, "5553440000000000000000000000000000000000")
+
+	for _, code := range []string{
+		"", "US", "USDT", "xrp", "Xrp", "U D", "US-", "U\x00D", "é$",
+		"000000000000000000000000555344000000000", "G000000000000000000000005553440000000000",
+	} {
+		_, err := NewCurrency(code)
+		c.Check(err, ErrorMatches, "Bad Currency: .*", Commentf("%q", code))
+	}
+
+	// Standard codes outside the allowed characters print as hex
+	space, err := NewCurrency("0000000000000000000000005520440000000000")
+	c.Assert(err, IsNil)
+	c.Check(space.Machine(), Equals, "0000000000000000000000005520440000000000")
+	c.Check(space.IsHex(), Equals, true)
 }
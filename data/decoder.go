@@ -1,6 +1,7 @@
 package data
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"reflect"
@@ -89,11 +90,17 @@ func ReadTransaction(r Reader) (Transaction, error) {
 	if err != nil {
 		return nil, err
 	}
-	tx := TxFactory[txType]()
+	tx, err := newTransaction(TransactionType(txType))
+	if err != nil {
+		return nil, err
+	}
 	v := reflect.ValueOf(tx)
 	if err := readObject(r, &v); err != nil {
 		return nil, err
 	}
+	if tx.GetTransactionType() != TransactionType(txType) {
+		return nil, fmt.Errorf("Field out of order: TransactionType")
+	}
 	return tx, nil
 }
 
@@ -174,16 +181,10 @@ func readCompressedInnerNode(r Reader, typ NodeType, nodeId Hash256) (*InnerNode
 }
 
 func ReadLedgerEntry(r Reader, nodeId Hash256) (LedgerEntry, error) {
-	leType, err := expectType(r, "LedgerEntryType")
-	if err != nil {
-		return nil, err
-	}
-	le := LedgerEntryFactory[leType]()
-	v := reflect.ValueOf(le)
 	// LedgerEntries have 32 bytes of index suffixed
 	// but don't have a variable bytes indicator
-	lr := LimitedByteReader(r, int64(r.Len()-32))
-	if err := readObject(lr, &v); err != nil {
+	le, err := readLedgerEntryFields(LimitedByteReader(r, int64(r.Len()-32)))
+	if err != nil {
 		return nil, err
 	}
 	hash, err := readHash(r)
@@ -195,6 +196,78 @@ func ReadLedgerEntry(r Reader, nodeId Hash256) (LedgerEntry, error) {
 	return le, nil
 }
 
+func readLedgerEntryFields(r Reader) (LedgerEntry, error) {
+	leType, err := expectType(r, "LedgerEntryType")
+	if err != nil {
+		return nil, err
+	}
+	le, err := NewLedgerEntry(LedgerEntryType(leType))
+	if err != nil {
+		return nil, err
+	}
+	v := reflect.ValueOf(le)
+	if err := readObject(r, &v); err != nil {
+		return nil, err
+	}
+	if le.GetLedgerEntryType() != LedgerEntryType(leType) {
+		return nil, fmt.Errorf("Field out of order: LedgerEntryType")
+	}
+	// The index is suffixed to an entry, and is never one of its fields
+	if le.GetLedgerIndex() != nil {
+		return nil, fmt.Errorf("Unexpected field: LedgerIndex")
+	}
+	return le, nil
+}
+
+// Decode parses the canonical binary form of a transaction or ledger entry,
+// as produced by Encode, telling which it is from the first field.
+// A ledger entry has no index suffixed, so its index is computed from its
+// fields where possible.
+func Decode(b []byte) (Hashable, error) {
+	enc, err := readEncoding(bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+	switch encodings[*enc] {
+	case "TransactionType":
+		return ReadTransaction(bytes.NewReader(b))
+	case "LedgerEntryType":
+		le, err := readLedgerEntryFields(bytes.NewReader(b))
+		if err != nil {
+			return nil, err
+		}
+		if index, err := LedgerIndex(le); err == nil {
+			copy(le.GetHash()[:], index[:])
+		}
+		return le, nil
+	default:
+		return nil, fmt.Errorf("Cannot decode object starting with: %s", encodings[*enc])
+	}
+}
+
+// CheckRoundTrip encodes h, decodes the result and encodes that again,
+// returning an error unless both encodings are the same bytes. Any
+// transaction or ledger entry which passes can be relied upon to survive
+// being passed around in binary form.
+func CheckRoundTrip(h Hashable) error {
+	encoded, err := Encode(h)
+	if err != nil {
+		return err
+	}
+	decoded, err := Decode(encoded)
+	if err != nil {
+		return fmt.Errorf("Cannot decode %s: %s", h.GetType(), err)
+	}
+	reencoded, err := Encode(decoded)
+	if err != nil {
+		return fmt.Errorf("Cannot encode decoded %s: %s", h.GetType(), err)
+	}
+	if !bytes.Equal(encoded, reencoded) {
+		return fmt.Errorf("%s does not round trip: %X became: %X", h.GetType(), encoded, reencoded)
+	}
+	return nil
+}
+
 func readHashPrefix(r Reader) (HashPrefix, error) {
 	var version HashPrefix
 	return version, read(r, &version)
@@ -236,17 +309,53 @@ var (
 	errorEndOfArray  = errors.New("EndOfArray")
 )
 
+// The type of value each inner object can be read into. Anything else comes
+// from a malformed or hostile encoding.
+var objectParents = map[string]reflect.Type{
+	"PreviousFields":    reflect.TypeOf(&AffectedNode{}),
+	"NewFields":         reflect.TypeOf(&AffectedNode{}),
+	"FinalFields":       reflect.TypeOf(&AffectedNode{}),
+	"ModifiedNode":      reflect.TypeOf(NodeEffect{}),
+	"DeletedNode":       reflect.TypeOf(NodeEffect{}),
+	"CreatedNode":       reflect.TypeOf(NodeEffect{}),
+	"SignerEntry":       reflect.TypeOf(SignerEntry{}),
+	"NFToken":           reflect.TypeOf(NFToken{}),
+	"Signer":            reflect.TypeOf(Signer{}),
+	"AuthAccount":       reflect.TypeOf(AuthAccount{}),
+	"PriceData":         reflect.TypeOf(PriceData{}),
+	"Majority":          reflect.TypeOf(Majority{}),
+	"Memo":              reflect.TypeOf(Memo{}),
+	"DisabledValidator": reflect.TypeOf(DisabledValidator{}),
+	"Hook":              reflect.TypeOf(Hook{}),
+	"HookParameter":     reflect.TypeOf(HookParameter{}),
+	"HookGrant":         reflect.TypeOf(HookGrant{}),
+}
+
 func readObject(r Reader, v *reflect.Value) error {
 	var err error
+	var last uint32
 	for enc, err := readEncoding(r); err == nil; enc, err = readEncoding(r) {
 		name := encodings[*enc]
+		// Canonical encodings have each field once, in order
+		if name != "EndOfObject" && name != "EndOfArray" {
+			if enc.Priority() <= last {
+				return fmt.Errorf("Field out of order: %s", name)
+			}
+			last = enc.Priority()
+		}
 		// fmt.Println(name, v, v.IsValid(), enc.typ, enc.field)
 		switch enc.typ {
 		case ST_ARRAY:
 			if name == "EndOfArray" {
 				return errorEndOfArray
 			}
+			if v.Kind() != reflect.Ptr {
+				return fmt.Errorf("Unexpected array: %s for field: %s", v.Type(), name)
+			}
 			array := getField(v, enc)
+			if array.Kind() != reflect.Slice {
+				return fmt.Errorf("Missing array: %s %+v", name, enc)
+			}
 		loop:
 			for {
 				child := reflect.New(array.Type().Elem()).Elem()
@@ -261,12 +370,18 @@ func readObject(r Reader, v *reflect.Value) error {
 				}
 			}
 		case ST_OBJECT:
+			if parent, ok := objectParents[name]; ok && (v.Type() != parent || (v.Kind() != reflect.Ptr && !v.CanSet())) {
+				return fmt.Errorf("Unexpected object: %s for field: %s", v.Type(), name)
+			}
 			switch name {
 			case "EndOfObject":
 				return errorEndOfObject
 			case "PreviousFields", "NewFields", "FinalFields":
 				leType := LedgerEntryType(v.Elem().FieldByName("LedgerEntryType").Uint())
-				le := LedgerEntryFactory[leType]()
+				le, err := NewLedgerEntry(leType)
+				if err != nil {
+					return err
+				}
 				fields := reflect.ValueOf(le)
 				v.Elem().FieldByName(name).Set(fields)
 				if err := readObject(r, &fields); err != nil && err != errorEndOfObject {
@@ -289,7 +404,8 @@ func readObject(r Reader, v *reflect.Value) error {
 			case "SignerEntry":
 				var signerEntry SignerEntry
 				s := reflect.ValueOf(&signerEntry)
-				err := readObject(r, &s)
+				inner := reflect.ValueOf(&signerEntry.SignerEntry)
+				err := readObject(r, &inner)
 				v.Set(s.Elem())
 				return err
 			case "NFToken":
@@ -301,9 +417,24 @@ func readObject(r Reader, v *reflect.Value) error {
 			case "Signer":
 				var signer Signer
 				s := reflect.ValueOf(&signer)
-				err := readObject(r, &s)
+				inner := reflect.ValueOf(&signer.Signer)
+				err := readObject(r, &inner)
 				v.Set(s.Elem())
 				return err
+			case "AuthAccount":
+				var authAccount AuthAccount
+				a := reflect.ValueOf(&authAccount)
+				inner := reflect.ValueOf(&authAccount.AuthAccount)
+				err := readObject(r, &inner)
+				v.Set(a.Elem())
+				return err
+			case "PriceData":
+				var priceData PriceData
+				p := reflect.ValueOf(&priceData)
+				inner := reflect.ValueOf(&priceData.PriceData)
+				err := readObject(r, &inner)
+				v.Set(p.Elem())
+				return err
 			case "Majority":
 				var majority Majority
 				m := reflect.ValueOf(&majority)
@@ -323,6 +454,27 @@ func readObject(r Reader, v *reflect.Value) error {
 				err := readObject(r, &dv)
 				v.Set(dv.Elem())
 				return err
+			case "Hook":
+				var hook Hook
+				h := reflect.ValueOf(&hook)
+				inner := reflect.ValueOf(&hook.Hook)
+				err := readObject(r, &inner)
+				v.Set(h.Elem())
+				return err
+			case "HookParameter":
+				var parameter HookParameter
+				p := reflect.ValueOf(&parameter)
+				inner := reflect.ValueOf(&parameter.HookParameter)
+				err := readObject(r, &inner)
+				v.Set(p.Elem())
+				return err
+			case "HookGrant":
+				var grant HookGrant
+				g := reflect.ValueOf(&grant)
+				inner := reflect.ValueOf(&grant.HookGrant)
+				err := readObject(r, &inner)
+				v.Set(g.Elem())
+				return err
 			default:
 				return fmt.Errorf("Unexpected object: %s for field: %s", v.Type(), name)
 			}
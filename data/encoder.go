@@ -14,6 +14,21 @@ func Raw(h Hashable) (Hash256, []byte, error) {
 	return raw(h, h.Prefix(), nil, false)
 }
 
+// Encode returns the canonical binary form of a transaction or ledger
+// entry, without any prefix or suffixed index, which is what Decode accepts.
+func Encode(h Hashable) ([]byte, error) {
+	switch h.(type) {
+	case Transaction, LedgerEntry:
+		var b bytes.Buffer
+		if err := encode(&b, h, false); err != nil {
+			return nil, err
+		}
+		return b.Bytes(), nil
+	default:
+		return nil, fmt.Errorf("Cannot encode: %s", h.GetType())
+	}
+}
+
 func NodeId(h Hashable) (Hash256, error) {
 	nodeid, _, err := raw(h, h.Prefix(), nil, false)
 	return nodeid, err
@@ -23,6 +38,21 @@ func SigningHash(s Signable) (Hash256, []byte, error) {
 	return raw(s, s.SigningPrefix(), nil, true)
 }
 
+// TransactionHash returns the hash which identifies a signed transaction,
+// computed over the 'TXN' prefix and all fields of tx. It is known before
+// submission and is the hash rippled reports for the transaction.
+func TransactionHash(tx Transaction) (Hash256, error) {
+	hash, _, err := Raw(tx)
+	return hash, err
+}
+
+// TransactionSigningHash returns the hash a single signer signs, computed
+// over the 'STX' prefix and the fields of tx less any signatures.
+func TransactionSigningHash(tx Transaction) (Hash256, error) {
+	hash, _, err := SigningHash(tx)
+	return hash, err
+}
+
 func MultiSigningHash(s MultiSignable, account Account) (Hash256, []byte, error) {
 	return raw(s, s.MultiSigningPrefix(), account.Bytes(), true)
 }
@@ -112,7 +142,7 @@ func writeRaw(w io.Writer, value interface{}, ignoreSigningFields bool) error {
 		if err := encode(w, v, ignoreSigningFields); err != nil {
 			return err
 		}
-		index, err := LedgerIndex(v)
+		index, err := leafIndex(v)
 		if err != nil {
 			return err
 		}
@@ -126,10 +156,10 @@ func encode(w io.Writer, value interface{}, ignoreSigningFields bool) error {
 	v := reflect.Indirect(reflect.ValueOf(value))
 	fields := getFields(&v, 0)
 	// fmt.Println(fields.String())
+	if ignoreSigningFields {
+		fields = fields.withoutSigningFields()
+	}
 	return fields.Each(func(e enc, v interface{}) error {
-		if ignoreSigningFields && e.SigningField() {
-			return nil
-		}
 		if err := writeEncoding(w, e); err != nil {
 			return err
 		}
@@ -164,6 +194,8 @@ func (s *fieldSlice) Append(e enc, v interface{}, children fieldSlice) {
 	*s = append(*s, field{e, v, children})
 }
 
+var amountType = reflect.TypeOf(Amount{})
+
 func getFields(v *reflect.Value, depth int) fieldSlice {
 	// fmt.Println(v, v.Kind(), v.Type().Name())
 	length := v.NumField()
@@ -178,22 +210,34 @@ func getFields(v *reflect.Value, depth int) fieldSlice {
 		if fieldName == "LedgerEntryType" && depth > 1 && typ.Name() == "leBase" {
 			continue
 		}
+		// The index of a ledger entry is its key, not one of its fields
+		if fieldName == "LedgerIndex" && typ.Name() == "leBase" {
+			continue
+		}
 		encoding := reverseEncodings[fieldName]
 		f := v.Field(i)
 		// fmt.Println(fieldName, encoding, f, f.Kind())
 		if f.Kind() == reflect.Interface {
 			f = f.Elem()
 		}
+		// An empty blob which is set explicitly is encoded, as that is how
+		// fields such as Domain or URI get cleared. Signatures are empty
+		// until signed, and multi-signed transactions must leave them out.
+		explicit := f.Kind() == reflect.Ptr && !f.IsNil() && encoding.typ == ST_VL && !encoding.SigningField()
 		if f.Kind() == reflect.Ptr {
 			f = f.Elem()
 		}
-		if !f.IsValid() || (f.Kind() == reflect.Slice && f.Len() == 0) {
+		if !f.IsValid() || (f.Kind() == reflect.Slice && f.Len() == 0 && !explicit) {
+			continue
+		}
+		// An Amount which was never set has nothing to encode
+		if f.Type() == amountType && f.FieldByName("Value").IsNil() {
 			continue
 		}
 		switch encoding.typ {
 		case ST_UINT8, ST_UINT16, ST_UINT32, ST_UINT64:
 			fields.Append(encoding, f.Addr().Interface(), nil)
-		case ST_HASH96, ST_HASH128, ST_HASH160, ST_HASH192, ST_HASH256, ST_HASH384, ST_HASH512, ST_AMOUNT, ST_VL, ST_ACCOUNT, ST_PATHSET, ST_VECTOR256:
+		case ST_HASH96, ST_HASH128, ST_HASH160, ST_HASH192, ST_HASH256, ST_HASH384, ST_HASH512, ST_AMOUNT, ST_VL, ST_ACCOUNT, ST_PATHSET, ST_VECTOR256, ST_ISSUE, ST_XCHAIN_BRIDGE, ST_CURRENCY:
 			fields.Append(encoding, f.Addr().Interface(), nil)
 		case ST_ARRAY:
 			var children fieldSlice
@@ -215,6 +259,19 @@ func getFields(v *reflect.Value, depth int) fieldSlice {
 	return fields
 }
 
+// Removes signing fields, along with any children they have
+func (s fieldSlice) withoutSigningFields() fieldSlice {
+	filtered := make(fieldSlice, 0, len(s))
+	for _, f := range s {
+		if f.encoding.SigningField() {
+			continue
+		}
+		f.children = f.children.withoutSigningFields()
+		filtered = append(filtered, f)
+	}
+	return filtered
+}
+
 func (s fieldSlice) Each(f func(e enc, v interface{}) error) error {
 	for _, field := range s {
 		if err := f(field.encoding, field.value); err != nil {
@@ -1,5 +1,7 @@
 package data
 
+import "fmt"
+
 // Horrible look up tables
 // Could all this be one big map?
 
@@ -26,30 +28,47 @@ const (
 	NFTOKEN_OFFER    LedgerEntryType = 0x37 // '7'
 
 	// TransactionType values come from rippled's "TxFormats.h"
-	PAYMENT              TransactionType = 0
-	ESCROW_CREATE        TransactionType = 1
-	ESCROW_FINISH        TransactionType = 2
-	ACCOUNT_SET          TransactionType = 3
-	ESCROW_CANCEL        TransactionType = 4
-	SET_REGULAR_KEY      TransactionType = 5
-	OFFER_CREATE         TransactionType = 7
-	OFFER_CANCEL         TransactionType = 8
-	TICKET_CREATE        TransactionType = 10
-	SIGNER_LIST_SET      TransactionType = 12
-	PAYCHAN_CREATE       TransactionType = 13
-	PAYCHAN_FUND         TransactionType = 14
-	PAYCHAN_CLAIM        TransactionType = 15
-	CHECK_CREATE         TransactionType = 16
-	CHECK_CASH           TransactionType = 17
-	CHECK_CANCEL         TransactionType = 18
-	SET_DEPOSIT_PREAUTH  TransactionType = 19
-	TRUST_SET            TransactionType = 20
-	ACCOUNT_DELETE       TransactionType = 21
-	NFTOKEN_MINT         TransactionType = 25
-	NFTOKEN_BURN         TransactionType = 26
-	NFTOKEN_CREATE_OFFER TransactionType = 27
-	NFTOKEN_CANCEL_OFFER TransactionType = 28
-	NFTOKEN_ACCEPT_OFFER TransactionType = 29
+	PAYMENT                      TransactionType = 0
+	ESCROW_CREATE                TransactionType = 1
+	ESCROW_FINISH                TransactionType = 2
+	ACCOUNT_SET                  TransactionType = 3
+	ESCROW_CANCEL                TransactionType = 4
+	SET_REGULAR_KEY              TransactionType = 5
+	OFFER_CREATE                 TransactionType = 7
+	OFFER_CANCEL                 TransactionType = 8
+	TICKET_CREATE                TransactionType = 10
+	SIGNER_LIST_SET              TransactionType = 12
+	PAYCHAN_CREATE               TransactionType = 13
+	PAYCHAN_FUND                 TransactionType = 14
+	PAYCHAN_CLAIM                TransactionType = 15
+	CHECK_CREATE                 TransactionType = 16
+	CHECK_CASH                   TransactionType = 17
+	CHECK_CANCEL                 TransactionType = 18
+	SET_DEPOSIT_PREAUTH          TransactionType = 19
+	TRUST_SET                    TransactionType = 20
+	ACCOUNT_DELETE               TransactionType = 21
+	HOOK_SET                     TransactionType = 22 // Only on networks with Hooks, such as Xahau
+	NFTOKEN_MINT                 TransactionType = 25
+	NFTOKEN_BURN                 TransactionType = 26
+	NFTOKEN_CREATE_OFFER         TransactionType = 27
+	NFTOKEN_CANCEL_OFFER         TransactionType = 28
+	NFTOKEN_ACCEPT_OFFER         TransactionType = 29
+	CLAWBACK                     TransactionType = 30
+	AMM_CREATE                   TransactionType = 35
+	AMM_DEPOSIT                  TransactionType = 36
+	AMM_WITHDRAW                 TransactionType = 37
+	AMM_VOTE                     TransactionType = 38
+	AMM_BID                      TransactionType = 39
+	AMM_DELETE                   TransactionType = 40
+	XCHAIN_CREATE_CLAIM_ID       TransactionType = 41
+	XCHAIN_COMMIT                TransactionType = 42
+	XCHAIN_CLAIM                 TransactionType = 43
+	XCHAIN_ADD_CLAIM_ATTESTATION TransactionType = 45
+	XCHAIN_CREATE_BRIDGE         TransactionType = 48
+	DID_SET                      TransactionType = 49
+	DID_DELETE                   TransactionType = 50
+	ORACLE_SET                   TransactionType = 51
+	ORACLE_DELETE                TransactionType = 52
 
 	AMENDMENT  TransactionType = 100
 	SET_FEE    TransactionType = 101
@@ -105,8 +124,29 @@ var TxFactory = [...]func() Transaction{
 	NFTOKEN_MINT:         func() Transaction { return &NFTokenMint{TxBase: TxBase{TransactionType: NFTOKEN_MINT}} },
 	NFTOKEN_BURN:         func() Transaction { return &NFTokenBurn{TxBase: TxBase{TransactionType: NFTOKEN_BURN}} },
 	NFTOKEN_CREATE_OFFER: func() Transaction { return &NFTokenCreateOffer{TxBase: TxBase{TransactionType: NFTOKEN_CREATE_OFFER}} },
-	NFTOKEN_CANCEL_OFFER: func() Transaction { return &NFTCancelOffer{TxBase: TxBase{TransactionType: NFTOKEN_CANCEL_OFFER}} },
-	NFTOKEN_ACCEPT_OFFER: func() Transaction { return &NFTAcceptOffer{TxBase: TxBase{TransactionType: NFTOKEN_ACCEPT_OFFER}} },
+	NFTOKEN_CANCEL_OFFER: func() Transaction { return &NFTokenCancelOffer{TxBase: TxBase{TransactionType: NFTOKEN_CANCEL_OFFER}} },
+	NFTOKEN_ACCEPT_OFFER: func() Transaction { return &NFTokenAcceptOffer{TxBase: TxBase{TransactionType: NFTOKEN_ACCEPT_OFFER}} },
+	CLAWBACK:             func() Transaction { return &Clawback{TxBase: TxBase{TransactionType: CLAWBACK}} },
+	AMM_CREATE:           func() Transaction { return &AMMCreate{TxBase: TxBase{TransactionType: AMM_CREATE}} },
+	AMM_DEPOSIT:          func() Transaction { return &AMMDeposit{TxBase: TxBase{TransactionType: AMM_DEPOSIT}} },
+	AMM_WITHDRAW:         func() Transaction { return &AMMWithdraw{TxBase: TxBase{TransactionType: AMM_WITHDRAW}} },
+	AMM_VOTE:             func() Transaction { return &AMMVote{TxBase: TxBase{TransactionType: AMM_VOTE}} },
+	AMM_BID:              func() Transaction { return &AMMBid{TxBase: TxBase{TransactionType: AMM_BID}} },
+	AMM_DELETE:           func() Transaction { return &AMMDelete{TxBase: TxBase{TransactionType: AMM_DELETE}} },
+	XCHAIN_CREATE_CLAIM_ID: func() Transaction {
+		return &XChainCreateClaimID{TxBase: TxBase{TransactionType: XCHAIN_CREATE_CLAIM_ID}}
+	},
+	XCHAIN_COMMIT: func() Transaction { return &XChainCommit{TxBase: TxBase{TransactionType: XCHAIN_COMMIT}} },
+	XCHAIN_CLAIM:  func() Transaction { return &XChainClaim{TxBase: TxBase{TransactionType: XCHAIN_CLAIM}} },
+	XCHAIN_ADD_CLAIM_ATTESTATION: func() Transaction {
+		return &XChainAddClaimAttestation{TxBase: TxBase{TransactionType: XCHAIN_ADD_CLAIM_ATTESTATION}}
+	},
+	XCHAIN_CREATE_BRIDGE: func() Transaction { return &XChainCreateBridge{TxBase: TxBase{TransactionType: XCHAIN_CREATE_BRIDGE}} },
+	DID_SET:              func() Transaction { return &DIDSet{TxBase: TxBase{TransactionType: DID_SET}} },
+	DID_DELETE:           func() Transaction { return &DIDDelete{TxBase: TxBase{TransactionType: DID_DELETE}} },
+	ORACLE_SET:           func() Transaction { return &OracleSet{TxBase: TxBase{TransactionType: ORACLE_SET}} },
+	ORACLE_DELETE:        func() Transaction { return &OracleDelete{TxBase: TxBase{TransactionType: ORACLE_DELETE}} },
+	HOOK_SET:             func() Transaction { return &SetHook{TxBase: TxBase{TransactionType: HOOK_SET}} },
 }
 
 var ledgerEntryNames = [...]string{
@@ -148,63 +188,97 @@ var ledgerEntryTypes = map[string]LedgerEntryType{
 }
 
 var txNames = [...]string{
-	PAYMENT:              "Payment",
-	ACCOUNT_SET:          "AccountSet",
-	ACCOUNT_DELETE:       "AccountDelete",
-	SET_REGULAR_KEY:      "SetRegularKey",
-	OFFER_CREATE:         "OfferCreate",
-	OFFER_CANCEL:         "OfferCancel",
-	TRUST_SET:            "TrustSet",
-	AMENDMENT:            "EnableAmendment",
-	SET_FEE:              "SetFee",
-	UNL_MODIFY:           "UNLModify",
-	TICKET_CREATE:        "TicketCreate",
-	ESCROW_CREATE:        "EscrowCreate",
-	ESCROW_FINISH:        "EscrowFinish",
-	ESCROW_CANCEL:        "EscrowCancel",
-	SIGNER_LIST_SET:      "SignerListSet",
-	PAYCHAN_CREATE:       "PaymentChannelCreate",
-	PAYCHAN_FUND:         "PaymentChannelFund",
-	PAYCHAN_CLAIM:        "PaymentChannelClaim",
-	CHECK_CREATE:         "CheckCreate",
-	CHECK_CASH:           "CheckCash",
-	CHECK_CANCEL:         "CheckCancel",
-	SET_DEPOSIT_PREAUTH:  "DepositPreauth",
-	NFTOKEN_MINT:         "NFTokenMint",
-	NFTOKEN_BURN:         "NFTokenBurn",
-	NFTOKEN_CREATE_OFFER: "NFTokenCreateOffer",
-	NFTOKEN_CANCEL_OFFER: "NFTokenCancelOffer",
-	NFTOKEN_ACCEPT_OFFER: "NFTokenAcceptOffer",
+	PAYMENT:                      "Payment",
+	ACCOUNT_SET:                  "AccountSet",
+	ACCOUNT_DELETE:               "AccountDelete",
+	SET_REGULAR_KEY:              "SetRegularKey",
+	OFFER_CREATE:                 "OfferCreate",
+	OFFER_CANCEL:                 "OfferCancel",
+	TRUST_SET:                    "TrustSet",
+	AMENDMENT:                    "EnableAmendment",
+	SET_FEE:                      "SetFee",
+	UNL_MODIFY:                   "UNLModify",
+	TICKET_CREATE:                "TicketCreate",
+	ESCROW_CREATE:                "EscrowCreate",
+	ESCROW_FINISH:                "EscrowFinish",
+	ESCROW_CANCEL:                "EscrowCancel",
+	SIGNER_LIST_SET:              "SignerListSet",
+	PAYCHAN_CREATE:               "PaymentChannelCreate",
+	PAYCHAN_FUND:                 "PaymentChannelFund",
+	PAYCHAN_CLAIM:                "PaymentChannelClaim",
+	CHECK_CREATE:                 "CheckCreate",
+	CHECK_CASH:                   "CheckCash",
+	CHECK_CANCEL:                 "CheckCancel",
+	SET_DEPOSIT_PREAUTH:          "DepositPreauth",
+	NFTOKEN_MINT:                 "NFTokenMint",
+	NFTOKEN_BURN:                 "NFTokenBurn",
+	NFTOKEN_CREATE_OFFER:         "NFTokenCreateOffer",
+	NFTOKEN_CANCEL_OFFER:         "NFTokenCancelOffer",
+	NFTOKEN_ACCEPT_OFFER:         "NFTokenAcceptOffer",
+	CLAWBACK:                     "Clawback",
+	AMM_CREATE:                   "AMMCreate",
+	AMM_DEPOSIT:                  "AMMDeposit",
+	AMM_WITHDRAW:                 "AMMWithdraw",
+	AMM_VOTE:                     "AMMVote",
+	AMM_BID:                      "AMMBid",
+	AMM_DELETE:                   "AMMDelete",
+	XCHAIN_CREATE_CLAIM_ID:       "XChainCreateClaimID",
+	XCHAIN_COMMIT:                "XChainCommit",
+	XCHAIN_CLAIM:                 "XChainClaim",
+	XCHAIN_ADD_CLAIM_ATTESTATION: "XChainAddClaimAttestation",
+	XCHAIN_CREATE_BRIDGE:         "XChainCreateBridge",
+	DID_SET:                      "DIDSet",
+	DID_DELETE:                   "DIDDelete",
+	ORACLE_SET:                   "OracleSet",
+	ORACLE_DELETE:                "OracleDelete",
+	HOOK_SET:                     "SetHook",
 }
 
 var txTypes = map[string]TransactionType{
-	"Payment":              PAYMENT,
-	"AccountSet":           ACCOUNT_SET,
-	"AccountDelete":        ACCOUNT_DELETE,
-	"SetRegularKey":        SET_REGULAR_KEY,
-	"OfferCreate":          OFFER_CREATE,
-	"OfferCancel":          OFFER_CANCEL,
-	"TrustSet":             TRUST_SET,
-	"EnableAmendment":      AMENDMENT,
-	"SetFee":               SET_FEE,
-	"UNLModify":            UNL_MODIFY,
-	"TicketCreate":         TICKET_CREATE,
-	"EscrowCreate":         ESCROW_CREATE,
-	"EscrowFinish":         ESCROW_FINISH,
-	"EscrowCancel":         ESCROW_CANCEL,
-	"SignerListSet":        SIGNER_LIST_SET,
-	"PaymentChannelCreate": PAYCHAN_CREATE,
-	"PaymentChannelFund":   PAYCHAN_FUND,
-	"PaymentChannelClaim":  PAYCHAN_CLAIM,
-	"CheckCreate":          CHECK_CREATE,
-	"CheckCash":            CHECK_CASH,
-	"CheckCancel":          CHECK_CANCEL,
-	"DepositPreauth":       SET_DEPOSIT_PREAUTH,
-	"NFTokenMint":          NFTOKEN_MINT,
-	"NFTokenBurn":          NFTOKEN_BURN,
-	"NFTokenCreateOffer":   NFTOKEN_CREATE_OFFER,
-	"NFTokenCancelOffer":   NFTOKEN_CANCEL_OFFER,
-	"NFTokenAcceptOffer":   NFTOKEN_ACCEPT_OFFER,
+	"Payment":                   PAYMENT,
+	"AccountSet":                ACCOUNT_SET,
+	"AccountDelete":             ACCOUNT_DELETE,
+	"SetRegularKey":             SET_REGULAR_KEY,
+	"OfferCreate":               OFFER_CREATE,
+	"OfferCancel":               OFFER_CANCEL,
+	"TrustSet":                  TRUST_SET,
+	"EnableAmendment":           AMENDMENT,
+	"SetFee":                    SET_FEE,
+	"UNLModify":                 UNL_MODIFY,
+	"TicketCreate":              TICKET_CREATE,
+	"EscrowCreate":              ESCROW_CREATE,
+	"EscrowFinish":              ESCROW_FINISH,
+	"EscrowCancel":              ESCROW_CANCEL,
+	"SignerListSet":             SIGNER_LIST_SET,
+	"PaymentChannelCreate":      PAYCHAN_CREATE,
+	"PaymentChannelFund":        PAYCHAN_FUND,
+	"PaymentChannelClaim":       PAYCHAN_CLAIM,
+	"CheckCreate":               CHECK_CREATE,
+	"CheckCash":                 CHECK_CASH,
+	"CheckCancel":               CHECK_CANCEL,
+	"DepositPreauth":            SET_DEPOSIT_PREAUTH,
+	"NFTokenMint":               NFTOKEN_MINT,
+	"NFTokenBurn":               NFTOKEN_BURN,
+	"NFTokenCreateOffer":        NFTOKEN_CREATE_OFFER,
+	"NFTokenCancelOffer":        NFTOKEN_CANCEL_OFFER,
+	"NFTokenAcceptOffer":        NFTOKEN_ACCEPT_OFFER,
+	"Clawback":                  CLAWBACK,
+	"AMMCreate":                 AMM_CREATE,
+	"AMMDeposit":                AMM_DEPOSIT,
+	"AMMWithdraw":               AMM_WITHDRAW,
+	"AMMVote":                   AMM_VOTE,
+	"AMMBid":                    AMM_BID,
+	"AMMDelete":                 AMM_DELETE,
+	"XChainCreateClaimID":       XCHAIN_CREATE_CLAIM_ID,
+	"XChainCommit":              XCHAIN_COMMIT,
+	"XChainClaim":               XCHAIN_CLAIM,
+	"XChainAddClaimAttestation": XCHAIN_ADD_CLAIM_ATTESTATION,
+	"XChainCreateBridge":        XCHAIN_CREATE_BRIDGE,
+	"DIDSet":                    DID_SET,
+	"DIDDelete":                 DID_DELETE,
+	"OracleSet":                 ORACLE_SET,
+	"OracleDelete":              ORACLE_DELETE,
+	"SetHook":                   HOOK_SET,
 }
 
 var HashableTypes []string
@@ -232,6 +306,24 @@ func (le LedgerEntryType) String() string {
 	return ledgerEntryNames[le]
 }
 
+// newTransaction returns an empty transaction of type t, or an error for
+// types without a factory, which are common in untrusted input
+func newTransaction(t TransactionType) (Transaction, error) {
+	if int(t) >= len(TxFactory) || TxFactory[t] == nil {
+		return nil, fmt.Errorf("Unknown TransactionType: %d", t)
+	}
+	return TxFactory[t](), nil
+}
+
+// NewLedgerEntry returns an empty ledger entry of type t, or an error for
+// types without a factory, such as the zero type of a node without one
+func NewLedgerEntry(t LedgerEntryType) (LedgerEntry, error) {
+	if int(t) >= len(LedgerEntryFactory) || LedgerEntryFactory[t] == nil {
+		return nil, fmt.Errorf("Unknown LedgerEntryType: %d", t)
+	}
+	return LedgerEntryFactory[t](), nil
+}
+
 func GetTxFactoryByType(txType string) func() Transaction {
 	return TxFactory[txTypes[txType]]
 }
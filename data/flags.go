@@ -18,21 +18,30 @@ const (
 	TxLimitQuality   TransactionFlag = 0x00040000
 	TxCircle         TransactionFlag = 0x00080000 // Not implemented
 
+	// AccountSet SetFlag and ClearFlag values, which are not valid Flags
+	TxSetRequireDest               TransactionFlag = 0x00000001
+	TxSetRequireAuth               TransactionFlag = 0x00000002
+	TxSetDisallowXRP               TransactionFlag = 0x00000003
+	TxSetDisableMaster             TransactionFlag = 0x00000004
+	TxSetAccountTxnID              TransactionFlag = 0x00000005
+	TxNoFreeze                     TransactionFlag = 0x00000006
+	TxGlobalFreeze                 TransactionFlag = 0x00000007
+	TxDefaultRipple                TransactionFlag = 0x00000008
+	TxDepositAuth                  TransactionFlag = 0x00000009
+	TxAuthorizedNFTokenMinter      TransactionFlag = 0x0000000A // Requires NFTokenMinter when set
+	TxDisallowIncomingNFTokenOffer TransactionFlag = 0x0000000C
+	TxDisallowIncomingCheck        TransactionFlag = 0x0000000D
+	TxDisallowIncomingPayChan      TransactionFlag = 0x0000000E
+	TxDisallowIncomingTrustline    TransactionFlag = 0x0000000F
+	TxAllowTrustLineClawback       TransactionFlag = 0x00000010
+
 	// AccountSet flags
-	TxSetRequireDest   TransactionFlag = 0x00000001
-	TxSetRequireAuth   TransactionFlag = 0x00000002
-	TxSetDisallowXRP   TransactionFlag = 0x00000003
-	TxSetDisableMaster TransactionFlag = 0x00000004
-	TxSetAccountTxnID  TransactionFlag = 0x00000005
-	TxNoFreeze         TransactionFlag = 0x00000006
-	TxGlobalFreeze     TransactionFlag = 0x00000007
-	TxDefaultRipple    TransactionFlag = 0x00000008
-	TxRequireDestTag   TransactionFlag = 0x00010000
-	TxOptionalDestTag  TransactionFlag = 0x00020000
-	TxRequireAuth      TransactionFlag = 0x00040000
-	TxOptionalAuth     TransactionFlag = 0x00080000
-	TxDisallowXRP      TransactionFlag = 0x00100000
-	TxAllowXRP         TransactionFlag = 0x00200000
+	TxRequireDestTag  TransactionFlag = 0x00010000
+	TxOptionalDestTag TransactionFlag = 0x00020000
+	TxRequireAuth     TransactionFlag = 0x00040000
+	TxOptionalAuth    TransactionFlag = 0x00080000
+	TxDisallowXRP     TransactionFlag = 0x00100000
+	TxAllowXRP        TransactionFlag = 0x00200000
 
 	// OfferCreate flags
 	TxPassive           TransactionFlag = 0x00010000
@@ -41,11 +50,13 @@ const (
 	TxSell              TransactionFlag = 0x00080000
 
 	// TrustSet flags
-	TxSetAuth       TransactionFlag = 0x00010000
-	TxSetNoRipple   TransactionFlag = 0x00020000
-	TxClearNoRipple TransactionFlag = 0x00040000
-	TxSetFreeze     TransactionFlag = 0x00100000
-	TxClearFreeze   TransactionFlag = 0x00200000
+	TxSetAuth         TransactionFlag = 0x00010000
+	TxSetNoRipple     TransactionFlag = 0x00020000
+	TxClearNoRipple   TransactionFlag = 0x00040000
+	TxSetFreeze       TransactionFlag = 0x00100000
+	TxClearFreeze     TransactionFlag = 0x00200000
+	TxSetDeepFreeze   TransactionFlag = 0x00400000
+	TxClearDeepFreeze TransactionFlag = 0x00800000
 
 	// EnableAmendments flags
 	TxGotMajority  TransactionFlag = 0x00010000
@@ -54,24 +65,53 @@ const (
 	// PaymentChannelClaim flags
 	TxRenew TransactionFlag = 0x00010000
 	TxClose TransactionFlag = 0x00020000
+
+	// NFTokenMint flags
+	TxBurnable     TransactionFlag = 0x00000001
+	TxOnlyXRP      TransactionFlag = 0x00000002
+	TxTrustLine    TransactionFlag = 0x00000004
+	TxTransferable TransactionFlag = 0x00000008
+	TxMutable      TransactionFlag = 0x00000010 // With DynamicNFT, allows NFTokenModify
+
+	// NFTokenCreateOffer flags
+	TxSellNFToken TransactionFlag = 0x00000001
+
+	// AMMDeposit and AMMWithdraw flags
+	TxLPToken             TransactionFlag = 0x00010000
+	TxWithdrawAll         TransactionFlag = 0x00020000 // AMMWithdraw only
+	TxOneAssetWithdrawAll TransactionFlag = 0x00040000 // AMMWithdraw only
+	TxSingleAsset         TransactionFlag = 0x00080000
+	TxTwoAsset            TransactionFlag = 0x00100000
+	TxOneAssetLPToken     TransactionFlag = 0x00200000
+	TxLimitLPToken        TransactionFlag = 0x00400000
+	TxTwoAssetIfEmpty     TransactionFlag = 0x00800000 // AMMDeposit only
 )
 
 // Ledger entry flags
 const (
 	// AccountRoot flags
-	LsPasswordSpent  LedgerEntryFlag = 0x00010000
-	LsRequireDestTag LedgerEntryFlag = 0x00020000
-	LsRequireAuth    LedgerEntryFlag = 0x00040000
-	LsDisallowXRP    LedgerEntryFlag = 0x00080000
-	LsDisableMaster  LedgerEntryFlag = 0x00100000
-	LsNoFreeze       LedgerEntryFlag = 0x00200000
-	LsGlobalFreeze   LedgerEntryFlag = 0x00400000
-	LsDefaultRipple  LedgerEntryFlag = 0x00800000
+	LsPasswordSpent                LedgerEntryFlag = 0x00010000
+	LsRequireDestTag               LedgerEntryFlag = 0x00020000
+	LsRequireAuth                  LedgerEntryFlag = 0x00040000
+	LsDisallowXRP                  LedgerEntryFlag = 0x00080000
+	LsDisableMaster                LedgerEntryFlag = 0x00100000
+	LsNoFreeze                     LedgerEntryFlag = 0x00200000
+	LsGlobalFreeze                 LedgerEntryFlag = 0x00400000
+	LsDefaultRipple                LedgerEntryFlag = 0x00800000
+	LsDepositAuth                  LedgerEntryFlag = 0x01000000
+	LsDisallowIncomingNFTokenOffer LedgerEntryFlag = 0x04000000
+	LsDisallowIncomingCheck        LedgerEntryFlag = 0x08000000
+	LsDisallowIncomingPayChan      LedgerEntryFlag = 0x10000000
+	LsDisallowIncomingTrustline    LedgerEntryFlag = 0x20000000
+	LsAllowTrustLineClawback       LedgerEntryFlag = 0x80000000
 
 	// Offer flags
 	LsPassive LedgerEntryFlag = 0x00010000
 	LsSell    LedgerEntryFlag = 0x00020000
 
+	// NFTokenOffer flags
+	LsSellNFToken LedgerEntryFlag = 0x00000001
+
 	// RippleState flags
 	LsLowReserve   LedgerEntryFlag = 0x00010000
 	LsHighReserve  LedgerEntryFlag = 0x00020000
@@ -83,6 +123,15 @@ const (
 	LsHighFreeze   LedgerEntryFlag = 0x00800000
 )
 
+// The Flags of a Hook in a SetHook, rather than of the transaction
+type HookFlag uint32
+
+const (
+	HookOverride        HookFlag = 0x00000001 // Replace or delete the hook in place
+	HookNamespaceDelete HookFlag = 0x00000002 // Delete the state of the namespace
+	HookCollect         HookFlag = 0x00000004 // Run again after the transaction
+)
+
 var txFlagNames = map[TransactionType][]struct {
 	Flag TransactionFlag
 	Name string
@@ -94,15 +143,10 @@ var txFlagNames = map[TransactionType][]struct {
 		{TxCircle, "Circle"},
 	},
 	ACCOUNT_SET: {
-		{TxSetRequireDest, "SetRequireDest"},
-		{TxSetRequireAuth, "SetRequireAuth"},
-		{TxSetDisallowXRP, "SetDisallowXRP"},
-		{TxSetDisableMaster, "SetDisableMaster"},
-		{TxNoFreeze, "NoFreeze"},
-		{TxGlobalFreeze, "GlobalFreeze"},
 		{TxRequireDestTag, "RequireDestTag"},
 		{TxOptionalDestTag, "OptionalDestTag"},
 		{TxRequireAuth, "RequireAuth"},
+		{TxOptionalAuth, "OptionalAuth"},
 		{TxDisallowXRP, "DisallowXRP"},
 		{TxAllowXRP, "AllowXRP"},
 	},
@@ -118,6 +162,43 @@ var txFlagNames = map[TransactionType][]struct {
 		{TxClearNoRipple, "ClearNoRipple"},
 		{TxSetFreeze, "SetFreeze"},
 		{TxClearFreeze, "ClearFreeze"},
+		{TxSetDeepFreeze, "SetDeepFreeze"},
+		{TxClearDeepFreeze, "ClearDeepFreeze"},
+	},
+	AMENDMENT: {
+		{TxGotMajority, "GotMajority"},
+		{TxLostMajority, "LostMajority"},
+	},
+	PAYCHAN_CLAIM: {
+		{TxRenew, "Renew"},
+		{TxClose, "Close"},
+	},
+	NFTOKEN_MINT: {
+		{TxBurnable, "Burnable"},
+		{TxOnlyXRP, "OnlyXRP"},
+		{TxTrustLine, "TrustLine"},
+		{TxTransferable, "Transferable"},
+		{TxMutable, "Mutable"},
+	},
+	NFTOKEN_CREATE_OFFER: {
+		{TxSellNFToken, "SellNFToken"},
+	},
+	AMM_DEPOSIT: {
+		{TxLPToken, "LPToken"},
+		{TxSingleAsset, "SingleAsset"},
+		{TxTwoAsset, "TwoAsset"},
+		{TxOneAssetLPToken, "OneAssetLPToken"},
+		{TxLimitLPToken, "LimitLPToken"},
+		{TxTwoAssetIfEmpty, "TwoAssetIfEmpty"},
+	},
+	AMM_WITHDRAW: {
+		{TxLPToken, "LPToken"},
+		{TxWithdrawAll, "WithdrawAll"},
+		{TxOneAssetWithdrawAll, "OneAssetWithdrawAll"},
+		{TxSingleAsset, "SingleAsset"},
+		{TxTwoAsset, "TwoAsset"},
+		{TxOneAssetLPToken, "OneAssetLPToken"},
+		{TxLimitLPToken, "LimitLPToken"},
 	},
 }
 
@@ -132,11 +213,22 @@ var leFlagNames = map[LedgerEntryType][]struct {
 		{LsDisallowXRP, "DisallowXRP"},
 		{LsDisableMaster, "DisableMaster"},
 		{LsNoFreeze, "NoFreeze"},
+		{LsGlobalFreeze, "GlobalFreeze"},
+		{LsDefaultRipple, "DefaultRipple"},
+		{LsDepositAuth, "DepositAuth"},
+		{LsDisallowIncomingNFTokenOffer, "DisallowIncomingNFTokenOffer"},
+		{LsDisallowIncomingCheck, "DisallowIncomingCheck"},
+		{LsDisallowIncomingPayChan, "DisallowIncomingPayChan"},
+		{LsDisallowIncomingTrustline, "DisallowIncomingTrustline"},
+		{LsAllowTrustLineClawback, "AllowTrustLineClawback"},
 	},
 	OFFER: {
 		{LsPassive, "Passive"},
 		{LsSell, "Sell"},
 	},
+	NFTOKEN_OFFER: {
+		{LsSellNFToken, "SellNFToken"},
+	},
 	RIPPLE_STATE: {
 		{LsLowReserve, "LowReserve"},
 		{LsHighReserve, "HighReserve"},
@@ -170,6 +262,22 @@ func (f TransactionFlag) Explain(tx Transaction) []string {
 	return flags
 }
 
+// ValidateFlags returns an error if Flags has any bits set which are not
+// valid for the transaction type
+func (t *TxBase) ValidateFlags() error {
+	if t.Flags == nil {
+		return nil
+	}
+	valid := TxCanonicalSignature
+	for _, n := range txFlagNames[t.TransactionType] {
+		valid |= n.Flag
+	}
+	if invalid := *t.Flags &^ valid; invalid != 0 {
+		return fmt.Errorf("Invalid flags for %s: %s", t.GetType(), invalid)
+	}
+	return nil
+}
+
 func (f LedgerEntryFlag) Explain(le LedgerEntry) []string {
 	var flags []string
 	for _, n := range leFlagNames[le.GetLedgerEntryType()] {
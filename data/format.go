@@ -23,6 +23,7 @@ const (
 	HP_TRANSACTION_MULTISIGN HashPrefix = 0x534D5400 // 'SMT' inner transaction to multi-sign
 	HP_VALIDATION            HashPrefix = 0x56414C00 // 'VAL' validation for signing
 	HP_PROPOSAL              HashPrefix = 0x50525000 // 'PRP' proposal for signing
+	HP_PAYMENT_CHANNEL_CLAIM HashPrefix = 0x434C4D00 // 'CLM' payment channel claim
 
 	// Node Types
 	NT_UNKNOWN          NodeType = 0
@@ -74,24 +75,27 @@ type enc struct {
 }
 
 const (
-	ST_UINT16    uint8 = 1
-	ST_UINT32    uint8 = 2
-	ST_UINT64    uint8 = 3
-	ST_HASH128   uint8 = 4
-	ST_HASH256   uint8 = 5
-	ST_AMOUNT    uint8 = 6
-	ST_VL        uint8 = 7
-	ST_ACCOUNT   uint8 = 8
-	ST_OBJECT    uint8 = 14
-	ST_ARRAY     uint8 = 15
-	ST_UINT8     uint8 = 16
-	ST_HASH160   uint8 = 17
-	ST_PATHSET   uint8 = 18
-	ST_VECTOR256 uint8 = 19
-	ST_HASH96    uint8 = 20
-	ST_HASH192   uint8 = 21
-	ST_HASH384   uint8 = 22
-	ST_HASH512   uint8 = 23
+	ST_UINT16        uint8 = 1
+	ST_UINT32        uint8 = 2
+	ST_UINT64        uint8 = 3
+	ST_HASH128       uint8 = 4
+	ST_HASH256       uint8 = 5
+	ST_AMOUNT        uint8 = 6
+	ST_VL            uint8 = 7
+	ST_ACCOUNT       uint8 = 8
+	ST_OBJECT        uint8 = 14
+	ST_ARRAY         uint8 = 15
+	ST_UINT8         uint8 = 16
+	ST_HASH160       uint8 = 17
+	ST_PATHSET       uint8 = 18
+	ST_VECTOR256     uint8 = 19
+	ST_HASH96        uint8 = 20
+	ST_HASH192       uint8 = 21
+	ST_HASH384       uint8 = 22
+	ST_HASH512       uint8 = 23
+	ST_ISSUE         uint8 = 24
+	ST_XCHAIN_BRIDGE uint8 = 25
+	ST_CURRENCY      uint8 = 26
 )
 
 // See rippled's SField.cpp for the strings and corresponding encoding values.
@@ -101,8 +105,10 @@ var encodings = map[enc]string{
 	{ST_UINT16, 2}: "TransactionType",
 	{ST_UINT16, 3}: "SignerWeight",
 	{ST_UINT16, 4}: "TransferFee",
+	{ST_UINT16, 5}: "TradingFee",
 	// 16-bit unsigned integers (uncommon)
 	{ST_UINT16, 16}: "Version",
+	{ST_UINT16, 20}: "HookApiVersion",
 	// 32-bit unsigned integers (common)
 	{ST_UINT32, 2}:  "Flags",
 	{ST_UINT32, 3}:  "SourceTag",
@@ -117,6 +123,7 @@ var encodings = map[enc]string{
 	{ST_UINT32, 12}: "WalletSize",
 	{ST_UINT32, 13}: "OwnerCount",
 	{ST_UINT32, 14}: "DestinationTag",
+	{ST_UINT32, 15}: "LastUpdateTime",
 	// 32-bit unsigned integers (uncommon)
 	{ST_UINT32, 16}: "HighQualityIn",
 	{ST_UINT32, 17}: "HighQualityOut",
@@ -147,6 +154,7 @@ var encodings = map[enc]string{
 	{ST_UINT32, 42}: "NFTokenTaxon",
 	{ST_UINT32, 43}: "MintedNFTokens",
 	{ST_UINT32, 44}: "BurnedNFTokens",
+	{ST_UINT32, 51}: "OracleDocumentID",
 	// 64-bit unsigned integers (common)
 	{ST_UINT64, 1}:  "IndexNext",
 	{ST_UINT64, 2}:  "IndexPrevious",
@@ -160,6 +168,8 @@ var encodings = map[enc]string{
 	{ST_UINT64, 10}: "Cookie",
 	{ST_UINT64, 11}: "ServerVersion",
 	{ST_UINT64, 12}: "NFTokenOfferNode",
+	{ST_UINT64, 20}: "XChainClaimID",
+	{ST_UINT64, 23}: "AssetPrice",
 	// 128-bit (common)
 	{ST_HASH128, 1}: "EmailHash",
 	// 256-bit (common)
@@ -178,7 +188,7 @@ var encodings = map[enc]string{
 	{ST_HASH256, 17}: "InvoiceID",
 	{ST_HASH256, 18}: "Nickname",
 	{ST_HASH256, 19}: "Amendment",
-	{ST_HASH256, 20}: "TicketID",
+	{ST_HASH256, 20}: "HookOn", // Retired TicketID, reused by Hooks networks
 	{ST_HASH256, 21}: "Digest",
 	{ST_HASH256, 22}: "Channel",
 	{ST_HASH256, 24}: "CheckID",
@@ -187,6 +197,8 @@ var encodings = map[enc]string{
 	{ST_HASH256, 27}: "NextPageMin",
 	{ST_HASH256, 28}: "NFTokenBuyOffer",
 	{ST_HASH256, 29}: "NFTokenSellOffer",
+	{ST_HASH256, 31}: "HookHash",
+	{ST_HASH256, 32}: "HookNamespace",
 	// currency amount (common)
 	{ST_AMOUNT, 1}:  "Amount",
 	{ST_AMOUNT, 2}:  "Balance",
@@ -198,11 +210,19 @@ var encodings = map[enc]string{
 	{ST_AMOUNT, 8}:  "Fee",
 	{ST_AMOUNT, 9}:  "SendMax",
 	{ST_AMOUNT, 10}: "DeliverMin",
+	{ST_AMOUNT, 11}: "Amount2",
+	{ST_AMOUNT, 12}: "BidMin",
+	{ST_AMOUNT, 13}: "BidMax",
 	// currency amount (uncommon)
 	{ST_AMOUNT, 16}: "MinimumOffer",
 	{ST_AMOUNT, 17}: "RippleEscrow",
 	{ST_AMOUNT, 18}: "DeliveredAmount",
 	{ST_AMOUNT, 19}: "NFTokenBrokerFee",
+	{ST_AMOUNT, 25}: "LPTokenOut",
+	{ST_AMOUNT, 26}: "LPTokenIn",
+	{ST_AMOUNT, 27}: "EPrice",
+	{ST_AMOUNT, 29}: "SignatureReward",
+	{ST_AMOUNT, 30}: "MinAccountCreateAmount",
 	// variable length (common)
 	{ST_VL, 1}:  "PublicKey",
 	{ST_VL, 2}:  "MessageKey",
@@ -225,6 +245,12 @@ var encodings = map[enc]string{
 	{ST_VL, 19}: "UNLModifyValidator",
 	{ST_VL, 20}: "ValidatorToDisable",
 	{ST_VL, 21}: "ValidatorToReEnable",
+	{ST_VL, 24}: "HookParameterName",
+	{ST_VL, 25}: "HookParameterValue",
+	{ST_VL, 26}: "DIDDocument",
+	{ST_VL, 27}: "Data",
+	{ST_VL, 28}: "AssetClass",
+	{ST_VL, 29}: "Provider",
 	// account
 	{ST_ACCOUNT, 1}: "Account",
 	{ST_ACCOUNT, 2}: "Owner",
@@ -235,6 +261,11 @@ var encodings = map[enc]string{
 	{ST_ACCOUNT, 7}: "Target",
 	{ST_ACCOUNT, 8}: "RegularKey",
 	{ST_ACCOUNT, 9}: "NFTokenMinter",
+	// account (uncommon)
+	{ST_ACCOUNT, 18}: "OtherChainSource",
+	{ST_ACCOUNT, 19}: "OtherChainDestination",
+	{ST_ACCOUNT, 20}: "AttestationSignerAccount",
+	{ST_ACCOUNT, 21}: "AttestationRewardAccount",
 	// inner object
 	{ST_OBJECT, 1}:  "EndOfObject",
 	{ST_OBJECT, 2}:  "TransactionMetaData",
@@ -248,10 +279,15 @@ var encodings = map[enc]string{
 	{ST_OBJECT, 10}: "Memo",
 	{ST_OBJECT, 11}: "SignerEntry",
 	{ST_OBJECT, 12}: "NFToken",
+	{ST_OBJECT, 14}: "Hook",
 	// inner object (uncommon)
 	{ST_OBJECT, 16}: "Signer",
 	{ST_OBJECT, 18}: "Majority",
 	{ST_OBJECT, 19}: "DisabledValidator",
+	{ST_OBJECT, 23}: "HookParameter",
+	{ST_OBJECT, 24}: "HookGrant",
+	{ST_OBJECT, 27}: "AuthAccount",
+	{ST_OBJECT, 32}: "PriceData",
 	// array of objects
 	{ST_ARRAY, 1}:  "EndOfArray",
 	{ST_ARRAY, 2}:  "SigningAccounts",
@@ -263,16 +299,23 @@ var encodings = map[enc]string{
 	{ST_ARRAY, 8}:  "AffectedNodes",
 	{ST_ARRAY, 9}:  "Memos",
 	{ST_ARRAY, 10}: "NFTokens",
+	{ST_ARRAY, 11}: "Hooks",
 	// array of objects (uncommon)
 	{ST_ARRAY, 16}: "Majorities",
 	{ST_ARRAY, 17}: "DisabledValidators",
+	{ST_ARRAY, 19}: "HookParameters",
+	{ST_ARRAY, 20}: "HookGrants",
+	{ST_ARRAY, 24}: "PriceDataSeries",
+	{ST_ARRAY, 25}: "AuthAccounts",
 	// 8-bit unsigned integers (common)
 	{ST_UINT8, 1}: "CloseResolution",
 	{ST_UINT8, 2}: "Method",
 	{ST_UINT8, 3}: "TransactionResult",
+	{ST_UINT8, 4}: "Scale",
 	// 8-bit unsigned integers (uncommon)
 	{ST_UINT8, 16}: "TickSize",
 	{ST_UINT8, 17}: "UNLModifyDisabling",
+	{ST_UINT8, 19}: "WasLockingChainSend",
 	// 160-bit (common)
 	{ST_HASH160, 1}: "TakerPaysCurrency",
 	{ST_HASH160, 2}: "TakerPaysIssuer",
@@ -285,6 +328,14 @@ var encodings = map[enc]string{
 	{ST_VECTOR256, 2}: "Hashes",
 	{ST_VECTOR256, 3}: "Amendments",
 	{ST_VECTOR256, 4}: "NFTokenOffers",
+	// issue
+	{ST_ISSUE, 3}: "Asset",
+	{ST_ISSUE, 4}: "Asset2",
+	// cross-chain bridge
+	{ST_XCHAIN_BRIDGE, 1}: "XChainBridge",
+	// currency
+	{ST_CURRENCY, 1}: "BaseAsset",
+	{ST_CURRENCY, 2}: "QuoteAsset",
 }
 
 var reverseEncodings map[string]enc
@@ -295,7 +346,7 @@ func init() {
 	signingFields = make(map[enc]struct{})
 	for e, name := range encodings {
 		reverseEncodings[name] = e
-		if strings.Contains(name, "Signature") {
+		if strings.Contains(name, "Signature") || name == "Signers" {
 			signingFields[e] = struct{}{}
 		}
 	}
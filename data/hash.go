@@ -33,6 +33,7 @@ type Hash256 [32]byte
 type Vector256 []Hash256
 type VariableLength []byte
 type PublicKey [33]byte
+type NodePublicKey [33]byte
 type Account [20]byte
 type RegularKey [20]byte
 type Seed [16]byte
@@ -167,6 +168,14 @@ func (p PublicKey) NodePublicKey() string {
 	return hash.String()
 }
 
+// AccountId returns the id derived from p, which is the Account when p is
+// a master key and the RegularKey when p is a regular key
+func (p PublicKey) AccountId() Account {
+	var account Account
+	copy(account[:], crypto.Sha256RipeMD160(p[:]))
+	return account
+}
+
 func (p PublicKey) String() string {
 	b, _ := p.MarshalText()
 	return string(b)
@@ -183,6 +192,33 @@ func (p *PublicKey) Bytes() []byte {
 	return []byte(nil)
 }
 
+// Expects a node public key in base58 form
+func NewNodePublicKeyFromAddress(s string) (*NodePublicKey, error) {
+	hash, err := crypto.NewRippleHashCheck(s, crypto.RIPPLE_NODE_PUBLIC)
+	if err != nil {
+		return nil, err
+	}
+	var key NodePublicKey
+	if len(hash.Payload()) != len(key) {
+		return nil, fmt.Errorf("Bad node public key: %s", s)
+	}
+	copy(key[:], hash.Payload())
+	return &key, nil
+}
+
+func (k NodePublicKey) Hash() (crypto.Hash, error) {
+	return crypto.NewNodePublicKey(k[:])
+}
+
+func (k NodePublicKey) PublicKey() PublicKey {
+	return PublicKey(k)
+}
+
+func (k NodePublicKey) String() string {
+	b, _ := k.MarshalText()
+	return string(b)
+}
+
 // Expects address in base58 form
 func NewAccountFromAddress(s string) (*Account, error) {
 	hash, err := crypto.NewRippleHashCheck(s, crypto.RIPPLE_ACCOUNT_ID)
@@ -194,6 +230,13 @@ func NewAccountFromAddress(s string) (*Account, error) {
 	return &account, nil
 }
 
+// IsValidAddress reports whether s is a classic "r..." address, with a
+// correct checksum, version and length
+func IsValidAddress(s string) bool {
+	_, err := NewAccountFromAddress(s)
+	return err == nil
+}
+
 func (a Account) Hash() (crypto.Hash, error) {
 	return crypto.NewAccountId(a[:])
 }
@@ -267,13 +310,41 @@ func (r *RegularKey) Bytes() []byte {
 
 // Expects address in base58 form
 func NewSeedFromAddress(s string) (*Seed, error) {
-	hash, err := crypto.NewRippleHashCheck(s, crypto.RIPPLE_FAMILY_SEED)
+	seed, _, err := ParseSeed(s)
+	return seed, err
+}
+
+// ParseSeed decodes a secp256k1 "s..." or ed25519 "sEd..." family seed
+// along with the key type it is intended for
+func ParseSeed(s string) (*Seed, KeyType, error) {
+	b, ed25519, err := crypto.DecodeSeed(s)
 	if err != nil {
-		return nil, err
+		return nil, ECDSA, err
+	}
+	var seed Seed
+	copy(seed[:], b)
+	if ed25519 {
+		return &seed, Ed25519, nil
+	}
+	return &seed, ECDSA, nil
+}
+
+// GenerateSeed returns a new random seed, along with the key of keyType it
+// derives. Encode(keyType) gives the seed to keep.
+func GenerateSeed(keyType KeyType) (*Seed, crypto.Key, error) {
+	hash, err := crypto.GenerateSeed()
+	if err != nil {
+		return nil, nil, err
 	}
+	seed, key := newSeed(hash.Payload(), keyType)
+	return seed, key, nil
+}
+
+// newSeed returns the seed with payload b and the key of keyType it derives
+func newSeed(b []byte, keyType KeyType) (*Seed, crypto.Key) {
 	var seed Seed
-	copy(seed[:], hash.Payload())
-	return &seed, nil
+	copy(seed[:], b)
+	return &seed, seed.Key(keyType)
 }
 
 func (s Seed) Hash() (crypto.Hash, error) {
@@ -288,6 +359,18 @@ func (s Seed) String() string {
 	return address.String()
 }
 
+// Encode returns the form of the seed which identifies keyType
+func (s Seed) Encode(keyType KeyType) string {
+	if keyType != Ed25519 {
+		return s.String()
+	}
+	address, err := crypto.EncodeEd25519Seed(s[:])
+	if err != nil {
+		return fmt.Sprintf("Bad Address: %s", b2h(s[:]))
+	}
+	return address
+}
+
 func (s *Seed) Bytes() []byte {
 	if s != nil {
 		return s[:]
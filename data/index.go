@@ -28,22 +28,35 @@ func (i *NodeIndex) Next() *NodeIndex {
 func LedgerIndex(le LedgerEntry) (*Hash256, error) {
 	switch v := le.(type) {
 	case *AccountRoot:
-		return GetAccountRootIndex(*v.Account)
+		if v.Account != nil {
+			return GetAccountRootIndex(*v.Account)
+		}
 	case *RippleState:
-		return GetRippleStateIndex(v.LowLimit.Issuer, v.HighLimit.Issuer, v.Balance.Currency)
+		if v.LowLimit != nil && v.HighLimit != nil && v.Balance != nil {
+			return GetRippleStateIndex(v.LowLimit.Issuer, v.HighLimit.Issuer, v.Balance.Currency)
+		}
 	case *Offer:
-		return GetOfferIndex(*v.Account, *v.Sequence)
+		if v.Account != nil && v.Sequence != nil {
+			return GetOfferIndex(*v.Account, *v.Sequence)
+		}
 	case *LedgerHashes:
 		return GetLedgerHashIndex()
 	case *Directory:
-		return GetDirectoryNodeIndex(*v.RootIndex, v.IndexPrevious.Next())
+		if v.RootIndex != nil {
+			return GetDirectoryNodeIndex(*v.RootIndex, v.IndexPrevious.Next())
+		}
 	case *FeeSettings:
 		return buildIndex([]interface{}{NS_FEE})
 	case *Amendments:
 		return buildIndex([]interface{}{NS_AMENDMENT})
-	default:
-		return nil, fmt.Errorf("Unknown LedgerEntry")
 	}
+	// Other entries, such as a SignerList which does not hold its owner,
+	// or ones missing the fields their index is built from, keep the index
+	// they were read with
+	if hash := le.GetHash(); !hash.IsZero() {
+		return hash, nil
+	}
+	return nil, fmt.Errorf("Unknown LedgerEntry")
 }
 
 func GetAccountRootIndex(account Account) (*Hash256, error) {
@@ -72,15 +85,27 @@ func GetOwnerDirectoryIndex(account Account) (*Hash256, error) {
 	return buildIndex([]interface{}{NS_OWNER_DIRECTORY, account.Bytes()})
 }
 
+// The SignerList of account, of which there is at most one, so its
+// SignerListID is always zero
+func GetSignerListIndex(account Account) (*Hash256, error) {
+	return buildIndex([]interface{}{NS_SIGNER_LIST, account.Bytes(), uint32(0)})
+}
+
 func GetBookIndex(paysCurrency, getsCurrency Hash160, paysIssuer, getsIssuer Hash160) (*Hash256, error) {
 	//TODO: change types to Currency and Account
-	index, err := buildIndex([]interface{}{NS_BOOK_DIRECTORY, paysCurrency.Bytes(), getsCurrency.Bytes(), paysCurrency.Bytes(), getsCurrency.Bytes()})
+	index, err := buildIndex([]interface{}{NS_BOOK_DIRECTORY, paysCurrency.Bytes(), getsCurrency.Bytes(), paysIssuer.Bytes(), getsIssuer.Bytes()})
 	if err != nil {
 		return nil, err
 	}
-	var zero [8]byte
-	copy(index[24:], zero[:])
-	return index, nil
+	base := BookBase(*index)
+	return &base, nil
+}
+
+// The directory of the offers in a book, as returned by GetBookIndex, which
+// all have the same quality
+func GetBookDirectoryIndex(book Hash256, quality Quality) *Hash256 {
+	copy(book[24:], quality.Bytes())
+	return &book
 }
 
 func GetFeeIndex() (*Hash256, error) {
@@ -24,6 +24,7 @@ type MultiSignable interface {
 	MultiSigningPrefix() HashPrefix
 	GetPublicKey() *PublicKey
 	GetSignature() *VariableLength
+	GetSigners() []Signer
 	SetSigners([]Signer)
 }
 
@@ -52,6 +53,8 @@ type Transaction interface {
 	GetTransactionType() TransactionType
 	GetBase() *TxBase
 	PathSet() PathSet
+	Hash() (Hash256, error)        // The identifying hash, as TransactionHash
+	SigningHash() (Hash256, error) // The single signing hash, as TransactionSigningHash
 }
 
 type Wire interface {
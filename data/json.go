@@ -60,8 +60,9 @@ func (txm *TransactionWithMetaData) UnmarshalJSON(b []byte) error {
 		// Transaction has the form {"tx":{}, "meta":{}, "validated": true}
 		// i.e. returned from `account_tx` command.
 		var split struct {
-			Tx   json.RawMessage
-			Meta json.RawMessage
+			Tx        json.RawMessage
+			Meta      json.RawMessage
+			Validated bool
 		}
 		if err := json.Unmarshal(b, &split); err != nil {
 			return err
@@ -69,6 +70,7 @@ func (txm *TransactionWithMetaData) UnmarshalJSON(b []byte) error {
 		if err := json.Unmarshal(split.Tx, txm); err != nil {
 			return err
 		}
+		txm.Validated = split.Validated
 		return json.Unmarshal(split.Meta, &txm.MetaData)
 	}
 
@@ -127,6 +129,28 @@ func (txm TransactionWithMetaData) marshalJSON() ([]byte, []byte, error) {
 	return tx, meta, nil
 }
 
+// Wrapper to stop recursive unmarshalling
+type metaDataJSON MetaData
+
+// "delivered_amount" is "unavailable" for transactions in ledgers closed
+// before it was recorded, which is treated the same as it being absent
+func (m *MetaData) UnmarshalJSON(b []byte) error {
+	var extract struct {
+		*metaDataJSON
+		DeliveredAmount json.RawMessage `json:"delivered_amount"`
+	}
+	extract.metaDataJSON = (*metaDataJSON)(m)
+	if err := json.Unmarshal(b, &extract); err != nil {
+		return err
+	}
+	m.DeliveredAmount = nil
+	if len(extract.DeliveredAmount) == 0 || string(extract.DeliveredAmount) == `"unavailable"` {
+		return nil
+	}
+	m.DeliveredAmount = new(Amount)
+	return json.Unmarshal(extract.DeliveredAmount, m.DeliveredAmount)
+}
+
 const txmFormat = `%s,"hash":"%s","inLedger":%d,"ledger_index":%d,"meta":%s}`
 
 func (txm TransactionWithMetaData) MarshalJSON() ([]byte, error) {
@@ -391,6 +415,30 @@ func (a *Amount) UnmarshalJSON(b []byte) (err error) {
 	return nil
 }
 
+type issueJSON struct {
+	Currency Currency `json:"currency"`
+	Issuer   *Account `json:"issuer,omitempty"`
+}
+
+func (i Issue) MarshalJSON() ([]byte, error) {
+	if i.IsNative() {
+		return json.Marshal(issueJSON{Currency: i.Currency})
+	}
+	return json.Marshal(issueJSON{i.Currency, &i.Issuer})
+}
+
+func (i *Issue) UnmarshalJSON(b []byte) error {
+	var dummy issueJSON
+	if err := json.Unmarshal(b, &dummy); err != nil {
+		return err
+	}
+	i.Currency = dummy.Currency
+	if dummy.Issuer != nil {
+		i.Issuer = *dummy.Issuer
+	}
+	return nil
+}
+
 func (c Currency) MarshalText() ([]byte, error) {
 	return []byte(c.Machine()), nil
 }
@@ -509,6 +557,24 @@ func (p *PublicKey) UnmarshalText(b []byte) error {
 	return err
 }
 
+func (k NodePublicKey) MarshalText() ([]byte, error) {
+	hash, err := k.Hash()
+	if err != nil {
+		return nil, err
+	}
+	return hash.MarshalText()
+}
+
+// Expects base58-encoded node public key
+func (k *NodePublicKey) UnmarshalText(b []byte) error {
+	key, err := NewNodePublicKeyFromAddress(string(b))
+	if err != nil {
+		return err
+	}
+	*k = *key
+	return nil
+}
+
 // A uint64 which gets represented as a hex string in json
 type Uint64Hex uint64
 
@@ -2,6 +2,7 @@ package data
 
 import (
 	"encoding/json"
+	"fmt"
 	"io/ioutil"
 	"path/filepath"
 
@@ -51,3 +52,88 @@ func (s *JSONSuite) TestLedgersJSON(c *C) {
 		compare(c, f, b, out)
 	}
 }
+
+func (s *JSONSuite) TestBalanceChanges(c *C) {
+	b, err := ioutil.ReadFile("testdata/transaction_payment_with_rippling.json")
+	c.Assert(err, IsNil)
+	var txm TransactionWithMetaData
+	c.Assert(json.Unmarshal(b, &txm), IsNil)
+	changes, err := txm.MetaData.BalanceChanges()
+	c.Assert(err, IsNil)
+	c.Assert(changes, HasLen, 11)
+
+	// Each trust line change is seen from both sides
+	c.Check(changes[0].Account.String(), Equals, "rpDMez6pm6dBve2TJsmDpv7Yae6V5Pyvy2")
+	c.Check(changes[0].Issuer.String(), Equals, "rGgj3GurcrAqgBXGVoS9wvQG3Hjkj5oCbj")
+	c.Check(changes[0].Currency.String(), Equals, "USD")
+	c.Check(changes[0].Change.String(), Equals, "0.52717390895")
+	c.Check(changes[1].Account.String(), Equals, "rGgj3GurcrAqgBXGVoS9wvQG3Hjkj5oCbj")
+	c.Check(changes[1].Issuer.String(), Equals, "rpDMez6pm6dBve2TJsmDpv7Yae6V5Pyvy2")
+	c.Check(changes[1].Change.String(), Equals, "-0.52717390895")
+
+	// The sender's only XRP change is the fee
+	c.Check(changes[8].Account, Equals, txm.GetBase().Account)
+	c.Check(changes[8].Currency.IsNative(), Equals, true)
+	c.Check(changes[8].Change.String(), Equals, txm.GetBase().Fee.Negate().String())
+
+	c.Check(changes[9].Account.String(), Equals, "rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B")
+	c.Check(changes[9].Change.String(), Equals, "19.515000003766")
+}
+
+const deliveredAmountPayment = `{
+	"TransactionType": "Payment",
+	"Account": "rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh",
+	"Destination": "rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf",
+	"Amount": {"currency": "USD", "issuer": "rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B", "value": "100"},
+	"Fee": "10",
+	"Flags": %d,
+	"Sequence": 1,
+	"ledger_index": %d,
+	"meta": {
+		"AffectedNodes": [],
+		"TransactionIndex": 0,
+		"TransactionResult": "%s"%s
+	}
+}`
+
+func (s *JSONSuite) TestDeliveredAmount(c *C) {
+	for _, test := range []struct {
+		flags     TransactionFlag
+		ledger    uint32
+		result    string
+		delivered string
+		expected  string
+		err       string
+	}{
+		// Partial payments deliver what the metadata says
+		{TxPartialPayment, 60000000, "tesSUCCESS", `{"currency": "USD", "issuer": "rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B", "value": "0.5"}`, "0.5/USD/rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B", ""},
+		{TxPartialPayment, 60000000, "tesSUCCESS", "", "100/USD/rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B", ""},
+		{TxPartialPayment, 4000000, "tesSUCCESS", `"unavailable"`, "", "Delivered amount unavailable for partial payment in ledger 4000000"},
+		{TxPartialPayment, 4000000, "tesSUCCESS", "", "", "Delivered amount unavailable for partial payment in ledger 4000000"},
+		// Other payments deliver their Amount, or nothing if they failed
+		{0, 4000000, "tesSUCCESS", `"unavailable"`, "100/USD/rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B", ""},
+		{0, 60000000, "tecPATH_DRY", "", "0/USD/rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B", ""},
+	} {
+		var delivered string
+		if test.delivered != "" {
+			delivered = `, "delivered_amount": ` + test.delivered
+		}
+		var txm TransactionWithMetaData
+		b := fmt.Sprintf(deliveredAmountPayment, test.flags, test.ledger, test.result, delivered)
+		c.Assert(json.Unmarshal([]byte(b), &txm), IsNil)
+		amount, err := txm.DeliveredAmount()
+		if test.err != "" {
+			c.Check(err, ErrorMatches, test.err)
+			continue
+		}
+		c.Assert(err, IsNil)
+		c.Check(amount.String(), Equals, test.expected)
+	}
+
+	var txm TransactionWithMetaData
+	b, err := ioutil.ReadFile("testdata/transaction_account_set.json")
+	c.Assert(err, IsNil)
+	c.Assert(json.Unmarshal(b, &txm), IsNil)
+	_, err = txm.DeliveredAmount()
+	c.Check(err, ErrorMatches, "Delivered amount is only available for payments, not AccountSet")
+}
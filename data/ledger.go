@@ -1,5 +1,9 @@
 package data
 
+import (
+	"fmt"
+)
+
 type LedgerHeader struct {
 	LedgerSequence  uint32      `json:"ledger_index,string"`
 	TotalXRP        uint64      `json:"total_coins,string"`
@@ -35,3 +39,45 @@ func (l Ledger) NodeType() NodeType { return NT_LEDGER }
 func (l Ledger) Ledger() uint32     { return l.LedgerSequence }
 func (l Ledger) NodeId() *Hash256   { return &l.Hash }
 func (l Ledger) GetHash() *Hash256  { return &l.Hash }
+
+// VerifyHash checks that the header of the ledger hashes to its Hash. The
+// parent close time is part of the header, so it must be known.
+func (l *Ledger) VerifyHash() error {
+	if l.ParentCloseTime == nil || l.CloseTime == nil {
+		return fmt.Errorf("Ledger %d is missing its close times", l.LedgerSequence)
+	}
+	hash, _, err := Raw(l)
+	if err != nil {
+		return err
+	}
+	if hash != l.Hash {
+		return fmt.Errorf("Ledger %d hashes to %s not %s", l.LedgerSequence, hash, l.Hash)
+	}
+	return nil
+}
+
+// VerifyTransactions checks that Transactions, which must be every
+// transaction of the ledger with its metadata, hash to TransactionHash
+func (l *Ledger) VerifyTransactions() error {
+	root, err := TransactionTreeRoot(l.Transactions)
+	if err != nil {
+		return err
+	}
+	if root != l.TransactionHash {
+		return fmt.Errorf("Transactions of ledger %d hash to %s not %s", l.LedgerSequence, root, l.TransactionHash)
+	}
+	return nil
+}
+
+// VerifyAccountState checks that AccountState, which must be the entire
+// state of the ledger, hashes to StateHash
+func (l *Ledger) VerifyAccountState() error {
+	root, err := AccountStateTreeRoot(l.AccountState)
+	if err != nil {
+		return err
+	}
+	if root != l.StateHash {
+		return fmt.Errorf("State of ledger %d hashes to %s not %s", l.LedgerSequence, root, l.StateHash)
+	}
+	return nil
+}
@@ -33,6 +33,12 @@ type AccountRoot struct {
 	BurnedNFTokens *uint32          `json:",omitempty"`
 }
 
+// AccountReserve returns the minimum XRP balance in drops of an account which
+// owns ownerCount objects, given the base and owner reserves of the network
+func AccountReserve(ownerCount uint32, base, inc uint64) uint64 {
+	return base + uint64(ownerCount)*inc
+}
+
 type RippleState struct {
 	leBase
 	Flags          *LedgerEntryFlag `json:",omitempty"`
@@ -57,7 +63,7 @@ type Offer struct {
 	BookDirectory *Hash256         `json:",omitempty"`
 	BookNode      *NodeIndex       `json:",omitempty"`
 	OwnerNode     *NodeIndex       `json:",omitempty"`
-	Expiration    *uint32          `json:",omitempty"`
+	Expiration    *RippleTime      `json:",omitempty"`
 }
 
 type Directory struct {
@@ -85,8 +91,8 @@ type LedgerHashes struct {
 }
 
 type Majority struct {
-	Amendment *Hash256 `json:",omitempty"`
-	CloseTime *uint32  `json:",omitempty"`
+	Amendment *Hash256    `json:",omitempty"`
+	CloseTime *RippleTime `json:",omitempty"`
 }
 
 type Amendments struct {
@@ -125,8 +131,8 @@ type Escrow struct {
 	Destination     Account          `json:",omitempty"`
 	Amount          Amount           `json:",omitempty"`
 	Condition       *VariableLength  `json:",omitempty"`
-	CancelAfter     *uint32          `json:",omitempty"`
-	FinishAfter     *uint32          `json:",omitempty"`
+	CancelAfter     *RippleTime      `json:",omitempty"`
+	FinishAfter     *RippleTime      `json:",omitempty"`
 	SourceTag       *uint32          `json:",omitempty"`
 	DestinationTag  *uint32          `json:",omitempty"`
 	OwnerNode       *NodeIndex       `json:",omitempty"`
@@ -171,8 +177,8 @@ type PayChannel struct {
 	SettleDelay     *uint32          `json:",omitempty"`
 	OwnerNode       *NodeIndex       `json:",omitempty"`
 	DestinationNode *NodeIndex       `json:",omitempty"`
-	Expiration      *uint32          `json:",omitempty"`
-	CancelAfter     *uint32          `json:",omitempty"`
+	Expiration      *RippleTime      `json:",omitempty"`
+	CancelAfter     *RippleTime      `json:",omitempty"`
 	DestinationTag  *uint32          `json:",omitempty"`
 	SourceTag       *uint32          `json:",omitempty"`
 }
@@ -187,7 +193,7 @@ type Check struct {
 	Sequence        *uint32          `json:",omitempty"`
 	DestinationNode *NodeIndex       `json:",omitempty"`
 	DestinationTag  *uint32          `json:",omitempty"`
-	Expiration      *uint32          `json:",omitempty"`
+	Expiration      *RippleTime      `json:",omitempty"`
 	SourceTag       *uint32          `json:",omitempty"`
 	InvoiceID       *Hash256         `json:",omitempty"`
 }
@@ -217,7 +223,7 @@ type NFTokenOffer struct {
 	OwnerNode        *NodeIndex       `json:",omitempty"`
 	NFTokenOfferNode *NodeIndex       `json:",omitempty"`
 	Destination      *Account         `json:",omitempty"`
-	Expiration       *uint32          `json:",omitempty"`
+	Expiration       *RippleTime      `json:",omitempty"`
 }
 
 func (a *AccountRoot) Affects(account Account) bool {
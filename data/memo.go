@@ -1,9 +1,12 @@
 package data
 
+// The contents of a memo are arbitrary bytes, which are hex encoded in JSON.
+// By convention MemoType and MemoFormat hold text, such as a URL and a MIME
+// type, describing MemoData.
 type MemoItem struct {
-	MemoType   VariableLength
-	MemoData   VariableLength
-	MemoFormat VariableLength
+	MemoType   VariableLength `json:",omitempty"`
+	MemoData   VariableLength `json:",omitempty"`
+	MemoFormat VariableLength `json:",omitempty"`
 }
 
 type Memo struct {
@@ -11,3 +14,24 @@ type Memo struct {
 }
 
 type Memos []Memo
+
+// NewMemo returns a memo holding the bytes of memoType, data and format.
+// Empty strings leave the corresponding field out.
+func NewMemo(memoType, data, format string) Memo {
+	var m Memo
+	if len(memoType) > 0 {
+		m.Memo.MemoType = VariableLength(memoType)
+	}
+	if len(data) > 0 {
+		m.Memo.MemoData = VariableLength(data)
+	}
+	if len(format) > 0 {
+		m.Memo.MemoFormat = VariableLength(format)
+	}
+	return m
+}
+
+// AddMemo appends a memo to the transaction, see NewMemo
+func (t *TxBase) AddMemo(memoType, data, format string) {
+	t.Memos = append(t.Memos, NewMemo(memoType, data, format))
+}
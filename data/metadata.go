@@ -56,9 +56,37 @@ type TransactionWithMetaData struct {
 	MetaData       MetaData   `json:"meta"`
 	Date           RippleTime `json:"date"`
 	LedgerSequence uint32     `json:"ledger_index"`
+	Validated      bool       `json:"validated"`
 	Id             Hash256    `json:"-"`
 }
 
+// Transactions in ledgers before this one may have delivered less than
+// their Amount without it being recorded in the metadata
+const deliveredAmountLedger = 4594095
+
+// DeliveredAmount returns the amount actually delivered by a Payment, which
+// for a partial payment can be less than its Amount. Nothing is delivered
+// by failed transactions. An error is returned when the delivered amount
+// can't be known, because it was not recorded at the time.
+func (t *TransactionWithMetaData) DeliveredAmount() (*Amount, error) {
+	payment, ok := t.Transaction.(*Payment)
+	if !ok {
+		return nil, fmt.Errorf("Delivered amount is only available for payments, not %s", t.GetType())
+	}
+	switch {
+	case !t.MetaData.TransactionResult.Success():
+		return payment.Amount.ZeroClone(), nil
+	case t.MetaData.DeliveredAmount != nil:
+		return t.MetaData.DeliveredAmount, nil
+	case payment.Flags == nil || *payment.Flags&TxPartialPayment == 0:
+		return &payment.Amount, nil
+	case t.LedgerSequence >= deliveredAmountLedger:
+		return &payment.Amount, nil
+	default:
+		return nil, fmt.Errorf("Delivered amount unavailable for partial payment in ledger %d", t.LedgerSequence)
+	}
+}
+
 func (t *TransactionWithMetaData) GetType() string    { return t.Transaction.GetType() }
 func (t *TransactionWithMetaData) Prefix() HashPrefix { return HP_TRANSACTION_NODE }
 func (t *TransactionWithMetaData) NodeType() NodeType { return NT_TRANSACTION_NODE }
@@ -1,6 +1,57 @@
 package data
 
+import (
+	"encoding/binary"
+)
+
 type NFToken struct {
 	NFTokenID *Hash256        `json:",omitempty"`
 	URI       *VariableLength `json:",omitempty"`
-}
\ No newline at end of file
+}
+
+// NFTokenIDFields are what an NFTokenID is made of. Flags are the low 16 bits
+// of the NFTokenMint flags, such as TxTransferable, and Sequence is the
+// MintedNFTokens of the issuer before the token was minted.
+type NFTokenIDFields struct {
+	Flags       uint16
+	TransferFee uint16
+	Issuer      Account
+	Taxon       uint32
+	Sequence    uint32
+}
+
+// The taxon is stored scrambled with the sequence, so that the tokens of a
+// taxon are not next to each other in the NFTokenPages of their owner
+func scrambleTaxon(taxon, sequence uint32) uint32 {
+	return taxon ^ (384160001*sequence + 2459)
+}
+
+// ParseNFTokenID returns the fields packed into id, with the taxon
+// unscrambled
+func ParseNFTokenID(id Hash256) NFTokenIDFields {
+	fields := NFTokenIDFields{
+		Flags:       binary.BigEndian.Uint16(id[0:2]),
+		TransferFee: binary.BigEndian.Uint16(id[2:4]),
+		Sequence:    binary.BigEndian.Uint32(id[28:32]),
+	}
+	copy(fields.Issuer[:], id[4:24])
+	fields.Taxon = scrambleTaxon(binary.BigEndian.Uint32(id[24:28]), fields.Sequence)
+	return fields
+}
+
+// BuildNFTokenID returns the id of the NFToken with the fields, which is the
+// one rippled gives it when it is minted
+func BuildNFTokenID(flags, transferFee uint16, issuer Account, taxon, sequence uint32) Hash256 {
+	var id Hash256
+	binary.BigEndian.PutUint16(id[0:2], flags)
+	binary.BigEndian.PutUint16(id[2:4], transferFee)
+	copy(id[4:24], issuer[:])
+	binary.BigEndian.PutUint32(id[24:28], scrambleTaxon(taxon, sequence))
+	binary.BigEndian.PutUint32(id[28:32], sequence)
+	return id
+}
+
+// ID returns the NFTokenID packed from the fields
+func (f NFTokenIDFields) ID() Hash256 {
+	return BuildNFTokenID(f.Flags, f.TransferFee, f.Issuer, f.Taxon, f.Sequence)
+}
@@ -0,0 +1,42 @@
+package data
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+type NFTSuite struct{}
+
+var _ = Suite(&NFTSuite{})
+
+func (s *NFTSuite) TestNFTokenID(c *C) {
+	for _, test := range []struct {
+		id, issuer         string
+		flags, transferFee uint16
+		taxon, sequence    uint32
+	}{
+		{"000B0539C35B55AA096BA6D87A6E6C965A6534150DC56E5E12C5D09E0000000C", "rJoxBSzpXhPtAuqFmqxQtGKjA13jUJWthE", 11, 1337, 1337, 12},
+		{"00080000B4F4AFC5FBCBD76873F18006173D2193467D3EE70000099B00000000", "rHVokeuSnjPjz718qdb47bGXBBHNMP3KDQ", 8, 0, 0, 0},
+	} {
+		id, err := NewHash256(test.id)
+		c.Assert(err, IsNil)
+		issuer, err := NewAccountFromAddress(test.issuer)
+		c.Assert(err, IsNil)
+		fields := ParseNFTokenID(*id)
+		c.Check(fields, Equals, NFTokenIDFields{
+			Flags:       test.flags,
+			TransferFee: test.transferFee,
+			Issuer:      *issuer,
+			Taxon:       test.taxon,
+			Sequence:    test.sequence,
+		})
+		c.Check(fields.ID(), Equals, *id)
+		c.Check(BuildNFTokenID(test.flags, test.transferFee, *issuer, test.taxon, test.sequence), Equals, *id)
+	}
+
+	// The same taxon is scrambled differently for each sequence
+	issuer, err := NewAccountFromAddress("rJoxBSzpXhPtAuqFmqxQtGKjA13jUJWthE")
+	c.Assert(err, IsNil)
+	first, second := BuildNFTokenID(0, 0, *issuer, 1, 0), BuildNFTokenID(0, 0, *issuer, 1, 1)
+	c.Check(first[24:28], Not(DeepEquals), second[24:28])
+	c.Check(ParseNFTokenID(second).Taxon, Equals, uint32(1))
+}
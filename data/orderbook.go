@@ -57,7 +57,7 @@ type AccountOffer struct {
 	Sequence   uint32          `json:"seq"`
 	TakerGets  Amount          `json:"taker_gets"`
 	TakerPays  Amount          `json:"taker_pays"`
-	Expiration *uint32         `json:"expiration"`
+	Expiration *RippleTime     `json:"expiration"`
 }
 
 type AccountOfferSlice []AccountOffer
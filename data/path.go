@@ -19,6 +19,12 @@ const (
 	PATH_ISSUER   pathEntry = 0x20
 )
 
+// The limits rippled puts on the Paths of a Payment
+const (
+	MaxPathSize   = 6
+	MaxPathLength = 8
+)
+
 // PathElem represents one link in a path.
 type PathElem struct {
 	Account  *Account
@@ -26,6 +32,29 @@ type PathElem struct {
 	Issuer   *Account
 }
 
+// NewAccountStep returns a link which ripples through account
+func NewAccountStep(account Account) PathElem {
+	return PathElem{Account: &account}
+}
+
+// NewCurrencyStep returns a link which converts to currency through the order
+// books, keeping the issuer of the previous link
+func NewCurrencyStep(currency Currency) PathElem {
+	return PathElem{Currency: &currency}
+}
+
+// NewIssuerStep returns a link which changes to the issuer of the same
+// currency through the order books
+func NewIssuerStep(issuer Account) PathElem {
+	return PathElem{Issuer: &issuer}
+}
+
+// NewOfferStep returns a link which converts to currency issued by issuer
+// through the order books
+func NewOfferStep(currency Currency, issuer Account) PathElem {
+	return PathElem{Currency: &currency, Issuer: &issuer}
+}
+
 func newPathElem(s string) (PathElem, error) {
 	var err error
 	pe := PathElem{}
@@ -91,6 +120,48 @@ func (p PathElem) pathEntry() pathEntry {
 	return entry
 }
 
+// Validate checks that the link is an account, or a currency and/or issuer,
+// and that XRP has no issuer
+func (p PathElem) Validate() error {
+	switch {
+	case p.pathEntry() == 0:
+		return fmt.Errorf("Empty path element")
+	case p.Account != nil && (p.Currency != nil || p.Issuer != nil):
+		return fmt.Errorf("Path element has an account and a currency or issuer: %s", p)
+	case p.Currency != nil && p.Currency.IsNative() && p.Issuer != nil && !p.Issuer.IsZero():
+		return fmt.Errorf("Path element has XRP with an issuer: %s", p)
+	default:
+		return nil
+	}
+}
+
+// Validate checks that the path has between 1 and MaxPathLength valid links
+func (p Path) Validate() error {
+	if len(p) == 0 || len(p) > MaxPathLength {
+		return fmt.Errorf("Path must have between 1 and %d elements: %d", MaxPathLength, len(p))
+	}
+	for _, pe := range p {
+		if err := pe.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Validate checks that there are at most MaxPathSize paths and that each of
+// them is valid
+func (p PathSet) Validate() error {
+	if len(p) > MaxPathSize {
+		return fmt.Errorf("PathSet must have at most %d paths: %d", MaxPathSize, len(p))
+	}
+	for _, path := range p {
+		if err := path.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (p Path) Signature() (uint32, error) {
 	checksum := crc32.NewIEEE()
 	for _, path := range p {
@@ -1,6 +1,8 @@
 package data
 
 import (
+	"bytes"
+
 	. "gopkg.in/check.v1"
 )
 
@@ -48,3 +50,46 @@ func (s *PathSuite) TestPathError(c *C) {
 	_, err := NewPath("Foo")
 	c.Assert(err.Error(), Equals, "Base58 string too short: Foo")
 }
+
+func (s *PathSuite) TestPathSteps(c *C) {
+	account, err := NewAccountFromAddress("r3ADD8kXSUKHd6zTCKfnKT3zV9EZHjzp1S")
+	c.Assert(err, IsNil)
+	issuer, err := NewAccountFromAddress("rNPRNzBB92BVpAhhZr4iXDTveCgV5Pofm9")
+	c.Assert(err, IsNil)
+	btc, err := NewCurrency("BTC")
+	c.Assert(err, IsNil)
+	xrp, err := NewCurrency("XRP")
+	c.Assert(err, IsNil)
+
+	paths := PathSet{
+		Path{NewOfferStep(btc, *issuer), NewAccountStep(*account)},
+		Path{NewCurrencyStep(xrp), NewIssuerStep(*issuer)},
+	}
+	c.Check(paths.Validate(), IsNil)
+	c.Check(paths[0].String(), Equals, "BTC/rNPRNzBB92BVpAhhZr4iXDTveCgV5Pofm9 => r3ADD8kXSUKHd6zTCKfnKT3zV9EZHjzp1S")
+	c.Check(paths[0][0].pathEntry(), Equals, PATH_CURRENCY|PATH_ISSUER)
+	c.Check(paths[1][1].pathEntry(), Equals, PATH_ISSUER)
+
+	var b bytes.Buffer
+	c.Assert(paths.Marshal(&b), IsNil)
+	var decoded PathSet
+	c.Assert(decoded.Unmarshal(bytes.NewReader(b.Bytes())), IsNil)
+	c.Check(decoded, DeepEquals, paths)
+
+	c.Check(PathSet{Path{}}.Validate(), ErrorMatches, "Path must have between 1 and 8 elements: 0")
+	c.Check(PathSet{Path{PathElem{}}}.Validate(), ErrorMatches, "Empty path element")
+	c.Check(Path{PathElem{Account: account, Currency: &btc}}.Validate(), ErrorMatches, "Path element has an account and a currency or issuer: .*")
+	c.Check(Path{NewOfferStep(xrp, *issuer)}.Validate(), ErrorMatches, "Path element has XRP with an issuer: .*")
+	c.Check(Path{NewOfferStep(xrp, Account{})}.Validate(), IsNil)
+	long := make(Path, MaxPathLength+1)
+	for i := range long {
+		long[i] = NewAccountStep(*account)
+	}
+	c.Check(long.Validate(), ErrorMatches, "Path must have between 1 and 8 elements: 9")
+	c.Check(make(PathSet, MaxPathSize+1).Validate(), ErrorMatches, "PathSet must have at most 6 paths: 7")
+
+	payment := &Payment{Paths: &PathSet{Path{PathElem{}}}}
+	c.Check(payment.Validate(), ErrorMatches, "Empty path element")
+	c.Check((&PathSet{Path{PathElem{}}}).Marshal(&b), ErrorMatches, "Empty path element")
+	c.Check((&PathSet{Path{}}).Marshal(&b), ErrorMatches, "Empty path")
+}
@@ -0,0 +1,189 @@
+package data
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/rubblelabs/ripple/crypto"
+)
+
+// The types of SHAMap node which rippled sends to peers, given by the last
+// byte of each node
+const (
+	wireTransaction         byte = 0
+	wireAccountState        byte = 1
+	wireInner               byte = 2
+	wireCompressedInner     byte = 3
+	wireTransactionWithMeta byte = 4
+)
+
+// The most nodes a proof can have, an inner node for each nibble of the key
+// and the leaf
+const maxProofLength = 65
+
+// Proof is the path through a SHAMap from a leaf up to the root, with each
+// node serialized as rippled sends it to peers
+type Proof [][]byte
+
+// Verify checks that the proof leads from root to the leaf keyed by key, and
+// returns the leaf, which is a transaction, a transaction with its metadata
+// or a ledger entry, without its key
+func (p Proof) Verify(root, key Hash256) ([]byte, error) {
+	if len(p) == 0 || len(p) > maxProofLength {
+		return nil, fmt.Errorf("Proof must have between 1 and %d nodes: %d", maxProofLength, len(p))
+	}
+	hash := root
+	for i := len(p) - 1; i >= 0; i-- {
+		depth := len(p) - 1 - i
+		node := p[i]
+		if len(node) == 0 {
+			return nil, fmt.Errorf("Empty proof node at depth %d", depth)
+		}
+		body, typ := node[:len(node)-1], node[len(node)-1]
+		if typ == wireInner || typ == wireCompressedInner {
+			children, err := readWireInner(body, typ)
+			if err != nil {
+				return nil, err
+			}
+			if hashInner(children) != hash {
+				return nil, fmt.Errorf("Proof node at depth %d does not match its parent", depth)
+			}
+			if i == 0 {
+				return nil, fmt.Errorf("Proof ends without a leaf")
+			}
+			if hash = children[key.nibble(depth)]; hash.IsZero() {
+				return nil, fmt.Errorf("Proof does not include: %s", key)
+			}
+			continue
+		}
+		if i != 0 {
+			return nil, fmt.Errorf("Proof has a leaf at depth %d", depth)
+		}
+		leaf, leafKey, leafHash, err := readWireLeaf(body, typ)
+		if err != nil {
+			return nil, err
+		}
+		if leafHash != hash {
+			return nil, fmt.Errorf("Proof leaf does not match its parent")
+		}
+		if leafKey != key {
+			return nil, fmt.Errorf("Proof is of %s not %s", leafKey, key)
+		}
+		return leaf, nil
+	}
+	panic("unreachable")
+}
+
+// VerifyLedgerEntryProof checks that proof leads from root, the StateHash of
+// a ledger, to the entry with index, and returns the entry
+func VerifyLedgerEntryProof(root, index Hash256, proof Proof) (LedgerEntry, error) {
+	if len(proof) > 0 && len(proof[0]) > 0 && proof[0][len(proof[0])-1] != wireAccountState {
+		return nil, fmt.Errorf("Proof is not of a ledger entry")
+	}
+	leaf, err := proof.Verify(root, index)
+	if err != nil {
+		return nil, err
+	}
+	le, err := readLedgerEntryFields(bytes.NewReader(leaf))
+	if err != nil {
+		return nil, err
+	}
+	copy(le.GetHash()[:], index[:])
+	return le, nil
+}
+
+// AccountStateProof returns the proof that the entry with index is in state,
+// which must be the entire state of a ledger
+func AccountStateProof(state LedgerEntrySlice, index Hash256) (Proof, error) {
+	leaves, err := accountStateLeaves(state)
+	if err != nil {
+		return nil, err
+	}
+	if err := sortLeaves(leaves); err != nil {
+		return nil, err
+	}
+	var proof Proof
+	for depth := 0; ; depth++ {
+		proof = append(Proof{wireInnerBytes(shaMapChildren(leaves, depth))}, proof...)
+		start := 0
+		for start < len(leaves) && leaves[start].key.nibble(depth) != index.nibble(depth) {
+			start = shaMapBranchEnd(leaves, start, depth)
+		}
+		if start == len(leaves) {
+			return nil, fmt.Errorf("Missing ledger entry: %s", index)
+		}
+		if leaves = leaves[start:shaMapBranchEnd(leaves, start, depth)]; len(leaves) == 1 {
+			break
+		}
+	}
+	if leaves[0].key != index {
+		return nil, fmt.Errorf("Missing ledger entry: %s", index)
+	}
+	for _, le := range state {
+		if key, err := leafIndex(le); err != nil || *key != index {
+			continue
+		}
+		b, err := Encode(le)
+		if err != nil {
+			return nil, err
+		}
+		leaf := append(append(b, index[:]...), wireAccountState)
+		return append(Proof{leaf}, proof...), nil
+	}
+	return nil, fmt.Errorf("Missing ledger entry: %s", index)
+}
+
+// wireInnerBytes serializes an inner node with all of its children
+func wireInnerBytes(children [16]Hash256) []byte {
+	b := make([]byte, 0, len(children)*32+1)
+	for _, child := range children {
+		b = append(b, child[:]...)
+	}
+	return append(b, wireInner)
+}
+
+// readWireInner reads the children of an inner node, which is compressed to
+// the branches it has when it has few of them
+func readWireInner(b []byte, typ byte) ([16]Hash256, error) {
+	var children [16]Hash256
+	switch {
+	case typ == wireInner && len(b) == len(children)*32:
+		for i := range children {
+			copy(children[i][:], b[i*32:])
+		}
+	case typ == wireCompressedInner && len(b)%33 == 0:
+		for ; len(b) > 0; b = b[33:] {
+			if b[32] >= 16 {
+				return children, fmt.Errorf("Bad inner node branch: %d", b[32])
+			}
+			copy(children[b[32]][:], b[:32])
+		}
+	default:
+		return children, fmt.Errorf("Bad inner node length: %d", len(b))
+	}
+	return children, nil
+}
+
+// readWireLeaf returns the contents of a leaf along with its key and hash.
+// A transaction without metadata has no key, as it is keyed by its hash.
+func readWireLeaf(b []byte, typ byte) ([]byte, Hash256, Hash256, error) {
+	var key, hash Hash256
+	var prefix HashPrefix
+	switch typ {
+	case wireTransaction:
+		copy(hash[:], crypto.Sha512Half(append(HP_TRANSACTION_ID.Bytes(), b...)))
+		return b, hash, hash, nil
+	case wireAccountState:
+		prefix = HP_LEAF_NODE
+	case wireTransactionWithMeta:
+		prefix = HP_TRANSACTION_NODE
+	default:
+		return nil, key, hash, fmt.Errorf("Unknown proof node type: %d", typ)
+	}
+	if len(b) < len(key) {
+		return nil, key, hash, fmt.Errorf("Proof leaf too short: %d", len(b))
+	}
+	copy(key[:], b[len(b)-len(key):])
+	copy(hash[:], crypto.Sha512Half(append(prefix.Bytes(), b...)))
+	return b[:len(b)-len(key)], key, hash, nil
+}
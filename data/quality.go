@@ -0,0 +1,102 @@
+package data
+
+import (
+	"encoding/binary"
+)
+
+const qualityMantissaMask uint64 = (1 << 56) - 1
+
+// Quality is the rate of an offer, TakerPays/TakerGets, with native amounts
+// counted in drops. It is encoded as rippled does in the last 8 bytes of a
+// book directory index: the exponent of the rate plus 100 in the top byte
+// and its mantissa in the other 56 bits, so that lower qualities, which are
+// better for the taker, sort first both as integers and in the ledger.
+type Quality uint64
+
+// NewQuality returns the quality of an offer. An offer which gets nothing
+// has a quality of zero.
+func NewQuality(pays, gets Amount) (Quality, error) {
+	if gets.IsZero() {
+		return 0, nil
+	}
+	num, err := pays.Value.NonNative()
+	if err != nil {
+		return 0, err
+	}
+	den, err := gets.Value.NonNative()
+	if err != nil {
+		return 0, err
+	}
+	rate, err := num.Divide(*den)
+	if err != nil {
+		return 0, err
+	}
+	if rate.IsZero() {
+		return 0, nil
+	}
+	return Quality(uint64(rate.offset+100)<<56 | rate.num), nil
+}
+
+// Value returns the rate as a non-native Value
+func (q Quality) Value() *Value {
+	if q == 0 {
+		return zeroNonNative.Clone()
+	}
+	return newValue(false, false, uint64(q)&qualityMantissaMask, int64(q>>56)-100)
+}
+
+// Compare returns -1 if q is a better rate for the taker than other, +1 if it
+// is worse and 0 if they are the same
+func (q Quality) Compare(other Quality) int {
+	switch {
+	case q < other:
+		return -1
+	case q > other:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Less reports whether q is a better rate for the taker than other
+func (q Quality) Less(other Quality) bool {
+	return q < other
+}
+
+// Invert returns the quality of an offer in the opposite direction,
+// TakerGets/TakerPays
+func (q Quality) Invert() (Quality, error) {
+	if q == 0 {
+		return 0, nil
+	}
+	one := newValue(false, false, minValue, -15)
+	rate, err := one.Divide(*q.Value())
+	if err != nil {
+		return 0, err
+	}
+	return Quality(uint64(rate.offset+100)<<56 | rate.num), nil
+}
+
+func (q Quality) String() string {
+	return q.Value().String()
+}
+
+func (q Quality) Bytes() []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, uint64(q))
+	return b
+}
+
+// BookQuality returns the quality of the offers in a book directory, which is
+// packed into the last 8 bytes of its index
+func BookQuality(dir Hash256) Quality {
+	return Quality(binary.BigEndian.Uint64(dir[24:]))
+}
+
+// BookBase returns the index of a book directory without its quality, which is
+// the index of the book itself as returned by GetBookIndex
+func BookBase(dir Hash256) Hash256 {
+	var zero [8]byte
+	copy(dir[24:], zero[:])
+	return dir
+}
@@ -0,0 +1,57 @@
+package data
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+type QualitySuite struct{}
+
+var _ = Suite(&QualitySuite{})
+
+func (s *QualitySuite) TestQuality(c *C) {
+	// The offer of the "OfferCreate with expiration" fixture
+	pays := amountCheck("125/USD/rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B")
+	gets := amountCheck("10000/XRP")
+	q, err := NewQuality(*pays, *gets)
+	c.Assert(err, IsNil)
+	c.Check(q, Equals, Quality(0x4D0470DE4DF82000))
+	c.Check(q.String(), Equals, "0.0000000125")
+	dir, err := NewHash256("DFA3B6DDAB58C7E8E5D944E736DA4B7046C30E4F460FD9DE4D0470DE4DF82000")
+	c.Assert(err, IsNil)
+	c.Check(BookQuality(*dir), Equals, q)
+
+	inverse, err := q.Invert()
+	c.Assert(err, IsNil)
+	c.Check(inverse.String(), Equals, "80000000")
+	again, err := NewQuality(*gets, *pays)
+	c.Assert(err, IsNil)
+	c.Check(again, Equals, inverse)
+
+	c.Check(q.Less(inverse), Equals, true)
+	c.Check(inverse.Less(q), Equals, false)
+	c.Check(q.Compare(inverse), Equals, -1)
+	c.Check(inverse.Compare(q), Equals, 1)
+	c.Check(q.Compare(q), Equals, 0)
+
+	zero, err := NewQuality(*pays, *amountCheck("0/XRP"))
+	c.Assert(err, IsNil)
+	c.Check(zero, Equals, Quality(0))
+	c.Check(zero.Value().IsZero(), Equals, true)
+}
+
+func (s *QualitySuite) TestBookDirectory(c *C) {
+	// The "BookDirectory" ledger entry fixture, of the BTC/ILS book
+	root, err := NewHash256("7D6F70854117F7471E428D7CD779BC816789217222B0276B5113E46252235D7F")
+	c.Assert(err, IsNil)
+	c.Check(BookQuality(*root), Equals, ExchangeRate(0x5113E46252235D7F).Quality())
+	btc, err := NewCurrency("BTC")
+	c.Assert(err, IsNil)
+	ils, err := NewCurrency("ILS")
+	c.Assert(err, IsNil)
+	issuer, err := NewAccountFromAddress("rNPRNzBB92BVpAhhZr4iXDTveCgV5Pofm9")
+	c.Assert(err, IsNil)
+	book, err := GetBookIndex(Hash160(btc), Hash160(ils), Hash160(*issuer), Hash160(*issuer))
+	c.Assert(err, IsNil)
+	c.Check(*book, Equals, BookBase(*root))
+	c.Check(*GetBookDirectoryIndex(*book, BookQuality(*root)), Equals, *root)
+}
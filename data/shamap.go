@@ -0,0 +1,145 @@
+package data
+
+import (
+	"crypto/sha512"
+	"fmt"
+	"sort"
+)
+
+// A leaf of a SHAMap, the radix tree of 16 branches which rippled keeps the
+// transactions and the state of a ledger in
+type shaMapLeaf struct {
+	key  Hash256
+	hash Hash256
+}
+
+// TransactionTreeRoot returns the root hash of the tree of txs, which is the
+// TransactionHash of the ledger they are all the transactions of. Each leaf
+// is hashed from the transaction and its metadata, and keyed by the hash of
+// the transaction, so neither is trusted.
+func TransactionTreeRoot(txs TransactionSlice) (Hash256, error) {
+	leaves := make([]shaMapLeaf, len(txs))
+	for i, txm := range txs {
+		key, err := TransactionHash(txm.Transaction)
+		if err != nil {
+			return zero256, err
+		}
+		hash, err := NodeId(txm)
+		if err != nil {
+			return zero256, err
+		}
+		leaves[i] = shaMapLeaf{key, hash}
+	}
+	return shaMapRoot(leaves)
+}
+
+// AccountStateTreeRoot returns the root hash of the tree of state, which is
+// the StateHash of the ledger it is the entire state of. Each leaf is keyed
+// by the index its entry was read with, or else the one computed from its
+// fields.
+func AccountStateTreeRoot(state LedgerEntrySlice) (Hash256, error) {
+	leaves, err := accountStateLeaves(state)
+	if err != nil {
+		return zero256, err
+	}
+	return shaMapRoot(leaves)
+}
+
+func accountStateLeaves(state LedgerEntrySlice) ([]shaMapLeaf, error) {
+	leaves := make([]shaMapLeaf, len(state))
+	for i, le := range state {
+		key, err := leafIndex(le)
+		if err != nil {
+			return nil, err
+		}
+		hash, err := NodeId(le)
+		if err != nil {
+			return nil, err
+		}
+		leaves[i] = shaMapLeaf{*key, hash}
+	}
+	return leaves, nil
+}
+
+// The root is always an inner node, and the root of an empty tree is zero
+func shaMapRoot(leaves []shaMapLeaf) (Hash256, error) {
+	if len(leaves) == 0 {
+		return zero256, nil
+	}
+	if err := sortLeaves(leaves); err != nil {
+		return zero256, err
+	}
+	return hashInner(shaMapChildren(leaves, 0)), nil
+}
+
+func sortLeaves(leaves []shaMapLeaf) error {
+	sort.Slice(leaves, func(i, j int) bool {
+		return leaves[i].key.Compare(leaves[j].key) < 0
+	})
+	for i := 1; i < len(leaves); i++ {
+		if leaves[i].key == leaves[i-1].key {
+			return fmt.Errorf("Duplicate key in tree: %s", leaves[i].key)
+		}
+	}
+	return nil
+}
+
+// shaMapChildren returns the children of the inner node at depth of the sorted
+// leaves, which all share their first depth nibbles. Each branch is either
+// empty, a leaf on its own or an inner node one deeper.
+func shaMapChildren(leaves []shaMapLeaf, depth int) [16]Hash256 {
+	var children [16]Hash256
+	for start := 0; start < len(leaves); {
+		end := shaMapBranchEnd(leaves, start, depth)
+		branch := leaves[start].key.nibble(depth)
+		if end-start == 1 {
+			children[branch] = leaves[start].hash
+		} else {
+			children[branch] = hashInner(shaMapChildren(leaves[start:end], depth+1))
+		}
+		start = end
+	}
+	return children
+}
+
+// shaMapBranchEnd returns the end of the leaves from start which are on the
+// same branch at depth
+func shaMapBranchEnd(leaves []shaMapLeaf, start, depth int) int {
+	branch := leaves[start].key.nibble(depth)
+	end := start + 1
+	for end < len(leaves) && leaves[end].key.nibble(depth) == branch {
+		end++
+	}
+	return end
+}
+
+func hashInner(children [16]Hash256) Hash256 {
+	hasher := sha512.New()
+	hasher.Write(HP_INNER_NODE.Bytes())
+	for _, child := range children {
+		hasher.Write(child[:])
+	}
+	var hash Hash256
+	copy(hash[:], hasher.Sum(nil))
+	return hash
+}
+
+func (h Hash256) nibble(depth int) int {
+	b := h[depth/2]
+	if depth%2 == 0 {
+		return int(b >> 4)
+	}
+	return int(b & 0x0F)
+}
+
+// leafIndex is the index an entry was read with, from JSON or the nodestore,
+// which is its key in the tree, or else the one computed from its fields
+func leafIndex(le LedgerEntry) (*Hash256, error) {
+	if index := le.GetLedgerIndex(); index != nil {
+		return index, nil
+	}
+	if hash := le.GetHash(); !hash.IsZero() {
+		return hash, nil
+	}
+	return LedgerIndex(le)
+}
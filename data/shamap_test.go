@@ -0,0 +1,95 @@
+package data
+
+import (
+	"encoding/json"
+	"io/ioutil"
+
+	internal "github.com/rubblelabs/ripple/testing"
+	. "gopkg.in/check.v1"
+)
+
+type SHAMapSuite struct{}
+
+var _ = Suite(&SHAMapSuite{})
+
+func readTestLedger(c *C) *Ledger {
+	b, err := ioutil.ReadFile("testdata/ledger_6000000.json")
+	c.Assert(err, IsNil)
+	var ledger Ledger
+	c.Assert(json.Unmarshal(b, &ledger), IsNil)
+	return &ledger
+}
+
+func (s *SHAMapSuite) TestVerifyTrees(c *C) {
+	ledger := readTestLedger(c)
+	c.Check(ledger.VerifyTransactions(), IsNil)
+	c.Check(ledger.VerifyAccountState(), IsNil)
+
+	// The header lacks the parent close time
+	c.Check(ledger.VerifyHash(), ErrorMatches, "Ledger 38129 is missing its close times")
+
+	root := ledger.AccountState[0].(*AccountRoot)
+	*root.Sequence++
+	c.Check(ledger.VerifyAccountState(), ErrorMatches, "State of ledger 38129 hashes to .* not 2C23D15B6B549123FB351E4B5CDE81C564318EB845449CD43C3EA7953C4DB452")
+	ledger.AccountState = append(ledger.AccountState, ledger.AccountState[1])
+	c.Check(ledger.VerifyAccountState(), ErrorMatches, "Duplicate key in tree: .*")
+
+	ledger.Transactions[0].MetaData.TransactionIndex++
+	c.Check(ledger.VerifyTransactions(), ErrorMatches, "Transactions of ledger 38129 hash to .* not DB83BF807416C5B3499A73130F843CF615AB8E797D79FE7D330ADF1BFA93951A")
+
+	empty, err := TransactionTreeRoot(nil)
+	c.Assert(err, IsNil)
+	c.Check(empty.IsZero(), Equals, true)
+}
+
+func (s *SHAMapSuite) TestVerifyHash(c *C) {
+	var test internal.TestData
+	for _, node := range internal.Nodes {
+		if node.Description == "Ledger Master" {
+			test = node
+		}
+	}
+	nodeId, err := NewHash256(test.NodeId())
+	c.Assert(err, IsNil)
+	n, err := ReadPrefix(test.Reader(), *nodeId)
+	c.Assert(err, IsNil)
+	ledger := n.(*Ledger)
+	c.Check(ledger.VerifyHash(), IsNil)
+	ledger.CloseFlags++
+	c.Check(ledger.VerifyHash(), ErrorMatches, "Ledger 3254427 hashes to .* not 0000011F4606F82FB72264B982BC58FCBB27822383AC94B63788CA7DF609A263")
+}
+
+func (s *SHAMapSuite) TestAccountStateProof(c *C) {
+	ledger := readTestLedger(c)
+	for _, i := range []int{0, 100, len(ledger.AccountState) - 1} {
+		index := *ledger.AccountState[i].GetLedgerIndex()
+		proof, err := AccountStateProof(ledger.AccountState, index)
+		c.Assert(err, IsNil)
+		le, err := VerifyLedgerEntryProof(ledger.StateHash, index, proof)
+		c.Assert(err, IsNil)
+		c.Check(le.GetType(), Equals, ledger.AccountState[i].GetType())
+		c.Check(*le.GetHash(), Equals, index)
+	}
+
+	index := *ledger.AccountState[0].GetLedgerIndex()
+	proof, err := AccountStateProof(ledger.AccountState, index)
+	c.Assert(err, IsNil)
+	other := *ledger.AccountState[1].GetLedgerIndex()
+	_, err = VerifyLedgerEntryProof(ledger.StateHash, other, proof)
+	c.Check(err, NotNil)
+	_, err = VerifyLedgerEntryProof(ledger.TransactionHash, index, proof)
+	c.Check(err, ErrorMatches, "Proof node at depth 0 does not match its parent")
+	_, err = proof[1:].Verify(ledger.StateHash, index)
+	c.Check(err, ErrorMatches, "Proof ends without a leaf")
+	_, err = VerifyLedgerEntryProof(ledger.StateHash, index, proof[1:])
+	c.Check(err, ErrorMatches, "Proof is not of a ledger entry")
+	_, err = VerifyLedgerEntryProof(ledger.StateHash, index, nil)
+	c.Check(err, ErrorMatches, "Proof must have between 1 and 65 nodes: 0")
+
+	proof[0][0] ^= 1
+	_, err = VerifyLedgerEntryProof(ledger.StateHash, index, proof)
+	c.Check(err, ErrorMatches, "Proof leaf does not match its parent")
+
+	_, err = AccountStateProof(ledger.AccountState, ledger.StateHash)
+	c.Check(err, ErrorMatches, "Missing ledger entry: .*")
+}
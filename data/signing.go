@@ -1,9 +1,22 @@
 package data
 
-import "github.com/rubblelabs/ripple/crypto"
+import (
+	"encoding/binary"
+	"fmt"
+	"sort"
 
+	"github.com/rubblelabs/ripple/crypto"
+)
+
+// Sign signs s with key, filling in SigningPubKey, TxnSignature and Hash.
+// Transactions also have the tfFullyCanonicalSig flag set, as the
+// signatures produced are always canonical. The Account is left as it is,
+// so key may be the regular key of the account rather than its master key.
 func Sign(s Signable, key crypto.Key, sequence *uint32) error {
 	s.InitialiseForSigning()
+	if tx, ok := s.(Transaction); ok {
+		setCanonicalSignatureFlag(tx.GetBase())
+	}
 	copy(s.GetPublicKey().Bytes(), key.Public(sequence))
 	hash, msg, err := SigningHash(s)
 	if err != nil {
@@ -22,25 +35,105 @@ func Sign(s Signable, key crypto.Key, sequence *uint32) error {
 	return nil
 }
 
+func setCanonicalSignatureFlag(base *TxBase) {
+	flags := TxCanonicalSignature
+	if base.Flags != nil {
+		flags |= *base.Flags
+	}
+	base.Flags = &flags
+}
+
 func CheckSignature(s Signable) (bool, error) {
 	hash, msg, err := SigningHash(s)
 	if err != nil {
 		return false, err
 	}
+	msg = append(s.SigningPrefix().Bytes(), msg...)
 	return crypto.Verify(s.GetPublicKey().Bytes(), hash.Bytes(), msg, s.GetSignature().Bytes())
 }
 
-func MultiSign(s MultiSignable, key crypto.Key, sequence *uint32, account Account) error {
-	s.InitialiseForSigning()
+// VerifyTransaction checks tx without trusting the server it came from:
+// its hash must match its contents, and it must carry either a valid
+// signature or valid signatures from all of its Signers. Whether those keys
+// may sign for the account depends on the ledger, see AccountRoot.Authorizes.
+func VerifyTransaction(tx Transaction) (bool, error) {
+	hash, _, err := Raw(tx)
+	if err != nil {
+		return false, err
+	}
+	if h := tx.GetHash(); !h.IsZero() && *h != hash {
+		return false, fmt.Errorf("Hash %s does not match transaction %s", h, hash)
+	}
+	if len(tx.GetBase().Signers) > 0 {
+		return CheckMultiSignature(tx.(MultiSignable))
+	}
+	if tx.GetPublicKey() == nil || tx.GetSignature() == nil {
+		return false, nil
+	}
+	return CheckSignature(tx)
+}
+
+// Authorizes reports whether key may sign single signed transactions for
+// the account: it is either the regular key, or the master key when that
+// has not been disabled.
+func (a *AccountRoot) Authorizes(key PublicKey) bool {
+	id := key.AccountId()
+	switch {
+	case a.RegularKey != nil && Account(*a.RegularKey) == id:
+		return true
+	case a.Flags != nil && *a.Flags&LsDisableMaster != 0:
+		return false
+	default:
+		return a.Account != nil && *a.Account == id
+	}
+}
+
+// The message signed by a claim is the 'CLM' prefix, the channel id
+// and the amount in drops as a 64-bit unsigned integer
+func claimMessage(channel Hash256, amount Value) ([]byte, error) {
+	if !amount.IsNative() || amount.IsNegative() {
+		return nil, fmt.Errorf("Claim amount must be a positive native value: %s", amount)
+	}
+	msg := append(HP_PAYMENT_CHANNEL_CLAIM.Bytes(), channel[:]...)
+	var drops [8]byte
+	binary.BigEndian.PutUint64(drops[:], amount.num)
+	return append(msg, drops[:]...), nil
+}
+
+// SignClaim returns the off-ledger signature authorising the redemption
+// of amount from a payment channel, as used by PaymentChannelClaim.
+func SignClaim(channel Hash256, amount Value, key crypto.Key, sequence *uint32) (VariableLength, error) {
+	msg, err := claimMessage(channel, amount)
+	if err != nil {
+		return nil, err
+	}
+	return crypto.Sign(key.Private(sequence), crypto.Sha512Half(msg), msg)
+}
+
+// CheckClaimSignature verifies a signature produced by SignClaim
+// against the public key of the channel.
+func CheckClaimSignature(channel Hash256, amount Value, publicKey PublicKey, signature VariableLength) (bool, error) {
+	msg, err := claimMessage(channel, amount)
+	if err != nil {
+		return false, err
+	}
+	return crypto.Verify(publicKey.Bytes(), crypto.Sha512Half(msg), msg, signature.Bytes())
+}
+
+// The signature of account over s, which excludes any existing signers
+func multiSignature(s MultiSignable, key crypto.Key, sequence *uint32, account Account) (VariableLength, error) {
 	hash, msg, err := MultiSigningHash(s, account)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	//
 	msg = append(s.MultiSigningPrefix().Bytes(), msg...)
 	msg = append(msg, account.Bytes()...)
+	return crypto.Sign(key.Private(sequence), hash.Bytes(), msg)
+}
 
-	sig, err := crypto.Sign(key.Private(sequence), hash.Bytes(), msg)
+func MultiSign(s MultiSignable, key crypto.Key, sequence *uint32, account Account) error {
+	s.InitialiseForSigning()
+	sig, err := multiSignature(s, key, sequence, account)
 	if err != nil {
 		return err
 	}
@@ -51,7 +144,64 @@ func MultiSign(s MultiSignable, key crypto.Key, sequence *uint32, account Accoun
 	return nil
 }
 
+// AddSigner signs s on behalf of account and adds the result to the
+// Signers of s, replacing any previous signature by account. The
+// SigningPubKey of s is left empty, as required for multi-signing.
+func AddSigner(s MultiSignable, key crypto.Key, sequence *uint32, account Account) error {
+	s.InitialiseForSigning()
+	*s.GetPublicKey() = PublicKey{}
+	*s.GetSignature() = nil
+	sig, err := multiSignature(s, key, sequence, account)
+	if err != nil {
+		return err
+	}
+	var publicKey PublicKey
+	copy(publicKey[:], key.Public(sequence))
+	signers := []Signer{{SignerItem{
+		Account:       account,
+		TxnSignature:  &sig,
+		SigningPubKey: &publicKey,
+	}}}
+	for _, signer := range s.GetSigners() {
+		if !signer.Signer.Account.Equals(account) {
+			signers = append(signers, signer)
+		}
+	}
+	return SetSigners(s, signers...)
+}
+
+// CheckMultiSignature verifies the signature of every signer of s.
+// Returns false if s has no signers.
+func CheckMultiSignature(s MultiSignable) (bool, error) {
+	signers := s.GetSigners()
+	if len(signers) == 0 {
+		return false, nil
+	}
+	for _, signer := range signers {
+		item := signer.Signer
+		if item.SigningPubKey == nil || item.TxnSignature == nil {
+			return false, fmt.Errorf("Incomplete signer: %s", item.Account)
+		}
+		hash, msg, err := MultiSigningHash(s, item.Account)
+		if err != nil {
+			return false, err
+		}
+		msg = append(s.MultiSigningPrefix().Bytes(), msg...)
+		msg = append(msg, item.Account.Bytes()...)
+		ok, err := crypto.Verify(item.SigningPubKey.Bytes(), hash.Bytes(), msg, item.TxnSignature.Bytes())
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// SetSigners sets the Signers of s, sorted by account as rippled
+// requires, and updates the hash.
 func SetSigners(s MultiSignable, signers ...Signer) error {
+	sort.Slice(signers, func(i, j int) bool {
+		return signers[i].Signer.Account.Less(signers[j].Signer.Account)
+	})
 	s.SetSigners(signers)
 
 	hash, _, err := Raw(s)
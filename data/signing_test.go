@@ -0,0 +1,453 @@
+package data
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/rubblelabs/ripple/crypto"
+	. "gopkg.in/check.v1"
+)
+
+type SigningSuite struct{}
+
+var _ = Suite(&SigningSuite{})
+
+func (s *SigningSuite) TestSignPayment(c *C) {
+	for _, test := range []struct {
+		seed     string
+		keyType  KeyType
+		sequence *uint32
+		account  string
+	}{
+		{"snoPBrXtMeMyMHUVTgbuqAfg1SUTb", ECDSA, new(uint32), "rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh"},
+		{"sEdVQ4wvD1AaTG6JA54qt38TengAuiz", Ed25519, nil, "rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf"},
+	} {
+		seed, keyType, err := ParseSeed(test.seed)
+		c.Assert(err, IsNil)
+		c.Assert(keyType, Equals, test.keyType)
+		c.Assert(seed.Encode(keyType), Equals, test.seed)
+
+		amount, err := NewAmount("1000000")
+		c.Assert(err, IsNil)
+		fee, err := NewNativeValue(10)
+		c.Assert(err, IsNil)
+		payment := &Payment{
+			TxBase: TxBase{
+				TransactionType: PAYMENT,
+				Account:         seed.AccountId(keyType, test.sequence),
+				Sequence:        1,
+				Fee:             *fee,
+			},
+			Destination: zeroAccount,
+			Amount:      *amount,
+		}
+		c.Assert(payment.Account.String(), Equals, test.account)
+		c.Assert(Sign(payment, seed.Key(keyType), test.sequence), IsNil)
+		if keyType == Ed25519 {
+			c.Assert(payment.SigningPubKey[0], Equals, byte(0xED))
+		}
+		ok, err := CheckSignature(payment)
+		c.Assert(err, IsNil)
+		c.Assert(ok, Equals, true)
+		c.Assert(payment.GetHash().IsZero(), Equals, false)
+		c.Assert(*payment.Flags, Equals, TxCanonicalSignature)
+		if keyType == ECDSA {
+			c.Assert(crypto.IsCanonicalSignature(payment.TxnSignature.Bytes()), Equals, true)
+		}
+	}
+}
+
+func (s *SigningSuite) TestGenerateSeed(c *C) {
+	for _, test := range []struct {
+		keyType  KeyType
+		sequence *uint32
+		prefix   string
+	}{
+		{ECDSA, new(uint32), "s"},
+		{Ed25519, nil, "sEd"},
+	} {
+		seed, key, err := GenerateSeed(test.keyType)
+		c.Assert(err, IsNil)
+		encoded := seed.Encode(test.keyType)
+		c.Check(strings.HasPrefix(encoded, test.prefix), Equals, true)
+		parsed, keyType, err := ParseSeed(encoded)
+		c.Assert(err, IsNil)
+		c.Check(keyType, Equals, test.keyType)
+		c.Check(*parsed, Equals, *seed)
+		account := parsed.AccountId(keyType, test.sequence)
+		c.Check(account.Bytes(), DeepEquals, key.Id(test.sequence))
+	}
+}
+
+// The seed of "masterpassphrase" from rippled, and the secp256k1 and ed25519
+// seeds of the xrpl.js keypairs fixtures
+func (s *SigningSuite) TestGenerateSeedVectors(c *C) {
+	master := crypto.Sha512Quarter([]byte("masterpassphrase"))
+	for _, test := range []struct {
+		keyType  KeyType
+		sequence *uint32
+		payload  []byte
+		seed     string
+		account  string
+	}{
+		{ECDSA, new(uint32), master, "snoPBrXtMeMyMHUVTgbuqAfg1SUTb", "rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh"},
+		{Ed25519, nil, master, "sEdVQ4wvD1AaTG6JA54qt38TengAuiz", "rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf"},
+		{ECDSA, new(uint32), nil, "sp5fghtJtpUorTwvof1NpDXAzNwf5", "rU6K7V3Po4snVhBBaU29sesqs2qTQJWDw1"},
+		{Ed25519, nil, nil, "sEdSKaCy2JT7JaM7v95H9SxkhP9wS2r", "rLUEXYuLiQptky37CqLcm9USQpPiz5rkpD"},
+	} {
+		if test.payload == nil {
+			parsed, keyType, err := ParseSeed(test.seed)
+			c.Assert(err, IsNil)
+			c.Check(keyType, Equals, test.keyType)
+			test.payload = parsed.Bytes()
+		}
+		seed, key := newSeed(test.payload, test.keyType)
+		c.Check(seed.Encode(test.keyType), Equals, test.seed)
+		account, err := crypto.AccountId(key, test.sequence)
+		c.Assert(err, IsNil)
+		c.Check(account.String(), Equals, test.account)
+		c.Check(seed.AccountId(test.keyType, test.sequence).String(), Equals, test.account)
+	}
+}
+
+func (s *SigningSuite) TestSignCanonicalFlag(c *C) {
+	seed, keyType, err := ParseSeed("snoPBrXtMeMyMHUVTgbuqAfg1SUTb")
+	c.Assert(err, IsNil)
+	amount, err := NewAmount("1000000")
+	c.Assert(err, IsNil)
+	flags := TxPartialPayment
+	payment := &Payment{
+		TxBase: TxBase{
+			TransactionType: PAYMENT,
+			Flags:           &flags,
+			Account:         seed.AccountId(keyType, new(uint32)),
+			Sequence:        1,
+		},
+		Destination: zeroAccount,
+		Amount:      *amount,
+	}
+	c.Assert(Sign(payment, seed.Key(keyType), new(uint32)), IsNil)
+	c.Check(*payment.Flags, Equals, TxPartialPayment|TxCanonicalSignature)
+	ok, err := CheckSignature(payment)
+	c.Assert(err, IsNil)
+	c.Check(ok, Equals, true)
+}
+
+func (s *SigningSuite) TestVerifyTransaction(c *C) {
+	seed, keyType, err := ParseSeed("snoPBrXtMeMyMHUVTgbuqAfg1SUTb")
+	c.Assert(err, IsNil)
+	amount, err := NewAmount("1000000")
+	c.Assert(err, IsNil)
+	payment := &Payment{
+		TxBase: TxBase{
+			TransactionType: PAYMENT,
+			Account:         seed.AccountId(keyType, new(uint32)),
+			Sequence:        1,
+		},
+		Destination: zeroAccount,
+		Amount:      *amount,
+	}
+	ok, err := VerifyTransaction(payment)
+	c.Assert(err, IsNil)
+	c.Check(ok, Equals, false)
+
+	c.Assert(Sign(payment, seed.Key(keyType), new(uint32)), IsNil)
+	ok, err = VerifyTransaction(payment)
+	c.Assert(err, IsNil)
+	c.Check(ok, Equals, true)
+
+	// Tampering changes the hash, and without it the signature
+	payment.Sequence = 2
+	_, err = VerifyTransaction(payment)
+	c.Check(err, ErrorMatches, "Hash .* does not match transaction .*")
+	payment.TxBase.Hash = Hash256{}
+	ok, err = VerifyTransaction(payment)
+	c.Assert(err, IsNil)
+	c.Check(ok, Equals, false)
+}
+
+func (s *SigningSuite) TestSignWithRegularKey(c *C) {
+	master, masterType, err := ParseSeed("snoPBrXtMeMyMHUVTgbuqAfg1SUTb")
+	c.Assert(err, IsNil)
+	regular, regularType, err := ParseSeed("sEdVQ4wvD1AaTG6JA54qt38TengAuiz")
+	c.Assert(err, IsNil)
+	account := master.AccountId(masterType, new(uint32))
+	regularKey := RegularKey(regular.AccountId(regularType, nil))
+
+	// The master key assigns the regular key
+	setRegularKey := &SetRegularKey{
+		TxBase: TxBase{
+			TransactionType: SET_REGULAR_KEY,
+			Account:         account,
+			Sequence:        1,
+		},
+		RegularKey: &regularKey,
+	}
+	c.Assert(Sign(setRegularKey, master.Key(masterType), new(uint32)), IsNil)
+	ok, err := VerifyTransaction(setRegularKey)
+	c.Assert(err, IsNil)
+	c.Check(ok, Equals, true)
+
+	// Which then signs for the account
+	amount, err := NewAmount("1000000")
+	c.Assert(err, IsNil)
+	payment := &Payment{
+		TxBase: TxBase{
+			TransactionType: PAYMENT,
+			Account:         account,
+			Sequence:        2,
+		},
+		Destination: zeroAccount,
+		Amount:      *amount,
+	}
+	c.Assert(Sign(payment, regular.Key(regularType), nil), IsNil)
+	c.Check(payment.Account, Equals, account)
+	c.Check(payment.SigningPubKey.AccountId(), Equals, Account(regularKey))
+	ok, err = VerifyTransaction(payment)
+	c.Assert(err, IsNil)
+	c.Check(ok, Equals, true)
+
+	root := &AccountRoot{Account: &account}
+	c.Check(root.Authorizes(*setRegularKey.SigningPubKey), Equals, true)
+	c.Check(root.Authorizes(*payment.SigningPubKey), Equals, false)
+	root.RegularKey = &regularKey
+	c.Check(root.Authorizes(*setRegularKey.SigningPubKey), Equals, true)
+	c.Check(root.Authorizes(*payment.SigningPubKey), Equals, true)
+	disabled := LsDisableMaster
+	root.Flags = &disabled
+	c.Check(root.Authorizes(*setRegularKey.SigningPubKey), Equals, false)
+	c.Check(root.Authorizes(*payment.SigningPubKey), Equals, true)
+}
+
+func (s *SigningSuite) TestTransactionHashes(c *C) {
+	test := findTransaction(c, "DepositPreauth")
+	tx, err := ReadTransaction(test.Reader())
+	c.Assert(err, IsNil)
+
+	hash, err := TransactionHash(tx)
+	c.Assert(err, IsNil)
+	c.Check(hash.String(), Equals, "5712A9F08C6D8896B0AF6D258E9D90B55AAC9933EB28B6500F50708DDF5C829F")
+	expected := crypto.Sha512Half(append([]byte("TXN\x00"), test.Bytes()...))
+	c.Check(hash.Bytes(), DeepEquals, expected)
+
+	// Signing hash excludes the signature but not the public key
+	signingHash, err := TransactionSigningHash(tx)
+	c.Assert(err, IsNil)
+	c.Check(signingHash, Not(Equals), hash)
+	base := tx.GetBase()
+	signature := *base.TxnSignature
+	base.TxnSignature = nil
+	_, unsigned, err := Raw(tx)
+	c.Assert(err, IsNil)
+	expected = crypto.Sha512Half(append([]byte("STX\x00"), unsigned...))
+	c.Check(signingHash.Bytes(), DeepEquals, expected)
+	ok, err := crypto.Verify(base.SigningPubKey.Bytes(), signingHash.Bytes(), nil, signature)
+	c.Assert(err, IsNil)
+	c.Check(ok, Equals, true)
+
+	// Changing the signature changes the transaction hash only
+	base.TxnSignature = &VariableLength{0x30}
+	changed, err := TransactionHash(tx)
+	c.Assert(err, IsNil)
+	c.Check(changed, Not(Equals), hash)
+	unchanged, err := TransactionSigningHash(tx)
+	c.Assert(err, IsNil)
+	c.Check(unchanged, Equals, signingHash)
+
+	// The methods are the same as the functions
+	method, err := tx.Hash()
+	c.Assert(err, IsNil)
+	c.Check(method, Equals, changed)
+	signingMethod, err := tx.SigningHash()
+	c.Assert(err, IsNil)
+	c.Check(signingMethod, Equals, signingHash)
+}
+
+func (s *SigningSuite) TestSignClaim(c *C) {
+	channel, err := NewHash256("5DB01B7FFED6B67E6B0414DED11E051D2EE2B7619CE0EAA6286D67A3A4D5BDB3")
+	c.Assert(err, IsNil)
+	amount, err := NewNativeValue(1000000)
+	c.Assert(err, IsNil)
+	other, err := NewNativeValue(1000001)
+	c.Assert(err, IsNil)
+	for _, test := range []struct {
+		seed     string
+		sequence *uint32
+	}{
+		{"snoPBrXtMeMyMHUVTgbuqAfg1SUTb", new(uint32)},
+		{"sEdVQ4wvD1AaTG6JA54qt38TengAuiz", nil},
+	} {
+		seed, keyType, err := ParseSeed(test.seed)
+		c.Assert(err, IsNil)
+		key := seed.Key(keyType)
+		var publicKey PublicKey
+		copy(publicKey[:], key.Public(test.sequence))
+
+		signature, err := SignClaim(*channel, *amount, key, test.sequence)
+		c.Assert(err, IsNil)
+		ok, err := CheckClaimSignature(*channel, *amount, publicKey, signature)
+		c.Assert(err, IsNil)
+		c.Check(ok, Equals, true)
+		ok, err = CheckClaimSignature(*channel, *other, publicKey, signature)
+		c.Assert(err, IsNil)
+		c.Check(ok, Equals, false)
+	}
+
+	seed, err := NewSeedFromAddress("snoPBrXtMeMyMHUVTgbuqAfg1SUTb")
+	c.Assert(err, IsNil)
+	usd, err := NewValue("1", false)
+	c.Assert(err, IsNil)
+	_, err = SignClaim(*channel, *usd, seed.Key(ECDSA), new(uint32))
+	c.Check(err, ErrorMatches, "Claim amount must be a positive native value.*")
+}
+
+func (s *SigningSuite) TestPaymentChannelClaim(c *C) {
+	seed, err := NewSeedFromAddress("snoPBrXtMeMyMHUVTgbuqAfg1SUTb")
+	c.Assert(err, IsNil)
+	channel, err := NewHash256("5DB01B7FFED6B67E6B0414DED11E051D2EE2B7619CE0EAA6286D67A3A4D5BDB3")
+	c.Assert(err, IsNil)
+	balance, err := NewAmount("1000000")
+	c.Assert(err, IsNil)
+	sequence := new(uint32)
+	signature, err := SignClaim(*channel, *balance.Value, seed.Key(ECDSA), sequence)
+	c.Assert(err, IsNil)
+	var publicKey PublicKey
+	copy(publicKey[:], seed.Key(ECDSA).Public(sequence))
+
+	flags := TxClose
+	claim := checkRoundTrip(c, &PaymentChannelClaim{
+		TxBase: TxBase{
+			TransactionType: PAYCHAN_CLAIM,
+			Flags:           &flags,
+		},
+		Channel:   *channel,
+		Balance:   balance,
+		Amount:    balance,
+		Signature: &signature,
+		PublicKey: &publicKey,
+	}).(*PaymentChannelClaim)
+	ok, err := CheckClaimSignature(claim.Channel, *claim.Balance.Value, *claim.PublicKey, *claim.Signature)
+	c.Assert(err, IsNil)
+	c.Check(ok, Equals, true)
+}
+
+func (s *SigningSuite) TestMultiSign(c *C) {
+	ecdsaSeed, _, err := ParseSeed("snoPBrXtMeMyMHUVTgbuqAfg1SUTb")
+	c.Assert(err, IsNil)
+	ed25519Seed, _, err := ParseSeed("sEdVQ4wvD1AaTG6JA54qt38TengAuiz")
+	c.Assert(err, IsNil)
+	sequence := new(uint32)
+	first := ecdsaSeed.AccountId(ECDSA, sequence)
+	second := ed25519Seed.AccountId(Ed25519, nil)
+	c.Assert(second.Less(first), Equals, true)
+
+	owner, err := NewAccountFromAddress("r9cZA1mLK5R5Am25ArfXFmqgNwjZgnfk59")
+	c.Assert(err, IsNil)
+	amount, err := NewAmount("1000000")
+	c.Assert(err, IsNil)
+	fee, err := NewNativeValue(30)
+	c.Assert(err, IsNil)
+	payment := &Payment{
+		TxBase: TxBase{
+			TransactionType: PAYMENT,
+			Account:         *owner,
+			Sequence:        1,
+			Fee:             *fee,
+		},
+		Destination: first,
+		Amount:      *amount,
+	}
+	c.Assert(AddSigner(payment, ecdsaSeed.Key(ECDSA), sequence, first), IsNil)
+	c.Assert(AddSigner(payment, ed25519Seed.Key(Ed25519), nil, second), IsNil)
+	// Signing again replaces the previous signature
+	c.Assert(AddSigner(payment, ecdsaSeed.Key(ECDSA), sequence, first), IsNil)
+
+	c.Assert(payment.Signers, HasLen, 2)
+	c.Check(payment.Signers[0].Signer.Account, Equals, second)
+	c.Check(payment.Signers[1].Signer.Account, Equals, first)
+	c.Check(payment.SigningPubKey.IsZero(), Equals, true)
+	ok, err := CheckMultiSignature(payment)
+	c.Assert(err, IsNil)
+	c.Check(ok, Equals, true)
+	ok, err = VerifyTransaction(payment)
+	c.Assert(err, IsNil)
+	c.Check(ok, Equals, true)
+
+	hash, raw, err := Raw(payment)
+	c.Assert(err, IsNil)
+	c.Check(hash, Equals, *payment.GetHash())
+	decoded, err := ReadTransaction(bytes.NewReader(raw))
+	c.Assert(err, IsNil)
+	decodedHash, _, err := Raw(decoded)
+	c.Assert(err, IsNil)
+	c.Check(decodedHash, Equals, hash)
+	ok, err = CheckMultiSignature(decoded.(MultiSignable))
+	c.Assert(err, IsNil)
+	c.Check(ok, Equals, true)
+
+	// Any change to the transaction invalidates the signatures
+	payment.Sequence++
+	ok, err = CheckMultiSignature(payment)
+	c.Assert(err, IsNil)
+	c.Check(ok, Equals, false)
+}
+
+func (s *SigningSuite) TestSignerListSet(c *C) {
+	first, err := NewAccountFromAddress("rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf")
+	c.Assert(err, IsNil)
+	second, err := NewAccountFromAddress("r9cZA1mLK5R5Am25ArfXFmqgNwjZgnfk59")
+	c.Assert(err, IsNil)
+	weight := uint16(1)
+	tx := checkRoundTrip(c, &SignerListSet{
+		TxBase:       TxBase{TransactionType: SIGNER_LIST_SET},
+		SignerQuorum: 2,
+		SignerEntries: []SignerEntry{
+			{SignerEntryItem{Account: first, SignerWeight: &weight}},
+			{SignerEntryItem{Account: second, SignerWeight: &weight}},
+		},
+	}).(*SignerListSet)
+	c.Assert(tx.SignerEntries, HasLen, 2)
+	c.Check(*tx.SignerEntries[0].SignerEntry.Account, Equals, *first)
+	c.Check(*tx.SignerEntries[1].SignerEntry.SignerWeight, Equals, weight)
+}
+
+func (s *SigningSuite) TestSignerListEntry(c *C) {
+	owner, err := NewAccountFromAddress("rf1BiGeXwwQoi8Z2ueFYTEXSwuJYfV2Jpn")
+	c.Assert(err, IsNil)
+	index, err := GetSignerListIndex(*owner)
+	c.Assert(err, IsNil)
+	c.Check(index.String(), Equals, "A9C28A28B85CD533217F5C0A0C7767666B093FA58A0F2D80026FCC4CD932DDC7")
+
+	first, err := NewAccountFromAddress("rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf")
+	c.Assert(err, IsNil)
+	second, err := NewAccountFromAddress("rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B")
+	c.Assert(err, IsNil)
+	flags, quorum, id, node := LedgerEntryFlag(0), uint32(3), uint32(0), NodeIndex(0)
+	weights := []uint16{2, 1}
+	list := &SignerList{
+		leBase:       leBase{LedgerEntryType: SIGNER_LIST, Hash: *index},
+		Flags:        &flags,
+		OwnerNode:    &node,
+		SignerQuorum: &quorum,
+		SignerEntries: []SignerEntry{
+			{SignerEntryItem{Account: first, SignerWeight: &weights[0]}},
+			{SignerEntryItem{Account: second, SignerWeight: &weights[1]}},
+		},
+		SignerListID: &id,
+	}
+	_, raw, err := Raw(list)
+	c.Assert(err, IsNil)
+	le, err := ReadLedgerEntry(bytes.NewReader(raw), *index)
+	c.Assert(err, IsNil)
+	decoded, ok := le.(*SignerList)
+	c.Assert(ok, Equals, true)
+	c.Check(*decoded.GetHash(), Equals, *index)
+	c.Check(*decoded.SignerQuorum, Equals, uint32(3))
+	c.Assert(decoded.SignerEntries, HasLen, 2)
+	c.Check(*decoded.SignerEntries[0].SignerEntry.Account, Equals, *first)
+	c.Check(*decoded.SignerEntries[0].SignerEntry.SignerWeight, Equals, uint16(2))
+	c.Check(*decoded.SignerEntries[1].SignerEntry.Account, Equals, *second)
+	c.Check(*decoded.SignerEntries[1].SignerEntry.SignerWeight, Equals, uint16(1))
+	c.Check(decoded.Affects(*second), Equals, true)
+	c.Check(decoded.Affects(*owner), Equals, false)
+}
@@ -1,6 +1,8 @@
 package data
 
 import (
+	"fmt"
+	"math"
 	"time"
 )
 
@@ -22,6 +24,16 @@ func NewRippleTime(t uint32) *RippleTime {
 	return &RippleTime{t}
 }
 
+// NewRippleTimeFromTime converts t, rounded down to the second, which must
+// be representable as the uint32 of a field such as Expiration or CancelAfter
+func NewRippleTimeFromTime(t time.Time) (*RippleTime, error) {
+	seconds := t.Unix() - rippleTimeEpoch
+	if seconds < 0 || seconds > math.MaxUint32 {
+		return nil, fmt.Errorf("Time out of range for the Ripple epoch: %s", t.UTC().Format(rippleTimeFormat))
+	}
+	return &RippleTime{uint32(seconds)}, nil
+}
+
 func convertToRippleTime(t time.Time) uint32 {
 	return uint32(t.Sub(time.Unix(rippleTimeEpoch, 0)).Nanoseconds() / 1000000000)
 }
@@ -30,6 +42,11 @@ func (t RippleTime) Time() time.Time {
 	return time.Unix(int64(t.T)+rippleTimeEpoch, 0)
 }
 
+// Unix returns the time as the number of seconds since the Unix epoch
+func (t RippleTime) Unix() int64 {
+	return int64(t.T) + rippleTimeEpoch
+}
+
 func Now() *RippleTime {
 	return &RippleTime{convertToRippleTime(time.Now())}
 }
@@ -0,0 +1,49 @@
+package data
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+
+	. "gopkg.in/check.v1"
+)
+
+type TimeSuite struct{}
+
+var _ = Suite(&TimeSuite{})
+
+func (s *TimeSuite) TestConversions(c *C) {
+	epoch, err := NewRippleTimeFromTime(time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC))
+	c.Assert(err, IsNil)
+	c.Check(epoch.Uint32(), Equals, uint32(0))
+	c.Check(epoch.Unix(), Equals, int64(946684800))
+
+	// The zone of a time makes no difference to the instant it is
+	zone := time.FixedZone("UTC+10", 10*60*60)
+	t, err := NewRippleTimeFromTime(time.Date(2017, time.April, 13, 9, 15, 32, 999999999, zone))
+	c.Assert(err, IsNil)
+	c.Check(t.Uint32(), Equals, uint32(545354132))
+	c.Check(t.String(), Equals, "2017-Apr-12 23:15:32 UTC")
+	c.Check(t.Time().Equal(time.Date(2017, time.April, 12, 23, 15, 32, 0, time.UTC)), Equals, true)
+
+	var parsed RippleTime
+	c.Assert(parsed.SetString("2017-Apr-12 23:15:32 UTC"), IsNil)
+	c.Check(parsed, Equals, *t)
+}
+
+func (s *TimeSuite) TestMarshalling(c *C) {
+	t := NewRippleTime(545354132)
+	b, err := json.Marshal(t)
+	c.Assert(err, IsNil)
+	c.Check(string(b), Equals, "545354132")
+	var decoded RippleTime
+	c.Assert(json.Unmarshal(b, &decoded), IsNil)
+	c.Check(decoded, Equals, *t)
+
+	var buf bytes.Buffer
+	c.Assert(t.Marshal(&buf), IsNil)
+	c.Check(buf.Bytes(), DeepEquals, []byte{0x20, 0x81, 0x71, 0x94})
+	decoded = RippleTime{}
+	c.Assert(decoded.Unmarshal(bytes.NewReader(buf.Bytes())), IsNil)
+	c.Check(decoded, Equals, *t)
+}
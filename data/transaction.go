@@ -1,5 +1,14 @@
 package data
 
+import (
+	"crypto/md5"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+	"time"
+)
+
 type TxBase struct {
 	TransactionType    TransactionType
 	Flags              *TransactionFlag `json:",omitempty"`
@@ -14,6 +23,7 @@ type TxBase struct {
 	Memos              Memos           `json:",omitempty"`
 	PreviousTxnID      *Hash256        `json:",omitempty"`
 	LastLedgerSequence *uint32         `json:",omitempty"`
+	TicketSequence     *uint32         `json:",omitempty"`
 	Hash               Hash256         `json:"hash"`
 }
 
@@ -27,6 +37,14 @@ type Signer struct {
 	Signer SignerItem
 }
 
+type AuthAccountItem struct {
+	Account Account
+}
+
+type AuthAccount struct {
+	AuthAccount AuthAccountItem
+}
+
 type Payment struct {
 	TxBase
 	Destination    Account
@@ -36,59 +54,98 @@ type Payment struct {
 	Paths          *PathSet `json:",omitempty"`
 	DestinationTag *uint32  `json:",omitempty"`
 	InvoiceID      *Hash256 `json:",omitempty"`
-	TicketSequence *uint32  `json:",omitempty"`
 }
 
-type AccountSet struct {
-	TxBase
-	EmailHash      *Hash128        `json:",omitempty"`
-	WalletLocator  *Hash256        `json:",omitempty"`
-	WalletSize     *uint32         `json:",omitempty"`
-	MessageKey     *VariableLength `json:",omitempty"`
-	Domain         *VariableLength `json:",omitempty"`
-	TransferRate   *uint32         `json:",omitempty"`
-	TickSize       *uint8          `json:",omitempty"`
-	SetFlag        *uint32         `json:",omitempty"`
-	ClearFlag      *uint32         `json:",omitempty"`
-	TicketSequence *uint32         `json:",omitempty"`
+// Validate checks that Paths, when set, is well formed
+func (p *Payment) Validate() error {
+	if p.Paths == nil {
+		return nil
+	}
+	return p.Paths.Validate()
 }
 
+type AccountSet struct {
+	TxBase
+	EmailHash     *Hash128        `json:",omitempty"`
+	WalletLocator *Hash256        `json:",omitempty"`
+	WalletSize    *uint32         `json:",omitempty"`
+	MessageKey    *VariableLength `json:",omitempty"`
+	Domain        *VariableLength `json:",omitempty"`
+	TransferRate  *uint32         `json:",omitempty"`
+	TickSize      *uint8          `json:",omitempty"`
+	SetFlag       *uint32         `json:",omitempty"`
+	ClearFlag     *uint32         `json:",omitempty"`
+	NFTokenMinter *Account        `json:",omitempty"`
+}
+
+// Limits rippled places on the fields of an AccountSet
+const (
+	MaxTransferRate uint32 = 2000000000 // Fees of 100%
+	MinTickSize     uint8  = 3
+	MaxTickSize     uint8  = 15
+	maxDomainLength        = 256
+)
+
+// https://xrpl.org/accountdelete.html
+// The remaining XRP of Account is sent to Destination. The Fee is not the
+// usual transaction cost but the owner reserve increment, see DeletionFee.
 type AccountDelete struct {
 	TxBase
 	Destination    Account
 	DestinationTag *uint32 `json:",omitempty"`
-	TicketSequence *uint32 `json:",omitempty"`
+}
+
+// DeletionFee returns the fee required to submit the AccountDelete given the
+// owner reserve increment of the network, which is destroyed rather than
+// returned to Destination.
+func (a *AccountDelete) DeletionFee(reserveIncrement Value) (*Value, error) {
+	if !reserveIncrement.IsNative() || reserveIncrement.IsNegative() {
+		return nil, fmt.Errorf("Reserve increment must be a positive native value: %s", reserveIncrement)
+	}
+	return reserveIncrement.Clone(), nil
+}
+
+// Validate checks that Destination is set and is not the deleted account
+func (a *AccountDelete) Validate() error {
+	switch {
+	case a.Destination.IsZero():
+		return fmt.Errorf("AccountDelete requires a Destination")
+	case a.Destination.Equals(a.Account):
+		return fmt.Errorf("AccountDelete cannot send to the deleted account: %s", a.Account)
+	default:
+		return nil
+	}
 }
 
 type SetRegularKey struct {
 	TxBase
-	RegularKey     *RegularKey `json:",omitempty"`
-	TicketSequence *uint32     `json:",omitempty"`
+	RegularKey *RegularKey `json:",omitempty"`
 }
 
 type OfferCreate struct {
 	TxBase
-	OfferSequence  *uint32 `json:",omitempty"`
-	TakerPays      Amount
-	TakerGets      Amount
-	Expiration     *uint32 `json:",omitempty"`
-	TicketSequence *uint32 `json:",omitempty"`
+	OfferSequence *uint32 `json:",omitempty"`
+	TakerPays     Amount
+	TakerGets     Amount
+	Expiration    *RippleTime `json:",omitempty"`
 }
 
 type OfferCancel struct {
 	TxBase
-	OfferSequence  uint32
-	TicketSequence *uint32 `json:",omitempty"`
+	OfferSequence uint32
 }
 
 type TrustSet struct {
 	TxBase
-	LimitAmount    Amount
-	QualityIn      *uint32 `json:",omitempty"`
-	QualityOut     *uint32 `json:",omitempty"`
-	TicketSequence *uint32 `json:",omitempty"`
+	LimitAmount Amount
+	QualityIn   *uint32 `json:",omitempty"`
+	QualityOut  *uint32 `json:",omitempty"`
 }
 
+// The QualityIn or QualityOut of a trust line which values balances at par,
+// which is what an unset quality means
+const QualityOne uint32 = 1000000000
+
 type SetFee struct {
 	TxBase
 	BaseFee           Uint64Hex
@@ -102,32 +159,51 @@ type Amendment struct {
 	Amendment Hash256
 }
 
+// https://xrpl.org/escrowcreate.html
+// Condition is a DER encoded crypto-condition, see crypto.PreimageSha256Condition
 type EscrowCreate struct {
 	TxBase
 	Destination    Account
 	Amount         Amount
-	Digest         *Hash256 `json:",omitempty"`
-	CancelAfter    *uint32  `json:",omitempty"`
-	FinishAfter    *uint32  `json:",omitempty"`
-	DestinationTag *uint32  `json:",omitempty"`
-	TicketSequence *uint32  `json:",omitempty"`
+	Digest         *Hash256        `json:",omitempty"`
+	Condition      *VariableLength `json:",omitempty"`
+	CancelAfter    *RippleTime     `json:",omitempty"`
+	FinishAfter    *RippleTime     `json:",omitempty"`
+	DestinationTag *uint32         `json:",omitempty"`
 }
 
+// https://xrpl.org/escrowfinish.html
+// Condition and Fulfillment must be supplied together for conditional escrows
 type EscrowFinish struct {
 	TxBase
-	Owner          Account
-	OfferSequence  uint32
-	Method         *uint8   `json:",omitempty"`
-	Digest         *Hash256 `json:",omitempty"`
-	Proof          *Hash256 `json:",omitempty"`
-	TicketSequence *uint32  `json:",omitempty"`
+	Owner         Account
+	OfferSequence uint32
+	Method        *uint8          `json:",omitempty"`
+	Digest        *Hash256        `json:",omitempty"`
+	Proof         *Hash256        `json:",omitempty"`
+	Condition     *VariableLength `json:",omitempty"`
+	Fulfillment   *VariableLength `json:",omitempty"`
+}
+
+// FulfillmentFee returns the fee required to submit the EscrowFinish given
+// the base fee of the network. Finishing with a fulfillment costs
+// baseFee * (33 + len(Fulfillment)/16), otherwise just baseFee.
+func (e *EscrowFinish) FulfillmentFee(baseFee Value) (*Value, error) {
+	if e.Fulfillment == nil || len(*e.Fulfillment) == 0 {
+		return baseFee.Clone(), nil
+	}
+	factor, err := NewNativeValue(int64(33 + len(*e.Fulfillment)/16))
+	if err != nil {
+		return nil, err
+	}
+	return baseFee.Multiply(*factor)
 }
 
+// https://xrpl.org/escrowcancel.html
 type EscrowCancel struct {
 	TxBase
-	Owner          Account
-	OfferSequence  uint32
-	TicketSequence *uint32 `json:",omitempty"`
+	Owner         Account
+	OfferSequence uint32
 }
 
 type PaymentChannelCreate struct {
@@ -136,28 +212,25 @@ type PaymentChannelCreate struct {
 	Destination    Account
 	SettleDelay    uint32
 	PublicKey      PublicKey
-	CancelAfter    *uint32 `json:",omitempty"`
-	DestinationTag *uint32 `json:",omitempty"`
-	SourceTag      *uint32 `json:",omitempty"`
-	TicketSequence *uint32 `json:",omitempty"`
+	CancelAfter    *RippleTime `json:",omitempty"`
+	DestinationTag *uint32     `json:",omitempty"`
+	SourceTag      *uint32     `json:",omitempty"`
 }
 
 type PaymentChannelFund struct {
 	TxBase
-	Channel        Hash256
-	Amount         Amount
-	Expiration     *uint32 `json:",omitempty"`
-	TicketSequence *uint32 `json:",omitempty"`
+	Channel    Hash256
+	Amount     Amount
+	Expiration *RippleTime `json:",omitempty"`
 }
 
 type PaymentChannelClaim struct {
 	TxBase
-	Channel        Hash256
-	Balance        *Amount         `json:",omitempty"`
-	Amount         *Amount         `json:",omitempty"`
-	Signature      *VariableLength `json:",omitempty"`
-	PublicKey      *PublicKey      `json:",omitempty"`
-	TicketSequence *uint32         `json:",omitempty"`
+	Channel   Hash256
+	Balance   *Amount         `json:",omitempty"`
+	Amount    *Amount         `json:",omitempty"`
+	Signature *VariableLength `json:",omitempty"`
+	PublicKey *PublicKey      `json:",omitempty"`
 }
 
 // CheckCreate, CheckCash, CheckCancel enabled by amendment 157D2D480E006395B76F948E3E07A45A05FE10230D88A7993C71F97AE4B1F2D1
@@ -167,40 +240,35 @@ type CheckCreate struct {
 	TxBase
 	Destination    Account
 	SendMax        Amount
-	DestinationTag *uint32  `json:",omitempty"`
-	Expiration     *uint32  `json:",omitempty"`
-	InvoiceID      *Hash256 `json:",omitempty"`
-	TicketSequence *uint32  `json:",omitempty"`
+	DestinationTag *uint32     `json:",omitempty"`
+	Expiration     *RippleTime `json:",omitempty"`
+	InvoiceID      *Hash256    `json:",omitempty"`
 }
 
 // https://ripple.com/build/transactions/#checkcash
 // Must include one of Amount or DeliverMin
 type CheckCash struct {
 	TxBase
-	CheckID        Hash256
-	Amount         *Amount `json:",omitempty"`
-	DeliverMin     *Amount `json:",omitempty"`
-	TicketSequence *uint32 `json:",omitempty"`
+	CheckID    Hash256
+	Amount     *Amount `json:",omitempty"`
+	DeliverMin *Amount `json:",omitempty"`
 }
 
 // https://ripple.com/build/transactions/#checkcancel
 type CheckCancel struct {
 	TxBase
-	CheckID        Hash256
-	TicketSequence *uint32 `json:",omitempty"`
+	CheckID Hash256
 }
 
 type TicketCreate struct {
 	TxBase
-	TicketCount    *uint32 `json:",omitempty"`
-	TicketSequence *uint32 `json:",omitempty"`
+	TicketCount *uint32 `json:",omitempty"`
 }
 
 type SignerListSet struct {
 	TxBase
-	SignerQuorum   uint32        `json:",omitempty"`
-	SignerEntries  []SignerEntry `json:",omitempty"`
-	TicketSequence *uint32       `json:",omitempty"`
+	SignerQuorum  uint32        `json:",omitempty"`
+	SignerEntries []SignerEntry `json:",omitempty"`
 }
 
 type UNLModify struct {
@@ -209,52 +277,383 @@ type UNLModify struct {
 	UNLModifyValidator *VariableLength `json:",omitempty"`
 }
 
+// https://xrpl.org/depositpreauth.html
 type SetDepositPreAuth struct {
 	TxBase
-	Authorize      *Account `json:",omitempty"`
-	Unauthorize    *Account `json:",omitempty"`
-	TicketSequence *uint32  `json:",omitempty"`
+	Authorize   *Account `json:",omitempty"`
+	Unauthorize *Account `json:",omitempty"`
+}
+
+// Validate checks that exactly one of Authorize or Unauthorize is set
+// and that the account is not preauthorizing itself.
+func (d *SetDepositPreAuth) Validate() error {
+	switch {
+	case (d.Authorize == nil) == (d.Unauthorize == nil):
+		return fmt.Errorf("DepositPreauth requires exactly one of Authorize or Unauthorize")
+	case d.Authorize != nil && d.Authorize.Equals(d.Account):
+		return fmt.Errorf("DepositPreauth cannot authorize its own account: %s", d.Account)
+	case d.Authorize != nil && d.Authorize.IsZero(), d.Unauthorize != nil && d.Unauthorize.IsZero():
+		return fmt.Errorf("DepositPreauth has an empty account")
+	default:
+		return nil
+	}
 }
 
 type NFTokenMint struct {
 	TxBase
-	NFTokenTaxon   *uint32         `json:",omitempty"`
-	TransferFee    *uint16         `json:",omitempty"`
-	Issuer         *Account        `json:",omitempty"`
-	URI            *VariableLength `json:",omitempty"`
-	TicketSequence *uint32         `json:",omitempty"`
+	NFTokenTaxon *uint32         `json:",omitempty"`
+	TransferFee  *uint16         `json:",omitempty"`
+	Issuer       *Account        `json:",omitempty"`
+	URI          *VariableLength `json:",omitempty"`
 }
 
 type NFTokenBurn struct {
 	TxBase
-	Owner          *Account `json:",omitempty"`
-	TicketSequence *uint32  `json:",omitempty"`
+	Owner *Account `json:",omitempty"`
 }
 
 type NFTokenCreateOffer struct {
 	TxBase
-	NFTokenID      *Hash256 `json:",omitempty"`
-	Amount         *Amount  `json:",omitempty"`
-	Destination    *Account `json:",omitempty"`
-	Owner          *Account `json:",omitempty"`
-	Expiration     *uint32  `json:",omitempty"`
-	TicketSequence *uint32  `json:",omitempty"`
+	NFTokenID   *Hash256    `json:",omitempty"`
+	Amount      *Amount     `json:",omitempty"`
+	Destination *Account    `json:",omitempty"`
+	Owner       *Account    `json:",omitempty"`
+	Expiration  *RippleTime `json:",omitempty"`
 }
 
-type NFTCancelOffer struct {
+type NFTokenCancelOffer struct {
 	TxBase
-	NFTokenOffers  *Vector256 `json:",omitempty"`
-	TicketSequence *uint32    `json:",omitempty"`
+	NFTokenOffers *Vector256 `json:",omitempty"`
 }
 
-type NFTAcceptOffer struct {
+type NFTokenAcceptOffer struct {
 	TxBase
 	NFTokenBuyOffer  *Hash256 `json:",omitempty"`
 	NFTokenSellOffer *Hash256 `json:",omitempty"`
 	NFTokenBrokerFee *Amount  `json:",omitempty"`
-	TicketSequence   *uint32  `json:",omitempty"`
 }
 
+// https://xrpl.org/clawback.html
+// The issuer of Amount is the holder the tokens are clawed back from,
+// the tokens themselves are always issued by Account.
+type Clawback struct {
+	TxBase
+	Amount Amount
+}
+
+// Holder returns the account the tokens are clawed back from
+func (c *Clawback) Holder() Account {
+	return c.Amount.Issuer
+}
+
+// Validate checks that Amount is a positive issued currency held by an
+// account other than the issuer.
+func (c *Clawback) Validate() error {
+	switch {
+	case c.Amount.Value == nil || c.Amount.IsNative():
+		return fmt.Errorf("Clawback amount must be an issued currency")
+	case c.Amount.IsNegative() || c.Amount.IsZero():
+		return fmt.Errorf("Clawback amount must be positive: %s", c.Amount)
+	case c.Amount.Issuer.Equals(c.Account):
+		return fmt.Errorf("Clawback holder cannot be the issuer: %s", c.Account)
+	default:
+		return nil
+	}
+}
+
+// AMMCreate, AMMDeposit, AMMWithdraw enabled by amendment 8CC0774A3BF66D1D22E76BBDA8E8A232E6B6313834301B3B23E8601196AE6455
+
+// https://xrpl.org/ammcreate.html
+type AMMCreate struct {
+	TxBase
+	Amount     Amount
+	Amount2    Amount
+	TradingFee uint16
+}
+
+// https://xrpl.org/ammdeposit.html
+// The combination of optional fields must match the mode set in Flags
+type AMMDeposit struct {
+	TxBase
+	Asset      Issue
+	Asset2     Issue
+	Amount     *Amount `json:",omitempty"`
+	Amount2    *Amount `json:",omitempty"`
+	EPrice     *Amount `json:",omitempty"`
+	LPTokenOut *Amount `json:",omitempty"`
+	TradingFee *uint16 `json:",omitempty"`
+}
+
+// https://xrpl.org/ammwithdraw.html
+// The combination of optional fields must match the mode set in Flags
+type AMMWithdraw struct {
+	TxBase
+	Asset     Issue
+	Asset2    Issue
+	Amount    *Amount `json:",omitempty"`
+	Amount2   *Amount `json:",omitempty"`
+	EPrice    *Amount `json:",omitempty"`
+	LPTokenIn *Amount `json:",omitempty"`
+}
+
+// https://xrpl.org/ammvote.html
+type AMMVote struct {
+	TxBase
+	Asset      Issue
+	Asset2     Issue
+	TradingFee uint16
+}
+
+// https://xrpl.org/ammbid.html
+// BidMin and BidMax are amounts of the pool's LP tokens
+type AMMBid struct {
+	TxBase
+	Asset        Issue
+	Asset2       Issue
+	BidMin       *Amount       `json:",omitempty"`
+	BidMax       *Amount       `json:",omitempty"`
+	AuthAccounts []AuthAccount `json:",omitempty"`
+}
+
+// https://xrpl.org/ammdelete.html
+type AMMDelete struct {
+	TxBase
+	Asset  Issue
+	Asset2 Issue
+}
+
+// XChainCreateBridge, XChainCreateClaimID, XChainCommit, XChainClaim,
+// XChainAddClaimAttestation enabled by the XChainBridge amendment
+
+// XChainBridge identifies a bridge by the door account and asset on each
+// chain. It is encoded as a single field, without headers for its parts.
+type XChainBridge struct {
+	LockingChainDoor  Account
+	LockingChainIssue Issue
+	IssuingChainDoor  Account
+	IssuingChainIssue Issue
+}
+
+// https://xrpl.org/xchaincreatebridge.html
+type XChainCreateBridge struct {
+	TxBase
+	XChainBridge           XChainBridge
+	SignatureReward        Amount
+	MinAccountCreateAmount *Amount `json:",omitempty"`
+}
+
+// https://xrpl.org/xchaincreateclaimid.html
+// Reserves a claim id on the destination chain for a transfer from
+// OtherChainSource.
+type XChainCreateClaimID struct {
+	TxBase
+	XChainBridge     XChainBridge
+	SignatureReward  Amount
+	OtherChainSource Account
+}
+
+// https://xrpl.org/xchaincommit.html
+// Locks or burns Amount on the source chain for the claim id created on
+// the destination chain.
+type XChainCommit struct {
+	TxBase
+	XChainBridge          XChainBridge
+	XChainClaimID         Uint64Hex
+	Amount                Amount
+	OtherChainDestination *Account `json:",omitempty"`
+}
+
+// https://xrpl.org/xchainclaim.html
+type XChainClaim struct {
+	TxBase
+	XChainBridge   XChainBridge
+	XChainClaimID  Uint64Hex
+	Destination    Account
+	DestinationTag *uint32 `json:",omitempty"`
+	Amount         Amount
+}
+
+// https://xrpl.org/xchainaddclaimattestation.html
+// Signature is made by PublicKey over the attested transfer, it is not the
+// signature of the transaction itself.
+type XChainAddClaimAttestation struct {
+	TxBase
+	XChainBridge             XChainBridge
+	XChainClaimID            Uint64Hex
+	Amount                   Amount
+	OtherChainSource         Account
+	Destination              *Account `json:",omitempty"`
+	AttestationRewardAccount Account
+	AttestationSignerAccount Account
+	WasLockingChainSend      uint8
+	PublicKey                VariableLength
+	Signature                VariableLength
+}
+
+// DIDSet, DIDDelete enabled by the DID amendment
+
+// https://xrpl.org/didset.html
+// At least one of URI, Data and DIDDocument must be set. Setting one to an
+// empty blob removes it from an existing DID.
+type DIDSet struct {
+	TxBase
+	URI         *VariableLength `json:",omitempty"`
+	Data        *VariableLength `json:",omitempty"`
+	DIDDocument *VariableLength `json:",omitempty"`
+}
+
+// https://xrpl.org/diddelete.html
+type DIDDelete struct {
+	TxBase
+}
+
+// OracleSet, OracleDelete enabled by the PriceOracle amendment
+
+// Limits rippled sets on an OracleSet
+const (
+	MaxOracleDataSeries = 10
+	MaxPriceScale       = 20
+)
+
+type PriceDataItem struct {
+	BaseAsset  Currency
+	QuoteAsset Currency
+	AssetPrice *Uint64Hex `json:",omitempty"`
+	Scale      *uint8     `json:",omitempty"`
+}
+
+// PriceData without an AssetPrice removes the pair from the oracle
+type PriceData struct {
+	PriceData PriceDataItem
+}
+
+// https://xrpl.org/oracleset.html
+// LastUpdateTime is seconds since the Unix epoch, not the Ripple epoch.
+// Provider and AssetClass are only needed when the oracle is created.
+type OracleSet struct {
+	TxBase
+	OracleDocumentID uint32
+	Provider         *VariableLength `json:",omitempty"`
+	URI              *VariableLength `json:",omitempty"`
+	AssetClass       *VariableLength `json:",omitempty"`
+	LastUpdateTime   uint32
+	PriceDataSeries  []PriceData
+}
+
+// Validate checks the size of PriceDataSeries and the scale of each price
+func (o *OracleSet) Validate() error {
+	if n := len(o.PriceDataSeries); n == 0 || n > MaxOracleDataSeries {
+		return fmt.Errorf("OracleSet must have between 1 and %d prices: %d", MaxOracleDataSeries, n)
+	}
+	for _, p := range o.PriceDataSeries {
+		if p.PriceData.Scale != nil && *p.PriceData.Scale > MaxPriceScale {
+			return fmt.Errorf("OracleSet scale too large for %s/%s: %d", p.PriceData.BaseAsset, p.PriceData.QuoteAsset, *p.PriceData.Scale)
+		}
+	}
+	return nil
+}
+
+// https://xrpl.org/oracledelete.html
+type OracleDelete struct {
+	TxBase
+	OracleDocumentID uint32
+}
+
+// SetHook is not part of the XRP Ledger. It is enabled by the Hooks amendment
+// on networks such as Xahau, and rippled rejects it.
+
+// Limits Hooks networks place on a SetHook
+const (
+	MaxHooks                  = 10
+	MaxHookParameters         = 16
+	MaxHookGrants             = 8
+	MaxHookParameterNameSize  = 32
+	MaxHookParameterValueSize = 256
+)
+
+type HookParameterItem struct {
+	HookParameterName  VariableLength
+	HookParameterValue *VariableLength `json:",omitempty"`
+}
+
+// A HookParameter without a HookParameterValue removes the parameter
+type HookParameter struct {
+	HookParameter HookParameterItem
+}
+
+// HookGrantItem allows the hook with HookHash to modify the state of the
+// granting hook, optionally only when installed on Authorize
+type HookGrantItem struct {
+	HookHash  Hash256
+	Authorize *Account `json:",omitempty"`
+}
+
+type HookGrant struct {
+	HookGrant HookGrantItem
+}
+
+// HookItem installs CreateCode, or the already installed hook with HookHash,
+// at its position in the chain of hooks of the account. HookOn has a bit for
+// each TransactionType, which is clear for those the hook runs on, except
+// for SetHook whose bit is inverted. An empty CreateCode with HookOverride
+// deletes the hook.
+type HookItem struct {
+	Flags          *HookFlag       `json:",omitempty"`
+	HookApiVersion *uint16         `json:",omitempty"`
+	HookOn         *Hash256        `json:",omitempty"`
+	HookHash       *Hash256        `json:",omitempty"`
+	HookNamespace  *Hash256        `json:",omitempty"`
+	CreateCode     *VariableLength `json:",omitempty"`
+	HookParameters []HookParameter `json:",omitempty"`
+	HookGrants     []HookGrant     `json:",omitempty"`
+}
+
+// An empty Hook leaves the hook at its position as it is
+type Hook struct {
+	Hook HookItem
+}
+
+// https://xrpl-hooks.readme.io/docs/sethook-transaction
+type SetHook struct {
+	TxBase
+	Hooks []Hook
+}
+
+// Validate checks the number of hooks and the sizes of their parameters and
+// grants
+func (s *SetHook) Validate() error {
+	if n := len(s.Hooks); n == 0 || n > MaxHooks {
+		return fmt.Errorf("SetHook must have between 1 and %d hooks: %d", MaxHooks, n)
+	}
+	for i, h := range s.Hooks {
+		if h.Hook.CreateCode != nil && h.Hook.HookHash != nil {
+			return fmt.Errorf("SetHook hook %d cannot have both CreateCode and HookHash", i)
+		}
+		if n := len(h.Hook.HookParameters); n > MaxHookParameters {
+			return fmt.Errorf("SetHook hook %d has too many parameters: %d", i, n)
+		}
+		if n := len(h.Hook.HookGrants); n > MaxHookGrants {
+			return fmt.Errorf("SetHook hook %d has too many grants: %d", i, n)
+		}
+		for _, p := range h.Hook.HookParameters {
+			name, value := p.HookParameter.HookParameterName, p.HookParameter.HookParameterValue
+			if len(name) == 0 || len(name) > MaxHookParameterNameSize {
+				return fmt.Errorf("SetHook hook %d parameter name must be between 1 and %d bytes: %X", i, MaxHookParameterNameSize, []byte(name))
+			}
+			if value != nil && len(*value) > MaxHookParameterValueSize {
+				return fmt.Errorf("SetHook hook %d parameter value is longer than %d bytes: %X", i, MaxHookParameterValueSize, []byte(name))
+			}
+		}
+	}
+	return nil
+}
+
+// Deprecated: use NFTokenCancelOffer and NFTokenAcceptOffer
+type (
+	NFTCancelOffer = NFTokenCancelOffer
+	NFTAcceptOffer = NFTokenAcceptOffer
+)
+
 func (t *TxBase) GetBase() *TxBase                    { return t }
 func (t *TxBase) GetType() string                     { return txNames[t.TransactionType] }
 func (t *TxBase) GetTransactionType() TransactionType { return t.TransactionType }
@@ -263,6 +662,7 @@ func (t *TxBase) GetPublicKey() *PublicKey            { return t.SigningPubKey }
 func (t *TxBase) GetSignature() *VariableLength       { return t.TxnSignature }
 func (t *TxBase) SigningPrefix() HashPrefix           { return HP_TRANSACTION_SIGN }
 func (t *TxBase) MultiSigningPrefix() HashPrefix      { return HP_TRANSACTION_MULTISIGN }
+func (t *TxBase) GetSigners() []Signer                { return t.Signers }
 func (t *TxBase) SetSigners(signers []Signer)         { t.Signers = signers }
 func (t *TxBase) PathSet() PathSet                    { return PathSet(nil) }
 func (t *TxBase) GetHash() *Hash256                   { return &t.Hash }
@@ -285,6 +685,34 @@ func (t *TxBase) Compare(other *TxBase) int {
 	}
 }
 
+// UseTicket makes the transaction consume the ticket with ticketSequence
+// rather than the account's next sequence, so Sequence must be zero.
+func (t *TxBase) UseTicket(ticketSequence uint32) {
+	t.Sequence = 0
+	t.TicketSequence = &ticketSequence
+}
+
+// AddFlags sets flags on the transaction, keeping any already set
+func (t *TxBase) AddFlags(flags TransactionFlag) {
+	if t.Flags != nil {
+		flags |= *t.Flags
+	}
+	t.Flags = &flags
+}
+
+// RemoveFlags clears flags on the transaction, keeping the others
+func (t *TxBase) RemoveFlags(flags TransactionFlag) {
+	if t.Flags != nil {
+		remaining := *t.Flags &^ flags
+		t.Flags = &remaining
+	}
+}
+
+// HasFlags reports whether all of flags are set on the transaction
+func (t *TxBase) HasFlags(flags TransactionFlag) bool {
+	return t.Flags != nil && *t.Flags&flags == flags
+}
+
 func (t *TxBase) InitialiseForSigning() {
 	if t.SigningPubKey == nil {
 		t.SigningPubKey = new(PublicKey)
@@ -298,6 +726,254 @@ func (o *OfferCreate) Ratio() *Value {
 	return o.TakerPays.Ratio(o.TakerGets)
 }
 
+// SetExpiration makes the offer expire at t, which is rounded down to the
+// second, see NewRippleTimeFromTime
+func (o *OfferCreate) SetExpiration(t time.Time) error {
+	expiration, err := NewRippleTimeFromTime(t)
+	if err != nil {
+		return err
+	}
+	o.Expiration = expiration
+	return nil
+}
+
+// SetImmediateOrCancel makes the offer take what it can when submitted and
+// never sit in the order book. It replaces FillOrKill.
+func (o *OfferCreate) SetImmediateOrCancel() {
+	o.RemoveFlags(TxFillOrKill)
+	o.AddFlags(TxImmediateOrCancel)
+}
+
+// SetFillOrKill makes the offer fail unless it is filled in full when
+// submitted. It replaces ImmediateOrCancel.
+func (o *OfferCreate) SetFillOrKill() {
+	o.RemoveFlags(TxImmediateOrCancel)
+	o.AddFlags(TxFillOrKill)
+}
+
+// SetSell makes the offer exchange all of TakerGets, even when that gets
+// more than TakerPays
+func (o *OfferCreate) SetSell() {
+	o.AddFlags(TxSell)
+}
+
+// SetPassive stops the offer consuming offers which exactly match it
+func (o *OfferCreate) SetPassive() {
+	o.AddFlags(TxPassive)
+}
+
+// Validate checks that the offer is not both ImmediateOrCancel and
+// FillOrKill, which rippled rejects
+func (o *OfferCreate) Validate() error {
+	if o.HasFlags(TxImmediateOrCancel | TxFillOrKill) {
+		return fmt.Errorf("OfferCreate cannot be both ImmediateOrCancel and FillOrKill")
+	}
+	return nil
+}
+
+// SetLimit sets the most of an issued currency the account will hold from
+// the issuer of limit
+func (t *TrustSet) SetLimit(limit Amount) error {
+	if limit.IsNative() {
+		return fmt.Errorf("TrustSet limit must be an issued currency: %s", limit)
+	}
+	if limit.IsNegative() {
+		return fmt.Errorf("TrustSet limit must not be negative: %s", limit)
+	}
+	t.LimitAmount = limit
+	return nil
+}
+
+// SetQualityIn values incoming balances at quality billionths of their face
+// value, see TrustLineQuality. QualityOne or zero restores the default.
+func (t *TrustSet) SetQualityIn(quality uint32) {
+	t.QualityIn = &quality
+}
+
+// SetQualityOut values outgoing balances at quality billionths of their face
+// value, see TrustLineQuality. QualityOne or zero restores the default.
+func (t *TrustSet) SetQualityOut(quality uint32) {
+	t.QualityOut = &quality
+}
+
+// SetAuth authorizes the counterparty to hold the account's issued currency,
+// for an account which requires authorization
+func (t *TrustSet) SetAuth() {
+	t.AddFlags(TxSetAuth)
+}
+
+// SetNoRipple sets or clears NoRipple on the account's side of the line,
+// replacing any earlier call
+func (t *TrustSet) SetNoRipple(noRipple bool) {
+	t.RemoveFlags(TxSetNoRipple | TxClearNoRipple)
+	if noRipple {
+		t.AddFlags(TxSetNoRipple)
+	} else {
+		t.AddFlags(TxClearNoRipple)
+	}
+}
+
+// SetFreeze freezes or unfreezes the line, replacing any earlier call
+func (t *TrustSet) SetFreeze(freeze bool) {
+	t.RemoveFlags(TxSetFreeze | TxClearFreeze)
+	if freeze {
+		t.AddFlags(TxSetFreeze)
+	} else {
+		t.AddFlags(TxClearFreeze)
+	}
+}
+
+// Validate checks that the limit is a non-negative issued currency and that
+// no flag is both set and cleared, which rippled rejects
+func (t *TrustSet) Validate() error {
+	switch {
+	case t.LimitAmount.Value == nil:
+		return fmt.Errorf("TrustSet requires a LimitAmount")
+	case t.LimitAmount.IsNative():
+		return fmt.Errorf("TrustSet limit must be an issued currency: %s", t.LimitAmount)
+	case t.LimitAmount.IsNegative():
+		return fmt.Errorf("TrustSet limit must not be negative: %s", t.LimitAmount)
+	case t.HasFlags(TxSetNoRipple | TxClearNoRipple):
+		return fmt.Errorf("TrustSet cannot both set and clear NoRipple")
+	case t.HasFlags(TxSetFreeze | TxClearFreeze):
+		return fmt.Errorf("TrustSet cannot both set and clear Freeze")
+	default:
+		return nil
+	}
+}
+
+// SetAccountFlag sets one of the account's flags, such as TxDefaultRipple
+func (a *AccountSet) SetAccountFlag(flag TransactionFlag) {
+	n := uint32(flag)
+	a.SetFlag = &n
+}
+
+// ClearAccountFlag clears one of the account's flags, such as TxDefaultRipple
+func (a *AccountSet) ClearAccountFlag(flag TransactionFlag) {
+	n := uint32(flag)
+	a.ClearFlag = &n
+}
+
+// SetDomain sets the domain of the account, which rippled stores as the
+// bytes of its ASCII form. An empty domain removes it.
+func (a *AccountSet) SetDomain(domain string) error {
+	if len(domain) > maxDomainLength {
+		return fmt.Errorf("AccountSet Domain is longer than %d bytes: %s", maxDomainLength, domain)
+	}
+	vl := VariableLength(domain)
+	a.Domain = &vl
+	return nil
+}
+
+// SetEmailHash sets the EmailHash of the account to the MD5 hash of email,
+// which is how avatar services look it up. An empty email removes it.
+func (a *AccountSet) SetEmailHash(email string) {
+	var hash Hash128
+	if email = strings.ToLower(strings.TrimSpace(email)); email != "" {
+		hash = md5.Sum([]byte(email))
+	}
+	a.EmailHash = &hash
+}
+
+// SetMessageKey sets the public key others can use to send the account
+// encrypted messages. An empty key removes it.
+func (a *AccountSet) SetMessageKey(key []byte) error {
+	if len(key) != 0 && len(key) != 33 {
+		return fmt.Errorf("AccountSet MessageKey must be 33 bytes: %X", key)
+	}
+	vl := VariableLength(key)
+	a.MessageKey = &vl
+	return nil
+}
+
+// SetTransferRate sets the billionths of an issued currency which the
+// account charges the sender when it changes hands, see TrustLineQuality.
+// A rate of zero or QualityOne means no fee.
+func (a *AccountSet) SetTransferRate(rate uint32) error {
+	if err := checkTransferRate(rate); err != nil {
+		return err
+	}
+	a.TransferRate = &rate
+	return nil
+}
+
+// SetTickSize sets the significant digits of the exchange rates of offers
+// involving the account's issued currencies. Zero removes the limit.
+func (a *AccountSet) SetTickSize(size uint8) error {
+	if err := checkTickSize(size); err != nil {
+		return err
+	}
+	a.TickSize = &size
+	return nil
+}
+
+// SetNFTokenMinter allows minter to mint NFTokens for the account
+func (a *AccountSet) SetNFTokenMinter(minter Account) {
+	a.NFTokenMinter = &minter
+	a.SetAccountFlag(TxAuthorizedNFTokenMinter)
+}
+
+// ClearNFTokenMinter stops any other account minting NFTokens for the
+// account
+func (a *AccountSet) ClearNFTokenMinter() {
+	a.NFTokenMinter = nil
+	a.ClearAccountFlag(TxAuthorizedNFTokenMinter)
+}
+
+// Validate checks the limits rippled places on the fields
+func (a *AccountSet) Validate() error {
+	if a.TransferRate != nil {
+		if err := checkTransferRate(*a.TransferRate); err != nil {
+			return err
+		}
+	}
+	if a.TickSize != nil {
+		if err := checkTickSize(*a.TickSize); err != nil {
+			return err
+		}
+	}
+	switch {
+	case a.SetFlag != nil && a.ClearFlag != nil && *a.SetFlag == *a.ClearFlag:
+		return fmt.Errorf("AccountSet cannot both set and clear flag: %d", *a.SetFlag)
+	case a.Domain != nil && len(*a.Domain) > maxDomainLength:
+		return fmt.Errorf("AccountSet Domain is longer than %d bytes", maxDomainLength)
+	case a.NFTokenMinter != nil && (a.SetFlag == nil || *a.SetFlag != uint32(TxAuthorizedNFTokenMinter)):
+		return fmt.Errorf("AccountSet NFTokenMinter requires SetFlag %d", TxAuthorizedNFTokenMinter)
+	case a.NFTokenMinter == nil && a.SetFlag != nil && *a.SetFlag == uint32(TxAuthorizedNFTokenMinter):
+		return fmt.Errorf("AccountSet SetFlag %d requires an NFTokenMinter", TxAuthorizedNFTokenMinter)
+	default:
+		return nil
+	}
+}
+
+func checkTransferRate(rate uint32) error {
+	if rate != 0 && (rate < QualityOne || rate > MaxTransferRate) {
+		return fmt.Errorf("AccountSet TransferRate must be 0 or between %d and %d: %d", QualityOne, MaxTransferRate, rate)
+	}
+	return nil
+}
+
+func checkTickSize(size uint8) error {
+	if size != 0 && (size < MinTickSize || size > MaxTickSize) {
+		return fmt.Errorf("AccountSet TickSize must be 0 or between %d and %d: %d", MinTickSize, MaxTickSize, size)
+	}
+	return nil
+}
+
+// TrustLineQuality converts a rate, such as "1.002" to value balances at a
+// premium of 0.2%, to the billionths of QualityIn and QualityOut
+func TrustLineQuality(rate string) (uint32, error) {
+	r, ok := new(big.Rat).SetString(rate)
+	if !ok {
+		return 0, fmt.Errorf("Bad trust line quality: %s", rate)
+	}
+	r.Mul(r, new(big.Rat).SetInt64(int64(QualityOne)))
+	if !r.IsInt() || r.Sign() < 0 || r.Num().Cmp(big.NewInt(math.MaxUint32)) > 0 {
+		return 0, fmt.Errorf("Trust line quality out of range: %s", rate)
+	}
+	return uint32(r.Num().Uint64()), nil
+}
+
 func (p *Payment) PathSet() PathSet {
 	if p.Paths == nil {
 		return PathSet(nil)
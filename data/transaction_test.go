@@ -0,0 +1,1193 @@
+package data
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rubblelabs/ripple/crypto"
+	internal "github.com/rubblelabs/ripple/testing"
+	. "gopkg.in/check.v1"
+)
+
+type TransactionSuite struct{}
+
+var _ = Suite(&TransactionSuite{})
+
+// Signs tx with the genesis key and checks that it survives a binary
+// round trip with the same hash. Returns the decoded transaction.
+func checkRoundTrip(c *C, tx Transaction) Transaction {
+	seed, err := NewSeedFromAddress("snoPBrXtMeMyMHUVTgbuqAfg1SUTb")
+	c.Assert(err, IsNil)
+	var sequence uint32
+	base := tx.GetBase()
+	base.Account = seed.AccountId(ECDSA, &sequence)
+	base.Sequence = 1
+	fee, err := NewNativeValue(10)
+	c.Assert(err, IsNil)
+	base.Fee = *fee
+	c.Assert(Sign(tx, seed.Key(ECDSA), &sequence), IsNil)
+
+	hash, raw, err := Raw(tx)
+	c.Assert(err, IsNil)
+	c.Assert(hash, Equals, *tx.GetHash())
+	decoded, err := ReadTransaction(bytes.NewReader(raw))
+	c.Assert(err, IsNil)
+	c.Assert(decoded.GetTransactionType(), Equals, tx.GetTransactionType())
+	decodedHash, decodedRaw, err := Raw(decoded)
+	c.Assert(err, IsNil)
+	c.Assert(decodedHash, Equals, hash)
+	c.Assert(decodedRaw, DeepEquals, raw)
+	ok, err := CheckSignature(decoded)
+	c.Assert(err, IsNil)
+	c.Assert(ok, Equals, true)
+
+	// Hash is not part of the binary format
+	*decoded.GetHash() = hash
+	expected, err := json.Marshal(tx)
+	c.Assert(err, IsNil)
+	obtained, err := json.Marshal(decoded)
+	c.Assert(err, IsNil)
+	c.Assert(string(obtained), Equals, string(expected))
+	return decoded
+}
+
+func (s *TransactionSuite) TestNFTokenMint(c *C) {
+	taxon, fee := uint32(42), uint16(5000)
+	uri := VariableLength("ipfs://bafybeigdyrzt5sfp7udm7hu76uh7y26nf3efuylqabf3oclgtqy55fbzdi")
+	flags := TxBurnable | TxTransferable
+	issuer, err := NewAccountFromAddress("rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf")
+	c.Assert(err, IsNil)
+	tx := &NFTokenMint{
+		TxBase: TxBase{
+			TransactionType: NFTOKEN_MINT,
+			Flags:           &flags,
+		},
+		NFTokenTaxon: &taxon,
+		TransferFee:  &fee,
+		Issuer:       issuer,
+		URI:          &uri,
+	}
+	decoded := checkRoundTrip(c, tx).(*NFTokenMint)
+	c.Check(*decoded.NFTokenTaxon, Equals, taxon)
+	c.Check(*decoded.TransferFee, Equals, fee)
+	c.Check(*decoded.Issuer, Equals, *issuer)
+	c.Check(string(*decoded.URI), Equals, string(uri))
+	c.Check(decoded.Flags.Explain(decoded), DeepEquals, []string{"CanonicalSignature", "Burnable", "Transferable"})
+
+	mint := checkVector(c, "NFTokenMint", "5B57CC6B9562955C3710AB536E27D5CDFDC263433986675BF4A45E8AB8395B42").(*NFTokenMint)
+	c.Check(*mint.NFTokenTaxon, Equals, taxon)
+	c.Check(*mint.TransferFee, Equals, fee)
+	c.Check(mint.Issuer, IsNil)
+	c.Check(string(*mint.URI), Equals, string(uri))
+	c.Check(mint.Flags.Explain(mint), DeepEquals, []string{"CanonicalSignature", "Burnable", "Transferable"})
+}
+
+func (s *TransactionSuite) TestNFTokenOffers(c *C) {
+	id, err := NewHash256("000B013A95F14B0044F78A264E41713C64B5F89242540EE208C3098E00000D65")
+	c.Assert(err, IsNil)
+	sellOffer, err := NewHash256("68CD1F6F906494EA08C9CB5CAFA64DFA90D4E834B7151899B73231DE5A0C3B77")
+	c.Assert(err, IsNil)
+	buyOffer, err := NewHash256("3A35B2A4EDF3F3BEE8B1BA9E1D3B3A8E7A5D3C49A4E3D1A1B2C3D4E5F6071829")
+	c.Assert(err, IsNil)
+	amount, err := NewAmount("1000000")
+	c.Assert(err, IsNil)
+	brokerFee, err := NewAmount("1/USD/rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B")
+	c.Assert(err, IsNil)
+	destination, err := NewAccountFromAddress("rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf")
+	c.Assert(err, IsNil)
+	expiration := NewRippleTime(772615400)
+	sell := TxSellNFToken
+
+	create := checkRoundTrip(c, &NFTokenCreateOffer{
+		TxBase: TxBase{
+			TransactionType: NFTOKEN_CREATE_OFFER,
+			Flags:           &sell,
+		},
+		NFTokenID:   id,
+		Amount:      amount,
+		Destination: destination,
+		Expiration:  expiration,
+	}).(*NFTokenCreateOffer)
+	c.Check(*create.NFTokenID, Equals, *id)
+	c.Check(create.Amount.String(), Equals, "1/XRP")
+	c.Check(*create.Destination, Equals, *destination)
+	c.Check(create.Flags.Explain(create), DeepEquals, []string{"CanonicalSignature", "SellNFToken"})
+
+	accept := checkRoundTrip(c, &NFTokenAcceptOffer{
+		TxBase:           TxBase{TransactionType: NFTOKEN_ACCEPT_OFFER},
+		NFTokenSellOffer: sellOffer,
+		NFTokenBuyOffer:  buyOffer,
+		NFTokenBrokerFee: brokerFee,
+	}).(*NFTokenAcceptOffer)
+	c.Check(*accept.NFTokenSellOffer, Equals, *sellOffer)
+	c.Check(*accept.NFTokenBuyOffer, Equals, *buyOffer)
+	c.Check(accept.NFTokenBrokerFee.String(), Equals, brokerFee.String())
+
+	offers := Vector256{*sellOffer, *buyOffer}
+	cancel := checkRoundTrip(c, &NFTokenCancelOffer{
+		TxBase:        TxBase{TransactionType: NFTOKEN_CANCEL_OFFER},
+		NFTokenOffers: &offers,
+	}).(*NFTokenCancelOffer)
+	c.Check(*cancel.NFTokenOffers, DeepEquals, offers)
+
+	create = checkVector(c, "NFTokenCreateOffer", "988CA3C30C3FC04DC72DF395F41D20AE3E250C5298D12BE6FE0876A174F7EAFC").(*NFTokenCreateOffer)
+	c.Check(*create.NFTokenID, Equals, *id)
+	c.Check(create.Amount.String(), Equals, "1/XRP")
+	c.Check(*create.Destination, Equals, *destination)
+	c.Check(*create.Expiration, Equals, *expiration)
+	c.Check(create.Owner, IsNil)
+	c.Check(create.Flags.Explain(create), DeepEquals, []string{"CanonicalSignature", "SellNFToken"})
+
+	accept = checkVector(c, "NFTokenAcceptOffer", "91D7153EB940C439044B1B0676DA21CD823E5CF26F7021D143EAA81BD874B37C").(*NFTokenAcceptOffer)
+	c.Check(*accept.NFTokenSellOffer, Equals, *sellOffer)
+	c.Check(*accept.NFTokenBuyOffer, Equals, *buyOffer)
+	c.Check(accept.NFTokenBrokerFee.String(), Equals, brokerFee.String())
+
+	cancel = checkVector(c, "NFTokenCancelOffer", "1DFE3D809DC6EAD8829E4749C42C379CE9840A027A716F36647997CAEB49D556").(*NFTokenCancelOffer)
+	c.Check(*cancel.NFTokenOffers, DeepEquals, offers)
+}
+
+func (s *TransactionSuite) TestAMMCreate(c *C) {
+	amount, err := NewAmount("10000000")
+	c.Assert(err, IsNil)
+	amount2, err := NewAmount("100/USD/rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B")
+	c.Assert(err, IsNil)
+	decoded := checkRoundTrip(c, &AMMCreate{
+		TxBase:     TxBase{TransactionType: AMM_CREATE},
+		Amount:     *amount,
+		Amount2:    *amount2,
+		TradingFee: 500,
+	}).(*AMMCreate)
+	c.Check(decoded.Amount.String(), Equals, amount.String())
+	c.Check(decoded.Amount2.String(), Equals, amount2.String())
+	c.Check(decoded.TradingFee, Equals, uint16(500))
+
+	create := checkVector(c, "AMMCreate", "AB8A5E20BCEC962C415CA389F19ED41E9CB50AC910D3FF253E2CCAA2E7CC3D47").(*AMMCreate)
+	c.Check(create.Amount.String(), Equals, amount.String())
+	c.Check(create.Amount2.String(), Equals, amount2.String())
+	c.Check(create.TradingFee, Equals, uint16(500))
+}
+
+func (s *TransactionSuite) TestAMMDepositWithdraw(c *C) {
+	asset, err := NewIssue("XRP")
+	c.Assert(err, IsNil)
+	asset2, err := NewIssue("USD/rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B")
+	c.Assert(err, IsNil)
+	amount, err := NewAmount("5000000")
+	c.Assert(err, IsNil)
+	lpTokens, err := NewAmount("1000/03930D02208264E2E40EC1B0C09E4DB96EE197B1/rMEJo9H5XvTe17UoAJzj8jtKVvTRcxwngo")
+	c.Assert(err, IsNil)
+
+	depositFlags := TxSingleAsset
+	deposit := checkRoundTrip(c, &AMMDeposit{
+		TxBase: TxBase{
+			TransactionType: AMM_DEPOSIT,
+			Flags:           &depositFlags,
+		},
+		Asset:  *asset,
+		Asset2: *asset2,
+		Amount: amount,
+	}).(*AMMDeposit)
+	c.Check(deposit.Asset.IsNative(), Equals, true)
+	c.Check(deposit.Asset2.String(), Equals, asset2.String())
+	c.Check(deposit.Amount.String(), Equals, amount.String())
+	c.Check(deposit.Flags.Explain(deposit), DeepEquals, []string{"CanonicalSignature", "SingleAsset"})
+
+	withdrawFlags := TxLPToken
+	withdraw := checkRoundTrip(c, &AMMWithdraw{
+		TxBase: TxBase{
+			TransactionType: AMM_WITHDRAW,
+			Flags:           &withdrawFlags,
+		},
+		Asset:     *asset,
+		Asset2:    *asset2,
+		LPTokenIn: lpTokens,
+	}).(*AMMWithdraw)
+	c.Check(withdraw.Asset2.String(), Equals, asset2.String())
+	c.Check(withdraw.LPTokenIn.String(), Equals, lpTokens.String())
+	c.Check(withdraw.Flags.Explain(withdraw), DeepEquals, []string{"CanonicalSignature", "LPToken"})
+
+	// Each mode has its own combination of the amount fields
+	deposit = checkVector(c, "AMMDeposit LPToken", "145DAF83DC9349A241733ED28A6D7DD47E47EE5ACAFFF9443AD8DE159611EE59").(*AMMDeposit)
+	c.Check(deposit.Asset.IsNative(), Equals, true)
+	c.Check(deposit.Asset2, Equals, *asset2)
+	c.Check(deposit.LPTokenOut.String(), Equals, lpTokens.String())
+	c.Check(deposit.Amount, IsNil)
+	c.Check(deposit.Flags.Explain(deposit), DeepEquals, []string{"CanonicalSignature", "LPToken"})
+
+	amount2, err := NewAmount("50/USD/rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B")
+	c.Assert(err, IsNil)
+	deposit = checkVector(c, "AMMDeposit TwoAsset", "B89DAF3170D41671D20AE994EB71B113858DEBF088D7395FECF782CCFC304A67").(*AMMDeposit)
+	c.Check(deposit.Amount.String(), Equals, amount.String())
+	c.Check(deposit.Amount2.String(), Equals, amount2.String())
+	c.Check(deposit.LPTokenOut, IsNil)
+	c.Check(deposit.Flags.Explain(deposit), DeepEquals, []string{"CanonicalSignature", "TwoAsset"})
+
+	ePrice, err := NewAmount("2500")
+	c.Assert(err, IsNil)
+	withdraw = checkVector(c, "AMMWithdraw LimitLPToken", "0AE62CD7DED8BDDA05CC8C26D0A579B81C177E718BB872F307FA165DB8625979").(*AMMWithdraw)
+	c.Check(withdraw.Amount.String(), Equals, amount.String())
+	c.Check(withdraw.EPrice.String(), Equals, ePrice.String())
+	c.Check(withdraw.Amount2, IsNil)
+	c.Check(withdraw.LPTokenIn, IsNil)
+	c.Check(withdraw.Flags.Explain(withdraw), DeepEquals, []string{"CanonicalSignature", "LimitLPToken"})
+}
+
+func (s *TransactionSuite) TestIssueEncoding(c *C) {
+	xrp, err := NewIssue("XRP")
+	c.Assert(err, IsNil)
+	usd, err := NewIssue("USD/rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B")
+	c.Assert(err, IsNil)
+
+	var b bytes.Buffer
+	c.Assert(xrp.Marshal(&b), IsNil)
+	c.Check(b.Len(), Equals, 20)
+	b.Reset()
+	c.Assert(usd.Marshal(&b), IsNil)
+	c.Check(b.Len(), Equals, 40)
+	var decoded Issue
+	c.Assert(decoded.Unmarshal(bytes.NewReader(b.Bytes())), IsNil)
+	c.Check(decoded, Equals, *usd)
+
+	out, err := json.Marshal(xrp)
+	c.Assert(err, IsNil)
+	c.Check(string(out), Equals, `{"currency":"XRP"}`)
+	out, err = json.Marshal(usd)
+	c.Assert(err, IsNil)
+	c.Check(string(out), Equals, `{"currency":"USD","issuer":"rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B"}`)
+	c.Assert(json.Unmarshal(out, &decoded), IsNil)
+	c.Check(decoded, Equals, *usd)
+
+	_, err = NewIssue("USD")
+	c.Check(err, NotNil)
+}
+
+func (s *TransactionSuite) TestAMMGovernance(c *C) {
+	asset, err := NewIssue("XRP")
+	c.Assert(err, IsNil)
+	asset2, err := NewIssue("USD/rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B")
+	c.Assert(err, IsNil)
+	bidMin, err := NewAmount("100/03930D02208264E2E40EC1B0C09E4DB96EE197B1/rMEJo9H5XvTe17UoAJzj8jtKVvTRcxwngo")
+	c.Assert(err, IsNil)
+	bidMax, err := NewAmount("250/03930D02208264E2E40EC1B0C09E4DB96EE197B1/rMEJo9H5XvTe17UoAJzj8jtKVvTRcxwngo")
+	c.Assert(err, IsNil)
+	first, err := NewAccountFromAddress("rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf")
+	c.Assert(err, IsNil)
+	second, err := NewAccountFromAddress("rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh")
+	c.Assert(err, IsNil)
+
+	vote := checkRoundTrip(c, &AMMVote{
+		TxBase:     TxBase{TransactionType: AMM_VOTE},
+		Asset:      *asset,
+		Asset2:     *asset2,
+		TradingFee: 600,
+	}).(*AMMVote)
+	c.Check(vote.Asset2, Equals, *asset2)
+	c.Check(vote.TradingFee, Equals, uint16(600))
+
+	bid := checkRoundTrip(c, &AMMBid{
+		TxBase: TxBase{TransactionType: AMM_BID},
+		Asset:  *asset,
+		Asset2: *asset2,
+		BidMin: bidMin,
+		BidMax: bidMax,
+		AuthAccounts: []AuthAccount{
+			{AuthAccountItem{*first}},
+			{AuthAccountItem{*second}},
+		},
+	}).(*AMMBid)
+	c.Check(bid.BidMin.String(), Equals, bidMin.String())
+	c.Check(bid.BidMax.String(), Equals, bidMax.String())
+	c.Assert(bid.AuthAccounts, HasLen, 2)
+	c.Check(bid.AuthAccounts[0].AuthAccount.Account, Equals, *first)
+	c.Check(bid.AuthAccounts[1].AuthAccount.Account, Equals, *second)
+
+	del := checkRoundTrip(c, &AMMDelete{
+		TxBase: TxBase{TransactionType: AMM_DELETE},
+		Asset:  *asset,
+		Asset2: *asset2,
+	}).(*AMMDelete)
+	c.Check(del.Asset.IsNative(), Equals, true)
+	c.Check(del.Asset2, Equals, *asset2)
+
+	vote = checkVector(c, "AMMVote", "94A4B0B1DDF1CE916DF692BB7885FD1352E601173795D536BB23A375506DC9CE").(*AMMVote)
+	c.Check(vote.Asset.IsNative(), Equals, true)
+	c.Check(vote.Asset2, Equals, *asset2)
+	c.Check(vote.TradingFee, Equals, uint16(600))
+
+	third, err := NewAccountFromAddress("rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B")
+	c.Assert(err, IsNil)
+	bid = checkVector(c, "AMMBid", "12DABADC043074A5249A80B9B003F009113F3B93FF9DA74C6766609632A3CADD").(*AMMBid)
+	c.Check(bid.Asset2, Equals, *asset2)
+	c.Check(bid.BidMin.String(), Equals, bidMin.String())
+	c.Check(bid.BidMax.String(), Equals, bidMax.String())
+	c.Assert(bid.AuthAccounts, HasLen, 2)
+	c.Check(bid.AuthAccounts[0].AuthAccount.Account, Equals, *first)
+	c.Check(bid.AuthAccounts[1].AuthAccount.Account, Equals, *third)
+
+	del = checkVector(c, "AMMDelete", "796675123102B441023404F56B62D01AA38F4AED2B27429E2434DFA38BCB9416").(*AMMDelete)
+	c.Check(del.Asset.IsNative(), Equals, true)
+	c.Check(del.Asset2, Equals, *asset2)
+}
+
+func (s *TransactionSuite) TestXChain(c *C) {
+	lockingDoor, err := NewAccountFromAddress("rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh")
+	c.Assert(err, IsNil)
+	issuingDoor, err := NewAccountFromAddress("rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf")
+	c.Assert(err, IsNil)
+	other, err := NewAccountFromAddress("rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B")
+	c.Assert(err, IsNil)
+	bridge := XChainBridge{
+		LockingChainDoor: *lockingDoor,
+		IssuingChainDoor: *issuingDoor,
+	}
+	reward, err := NewAmount("100")
+	c.Assert(err, IsNil)
+	amount, err := NewAmount("10000000")
+	c.Assert(err, IsNil)
+
+	create := checkRoundTrip(c, &XChainCreateBridge{
+		TxBase:                 TxBase{TransactionType: XCHAIN_CREATE_BRIDGE},
+		XChainBridge:           bridge,
+		SignatureReward:        *reward,
+		MinAccountCreateAmount: amount,
+	}).(*XChainCreateBridge)
+	c.Check(create.GetType(), Equals, "XChainCreateBridge")
+	c.Check(create.XChainBridge, DeepEquals, bridge)
+	c.Check(create.MinAccountCreateAmount.String(), Equals, amount.String())
+
+	// The doors are length prefixed and the issues are not, with no field
+	// headers inside the bridge
+	_, raw, err := Raw(create)
+	c.Assert(err, IsNil)
+	c.Check(string(b2h(raw)), Matches, ".*011914B5F762798A53D543A014CAF8B297CFF8F2F937E80{40}14AA066C988C712815CC37AF71472B7CBBBD4E2A0A0{40}.*")
+
+	out, err := json.Marshal(create)
+	c.Assert(err, IsNil)
+	c.Check(string(out), Matches, `.*"XChainBridge":\{"LockingChainDoor":"rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh","LockingChainIssue":\{"currency":"XRP"\},"IssuingChainDoor":"rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf","IssuingChainIssue":\{"currency":"XRP"\}\}.*`)
+
+	claimID := checkRoundTrip(c, &XChainCreateClaimID{
+		TxBase:           TxBase{TransactionType: XCHAIN_CREATE_CLAIM_ID},
+		XChainBridge:     bridge,
+		SignatureReward:  *reward,
+		OtherChainSource: *other,
+	}).(*XChainCreateClaimID)
+	c.Check(claimID.OtherChainSource, Equals, *other)
+
+	commit := checkRoundTrip(c, &XChainCommit{
+		TxBase:                TxBase{TransactionType: XCHAIN_COMMIT},
+		XChainBridge:          bridge,
+		XChainClaimID:         13,
+		Amount:                *amount,
+		OtherChainDestination: other,
+	}).(*XChainCommit)
+	c.Check(commit.XChainClaimID, Equals, Uint64Hex(13))
+	c.Check(commit.Amount.String(), Equals, amount.String())
+	c.Check(*commit.OtherChainDestination, Equals, *other)
+
+	tag := uint32(7)
+	claim := checkRoundTrip(c, &XChainClaim{
+		TxBase:         TxBase{TransactionType: XCHAIN_CLAIM},
+		XChainBridge:   bridge,
+		XChainClaimID:  13,
+		Destination:    *other,
+		DestinationTag: &tag,
+		Amount:         *amount,
+	}).(*XChainClaim)
+	c.Check(claim.XChainBridge, DeepEquals, bridge)
+	c.Check(claim.Destination, Equals, *other)
+	c.Check(*claim.DestinationTag, Equals, tag)
+
+	attestation := checkRoundTrip(c, &XChainAddClaimAttestation{
+		TxBase:                   TxBase{TransactionType: XCHAIN_ADD_CLAIM_ATTESTATION},
+		XChainBridge:             bridge,
+		XChainClaimID:            13,
+		Amount:                   *amount,
+		OtherChainSource:         *other,
+		Destination:              other,
+		AttestationRewardAccount: *lockingDoor,
+		AttestationSignerAccount: *issuingDoor,
+		WasLockingChainSend:      1,
+		PublicKey:                VariableLength{0xED, 0x01, 0x02},
+		Signature:                VariableLength{0x03, 0x04},
+	}).(*XChainAddClaimAttestation)
+	c.Check(attestation.AttestationSignerAccount, Equals, *issuingDoor)
+	c.Check(attestation.WasLockingChainSend, Equals, uint8(1))
+	c.Check(attestation.PublicKey, DeepEquals, VariableLength{0xED, 0x01, 0x02})
+	c.Check(attestation.Signature, DeepEquals, VariableLength{0x03, 0x04})
+
+	// The signer and reward accounts are fields 20 and 21, after
+	// OtherChainSource and OtherChainDestination
+	_, raw, err = Raw(attestation)
+	c.Assert(err, IsNil)
+	c.Check(string(b2h(raw)), Matches, ".*8012140A20B3C85F482532A9578DBB3950B85CA06594D1801414AA066C988C712815CC37AF71472B7CBBBD4E2A0A801514B5F762798A53D543A014CAF8B297CFF8F2F937E8.*")
+
+	commit = checkVector(c, "XChainCommit", "14200034A526E4294EC5E8FB37763D19A1F0659A6B73D1A45F9EF13164B3D589").(*XChainCommit)
+	c.Check(commit.XChainBridge, DeepEquals, bridge)
+	c.Check(commit.XChainClaimID, Equals, Uint64Hex(13))
+	c.Check(commit.Amount.String(), Equals, amount.String())
+	c.Check(*commit.OtherChainDestination, Equals, *other)
+
+	claim = checkVector(c, "XChainClaim", "BA435F2FE470DCEEC653AECCD87CF97DDF3B2E61B0590C19830BA8AD275EC862").(*XChainClaim)
+	c.Check(claim.XChainBridge, DeepEquals, bridge)
+	c.Check(claim.XChainClaimID, Equals, Uint64Hex(13))
+	c.Check(claim.Destination, Equals, *other)
+	c.Check(*claim.DestinationTag, Equals, tag)
+	c.Check(claim.Amount.String(), Equals, amount.String())
+
+	attestation = checkVector(c, "XChainAddClaimAttestation", "9D301217A1AA79D3461512195F94546F1FD1EDE5C123BDC1E85D890B68D2759E").(*XChainAddClaimAttestation)
+	c.Check(attestation.XChainBridge, DeepEquals, bridge)
+	c.Check(attestation.OtherChainSource, Equals, *other)
+	c.Check(*attestation.Destination, Equals, *other)
+	c.Check(attestation.AttestationRewardAccount, Equals, *lockingDoor)
+	c.Check(attestation.AttestationSignerAccount, Equals, *issuingDoor)
+	c.Check(attestation.WasLockingChainSend, Equals, uint8(1))
+	c.Check(attestation.PublicKey, DeepEquals, VariableLength{0xED, 0x01, 0x02})
+	c.Check(attestation.Signature, DeepEquals, VariableLength{0x03, 0x04})
+}
+
+func (s *TransactionSuite) TestDID(c *C) {
+	test := findTransaction(c, "DIDSet")
+	tx, err := ReadTransaction(test.Reader())
+	c.Assert(err, IsNil)
+	set, ok := tx.(*DIDSet)
+	c.Assert(ok, Equals, true)
+	hash, raw, err := Raw(set)
+	c.Assert(err, IsNil)
+	c.Check(hash.String(), Equals, "8416534436A5E67FECB3739BA43CDB89F827869E8D32202FB598812C3C09924B")
+	c.Check(string(b2h(raw)), Equals, test.Encoded)
+	ok, err = CheckSignature(set)
+	c.Assert(err, IsNil)
+	c.Check(ok, Equals, true)
+
+	c.Check(set.GetType(), Equals, "DIDSet")
+	c.Check(string(*set.URI), Equals, "ipfs://bafybeigdyrzt5sfp7udm7hu76uh7y26nf3efuylqabf3oclgtqy55fbzdi")
+	c.Check(string(*set.Data), Equals, "attestation")
+	c.Check(string(*set.DIDDocument), Equals, `{"@context":"https://www.w3.org/ns/did/v1"}`)
+
+	out, err := json.Marshal(set)
+	c.Assert(err, IsNil)
+	c.Check(string(out), Matches, `.*"TransactionType":"DIDSet".*"Data":"6174746573746174696F6E".*`)
+
+	// Clearing a field is done with an empty blob, which still gets encoded
+	empty := VariableLength{}
+	cleared := checkRoundTrip(c, &DIDSet{
+		TxBase: TxBase{TransactionType: DID_SET},
+		URI:    set.URI,
+		Data:   &empty,
+	}).(*DIDSet)
+	c.Check(cleared.URI, DeepEquals, set.URI)
+	c.Assert(cleared.Data, NotNil)
+	c.Check(*cleared.Data, HasLen, 0)
+	c.Check(cleared.DIDDocument, IsNil)
+	_, raw, err = Raw(cleared)
+	c.Assert(err, IsNil)
+	c.Check(string(b2h(raw)), Matches, ".*701B00.*")
+
+	del := checkRoundTrip(c, &DIDDelete{
+		TxBase: TxBase{TransactionType: DID_DELETE},
+	}).(*DIDDelete)
+	c.Check(del.GetType(), Equals, "DIDDelete")
+}
+
+func (s *TransactionSuite) TestOracle(c *C) {
+	xrp, err := NewCurrency("XRP")
+	c.Assert(err, IsNil)
+	usd, err := NewCurrency("USD")
+	c.Assert(err, IsNil)
+	eur, err := NewCurrency("EUR")
+	c.Assert(err, IsNil)
+	price, scale := Uint64Hex(740), uint8(3)
+	provider, class := VariableLength("chainlink"), VariableLength("currency")
+
+	set := checkRoundTrip(c, &OracleSet{
+		TxBase:           TxBase{TransactionType: ORACLE_SET},
+		OracleDocumentID: 34,
+		Provider:         &provider,
+		AssetClass:       &class,
+		LastUpdateTime:   1724871860,
+		PriceDataSeries: []PriceData{
+			{PriceDataItem{BaseAsset: xrp, QuoteAsset: usd, AssetPrice: &price, Scale: &scale}},
+			{PriceDataItem{BaseAsset: xrp, QuoteAsset: eur}},
+		},
+	}).(*OracleSet)
+	c.Check(set.GetType(), Equals, "OracleSet")
+	c.Check(set.OracleDocumentID, Equals, uint32(34))
+	c.Check(set.LastUpdateTime, Equals, uint32(1724871860))
+	c.Check(string(*set.Provider), Equals, "chainlink")
+	c.Check(string(*set.AssetClass), Equals, "currency")
+	c.Check(set.URI, IsNil)
+	c.Assert(set.PriceDataSeries, HasLen, 2)
+	c.Check(set.PriceDataSeries[0].PriceData.BaseAsset, Equals, xrp)
+	c.Check(set.PriceDataSeries[0].PriceData.QuoteAsset, Equals, usd)
+	c.Check(*set.PriceDataSeries[0].PriceData.AssetPrice, Equals, price)
+	c.Check(*set.PriceDataSeries[0].PriceData.Scale, Equals, scale)
+	c.Check(set.PriceDataSeries[1].PriceData.QuoteAsset, Equals, eur)
+	c.Check(set.PriceDataSeries[1].PriceData.AssetPrice, IsNil)
+	c.Check(set.Validate(), IsNil)
+
+	// LastUpdateTime, OracleDocumentID, Provider and the PriceDataSeries
+	// array of PriceData objects each have their own field code
+	_, raw, err := Raw(set)
+	c.Assert(err, IsNil)
+	c.Check(string(b2h(raw)), Matches, "120033.*2F66CF74B4203300000022.*701D09636861696E6C696E6B.*F018E020301700000000000002E4041003011A0{40}021A0{24}5553440{10}E1E020.*E1F1$")
+
+	out, err := json.Marshal(set)
+	c.Assert(err, IsNil)
+	c.Check(string(out), Matches, `.*"PriceDataSeries":\[\{"PriceData":\{"BaseAsset":"XRP","QuoteAsset":"USD","AssetPrice":"00000000000002E4","Scale":3\}\},\{"PriceData":\{"BaseAsset":"XRP","QuoteAsset":"EUR"\}\}\].*`)
+
+	tooLarge := uint8(MaxPriceScale + 1)
+	set.PriceDataSeries[0].PriceData.Scale = &tooLarge
+	c.Check(set.Validate(), ErrorMatches, "OracleSet scale too large for XRP/USD: 21")
+	set.PriceDataSeries = nil
+	c.Check(set.Validate(), ErrorMatches, "OracleSet must have between 1 and 10 prices: 0")
+
+	del := checkRoundTrip(c, &OracleDelete{
+		TxBase:           TxBase{TransactionType: ORACLE_DELETE},
+		OracleDocumentID: 34,
+	}).(*OracleDelete)
+	c.Check(del.GetType(), Equals, "OracleDelete")
+	c.Check(del.OracleDocumentID, Equals, uint32(34))
+
+	set = checkVector(c, "OracleSet", "587E2752D2A25BA2C540AFD4E17328E2B79B1AE0CE9CED46E7ACBCCBEA9163C6").(*OracleSet)
+	c.Check(set.OracleDocumentID, Equals, uint32(34))
+	c.Check(set.LastUpdateTime, Equals, uint32(1724871860))
+	c.Check(string(*set.Provider), Equals, "chainlink")
+	c.Check(string(*set.AssetClass), Equals, "currency")
+	c.Assert(set.PriceDataSeries, HasLen, 2)
+	c.Check(set.PriceDataSeries[0].PriceData.BaseAsset, Equals, xrp)
+	c.Check(set.PriceDataSeries[0].PriceData.QuoteAsset, Equals, usd)
+	c.Check(*set.PriceDataSeries[0].PriceData.AssetPrice, Equals, price)
+	c.Check(*set.PriceDataSeries[0].PriceData.Scale, Equals, scale)
+	c.Check(set.PriceDataSeries[1].PriceData.QuoteAsset, Equals, eur)
+	c.Check(set.PriceDataSeries[1].PriceData.AssetPrice, IsNil)
+	c.Check(set.PriceDataSeries[1].PriceData.Scale, IsNil)
+	c.Check(set.Validate(), IsNil)
+}
+
+func (s *TransactionSuite) TestSetHook(c *C) {
+	on, err := NewHash256("FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFBFFFFE")
+	c.Assert(err, IsNil)
+	namespace, err := NewHash256("CAFECAFECAFECAFECAFECAFECAFECAFECAFECAFECAFECAFECAFECAFECAFECAFE")
+	c.Assert(err, IsNil)
+	granted, err := NewHash256("0123456789ABCDEF0123456789ABCDEF0123456789ABCDEF0123456789ABCDEF")
+	c.Assert(err, IsNil)
+	version, flags := uint16(0), HookOverride
+	code, value, deleted := VariableLength{0x00, 0x61, 0x73, 0x6D}, VariableLength("value"), VariableLength{}
+
+	set := checkRoundTrip(c, &SetHook{
+		TxBase: TxBase{TransactionType: HOOK_SET},
+		Hooks: []Hook{
+			{HookItem{
+				Flags:          &flags,
+				HookApiVersion: &version,
+				HookOn:         on,
+				HookNamespace:  namespace,
+				CreateCode:     &code,
+				HookParameters: []HookParameter{
+					{HookParameterItem{HookParameterName: VariableLength("name"), HookParameterValue: &value}},
+				},
+				HookGrants: []HookGrant{{HookGrantItem{HookHash: *granted}}},
+			}},
+			{},
+			{HookItem{Flags: &flags, CreateCode: &deleted}},
+		},
+	}).(*SetHook)
+	c.Check(set.GetType(), Equals, "SetHook")
+	c.Assert(set.Hooks, HasLen, 3)
+	hook := set.Hooks[0].Hook
+	c.Check(*hook.Flags, Equals, HookOverride)
+	c.Check(*hook.HookApiVersion, Equals, version)
+	c.Check(*hook.HookOn, Equals, *on)
+	c.Check(*hook.HookNamespace, Equals, *namespace)
+	c.Check(*hook.CreateCode, DeepEquals, code)
+	c.Assert(hook.HookParameters, HasLen, 1)
+	c.Check(string(hook.HookParameters[0].HookParameter.HookParameterName), Equals, "name")
+	c.Check(string(*hook.HookParameters[0].HookParameter.HookParameterValue), Equals, "value")
+	c.Assert(hook.HookGrants, HasLen, 1)
+	c.Check(hook.HookGrants[0].HookGrant.HookHash, Equals, *granted)
+	c.Check(hook.HookGrants[0].HookGrant.Authorize, IsNil)
+	c.Check(set.Hooks[1], DeepEquals, Hook{})
+	c.Check(*set.Hooks[2].Hook.CreateCode, HasLen, 0)
+	c.Check(set.Validate(), IsNil)
+
+	// Hooks, Hook, HookParameters and HookParameter each have their own
+	// field code, and the empty CreateCode which deletes a hook is encoded
+	_, raw, err := Raw(set)
+	c.Assert(err, IsNil)
+	c.Check(string(b2h(raw)), Matches, "120016.*FBEE1014000022000000015014F{58}BF{4}E5020(CAFE){16}7B040061736DF013E0177018046E616D6570190576616C7565E1F1F014E018501F0123.*E1F1E1EEE1EE22000000017B00E1F1$")
+
+	out, err := json.Marshal(set)
+	c.Assert(err, IsNil)
+	c.Check(string(out), Matches, `.*"Hooks":\[\{"Hook":\{"Flags":1,"HookApiVersion":0,"HookOn":"F{58}BF{4}E".*"CreateCode":"0061736D","HookParameters":\[\{"HookParameter":\{"HookParameterName":"6E616D65","HookParameterValue":"76616C7565"\}\}\],"HookGrants":\[\{"HookGrant":\{"HookHash":"0123.*"\}\}\]\}\},\{"Hook":\{\}\},\{"Hook":\{"Flags":1,"CreateCode":""\}\}\].*`)
+	var decoded SetHook
+	c.Assert(json.Unmarshal(out, &decoded), IsNil)
+	c.Check(decoded.Hooks, DeepEquals, set.Hooks)
+
+	long := VariableLength(strings.Repeat("x", MaxHookParameterNameSize+1))
+	set.Hooks[0].Hook.HookParameters[0].HookParameter.HookParameterName = long
+	c.Check(set.Validate(), ErrorMatches, "SetHook hook 0 parameter name must be between 1 and 32 bytes: 7878.*")
+	set.Hooks[0].Hook.HookHash = granted
+	c.Check(set.Validate(), ErrorMatches, "SetHook hook 0 cannot have both CreateCode and HookHash")
+	set.Hooks = make([]Hook, MaxHooks+1)
+	c.Check(set.Validate(), ErrorMatches, "SetHook must have between 1 and 10 hooks: 11")
+
+	set = checkVector(c, "SetHook", "D9086295BB73D410CAEA82A8015BECFE32A1384F9E83809E8B53F280576C0F0D").(*SetHook)
+	c.Assert(set.Hooks, HasLen, 2)
+	hook = set.Hooks[0].Hook
+	c.Check(*hook.Flags, Equals, HookOverride)
+	c.Check(*hook.HookApiVersion, Equals, version)
+	c.Check(*hook.HookOn, Equals, *on)
+	c.Check(*hook.HookNamespace, Equals, *namespace)
+	c.Check(*hook.CreateCode, DeepEquals, code)
+	c.Assert(hook.HookParameters, HasLen, 1)
+	c.Check(string(hook.HookParameters[0].HookParameter.HookParameterName), Equals, "name")
+	c.Check(string(*hook.HookParameters[0].HookParameter.HookParameterValue), Equals, "value")
+	c.Assert(hook.HookGrants, HasLen, 1)
+	c.Check(hook.HookGrants[0].HookGrant.HookHash, Equals, *granted)
+	c.Check(set.Hooks[1], DeepEquals, Hook{})
+	c.Check(set.Validate(), IsNil)
+}
+
+// Returns the signed transaction in internal.Transactions with description
+func findTransaction(c *C, description string) internal.TestData {
+	for _, t := range internal.Transactions {
+		if t.Description == description {
+			return t
+		}
+	}
+	c.Fatalf("Missing test transaction: %s", description)
+	return internal.TestData{}
+}
+
+// Decodes the transaction in internal.Transactions with description and
+// checks its hash. TestParseTransactions checks the signature and encoding.
+// The vectors are signed by the genesis account, and were checked against
+// an encoder written from rippled's field definitions rather than this
+// package's.
+func checkVector(c *C, description, hash string) Transaction {
+	test := findTransaction(c, description)
+	tx, err := ReadTransaction(test.Reader())
+	c.Assert(err, IsNil)
+	obtained, err := TransactionHash(tx)
+	c.Assert(err, IsNil)
+	c.Check(obtained.String(), Equals, hash)
+	return tx
+}
+
+// The blob is a CheckCash signed by the genesis account
+func (s *TransactionSuite) TestAmendedCheckCash(c *C) {
+	test := findTransaction(c, "CheckCash")
+	tx, err := ReadTransaction(test.Reader())
+	c.Assert(err, IsNil)
+	cash, ok := tx.(*CheckCash)
+	c.Assert(ok, Equals, true)
+	hash, raw, err := Raw(cash)
+	c.Assert(err, IsNil)
+	c.Check(hash.String(), Equals, "20E9A041F5CADC79CF982C6D3A8E584C2DC7D4563DB1D9B7C4B0291DF56A33B0")
+	c.Check(string(b2h(raw)), Equals, test.Encoded)
+	c.Check(cash.CheckID.String(), Equals, "838766BA2B995C00744175F69A1B11E32C3DBC40E64801A4056FCBD657F57334")
+	c.Check(cash.Amount.String(), Equals, "100/USD/rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh")
+	c.Check(cash.DeliverMin, IsNil)
+	ok, err = CheckSignature(cash)
+	c.Assert(err, IsNil)
+	c.Check(ok, Equals, true)
+}
+
+func (s *TransactionSuite) TestChecks(c *C) {
+	destination, err := NewAccountFromAddress("rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf")
+	c.Assert(err, IsNil)
+	sendMax, err := NewAmount("100/USD/rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf")
+	c.Assert(err, IsNil)
+	deliverMin, err := NewAmount("95/USD/rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf")
+	c.Assert(err, IsNil)
+	invoice, err := NewHash256("6F1DFD1D0FE8A32E40E1F2C05CF1C15545BAB56B617F9C6C2D63A6B704BEF59B")
+	c.Assert(err, IsNil)
+	checkID, err := NewHash256("49647F0D748DC3FE26BDACBC57F251AADEFFF391403EC9BF87C97F67E9977FB0")
+	c.Assert(err, IsNil)
+	tag, expiration := uint32(7), NewRippleTime(570113521)
+
+	create := checkRoundTrip(c, &CheckCreate{
+		TxBase:         TxBase{TransactionType: CHECK_CREATE},
+		Destination:    *destination,
+		SendMax:        *sendMax,
+		DestinationTag: &tag,
+		Expiration:     expiration,
+		InvoiceID:      invoice,
+	}).(*CheckCreate)
+	c.Check(create.Destination, Equals, *destination)
+	c.Check(create.SendMax.String(), Equals, sendMax.String())
+	c.Check(*create.DestinationTag, Equals, tag)
+	c.Check(*create.Expiration, Equals, *expiration)
+	c.Check(*create.InvoiceID, Equals, *invoice)
+
+	cash := checkRoundTrip(c, &CheckCash{
+		TxBase:     TxBase{TransactionType: CHECK_CASH},
+		CheckID:    *checkID,
+		DeliverMin: deliverMin,
+	}).(*CheckCash)
+	c.Check(cash.CheckID, Equals, *checkID)
+	c.Check(cash.Amount, IsNil)
+	c.Check(cash.DeliverMin.String(), Equals, deliverMin.String())
+
+	cancel := checkRoundTrip(c, &CheckCancel{
+		TxBase:  TxBase{TransactionType: CHECK_CANCEL},
+		CheckID: *checkID,
+	}).(*CheckCancel)
+	c.Check(cancel.CheckID, Equals, *checkID)
+}
+
+func (s *TransactionSuite) TestDepositPreauth(c *C) {
+	test := findTransaction(c, "DepositPreauth")
+	tx, err := ReadTransaction(test.Reader())
+	c.Assert(err, IsNil)
+	preauth, ok := tx.(*SetDepositPreAuth)
+	c.Assert(ok, Equals, true)
+	c.Check(preauth.GetTransactionType().String(), Equals, "DepositPreauth")
+	hash, raw, err := Raw(preauth)
+	c.Assert(err, IsNil)
+	c.Check(hash.String(), Equals, "5712A9F08C6D8896B0AF6D258E9D90B55AAC9933EB28B6500F50708DDF5C829F")
+	c.Check(string(b2h(raw)), Equals, test.Encoded)
+	c.Check(preauth.Authorize.String(), Equals, "rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf")
+	c.Check(preauth.Unauthorize, IsNil)
+	c.Check(preauth.Validate(), IsNil)
+	ok, err = CheckSignature(preauth)
+	c.Assert(err, IsNil)
+	c.Check(ok, Equals, true)
+
+	other := preauth.Authorize
+	unauth := checkRoundTrip(c, &SetDepositPreAuth{
+		TxBase:      TxBase{TransactionType: SET_DEPOSIT_PREAUTH, Account: preauth.Account},
+		Unauthorize: other,
+	}).(*SetDepositPreAuth)
+	c.Check(unauth.Authorize, IsNil)
+	c.Check(*unauth.Unauthorize, Equals, *other)
+	c.Check(unauth.Validate(), IsNil)
+
+	unauth.Authorize = other
+	c.Check(unauth.Validate(), ErrorMatches, "DepositPreauth requires exactly one of Authorize or Unauthorize")
+	unauth.Unauthorize = nil
+	unauth.Authorize = &unauth.Account
+	c.Check(unauth.Validate(), ErrorMatches, "DepositPreauth cannot authorize its own account: .*")
+	unauth.Authorize = nil
+	c.Check(unauth.Validate(), ErrorMatches, "DepositPreauth requires exactly one of Authorize or Unauthorize")
+	unauth.Unauthorize = &Account{}
+	c.Check(unauth.Validate(), ErrorMatches, "DepositPreauth has an empty account")
+}
+
+func (s *TransactionSuite) TestTickets(c *C) {
+	test := findTransaction(c, "TicketCreate")
+	tx, err := ReadTransaction(test.Reader())
+	c.Assert(err, IsNil)
+	create, ok := tx.(*TicketCreate)
+	c.Assert(ok, Equals, true)
+	hash, raw, err := Raw(create)
+	c.Assert(err, IsNil)
+	c.Check(hash.String(), Equals, "CA5AAF5C611C4D416A2755E1932FFB62575CA3D87F7859F9007E44103D9CB1A9")
+	c.Check(string(b2h(raw)), Equals, test.Encoded)
+	c.Check(create.Sequence, Equals, uint32(5))
+	c.Check(*create.TicketCount, Equals, uint32(5))
+	c.Check(create.TicketSequence, IsNil)
+
+	test = findTransaction(c, "Ticketed Payment")
+	tx, err = ReadTransaction(test.Reader())
+	c.Assert(err, IsNil)
+	payment, ok := tx.(*Payment)
+	c.Assert(ok, Equals, true)
+	hash, raw, err = Raw(payment)
+	c.Assert(err, IsNil)
+	c.Check(hash.String(), Equals, "CE84200A7DFEC8E0052163F2788BD895F0AB3F9888484C055408A6E336891F17")
+	c.Check(string(b2h(raw)), Equals, test.Encoded)
+	c.Check(payment.Sequence, Equals, uint32(0))
+	c.Check(*payment.TicketSequence, Equals, uint32(6))
+	ok, err = CheckSignature(payment)
+	c.Assert(err, IsNil)
+	c.Check(ok, Equals, true)
+
+	// Sequence is replaced by the ticket and still serialized as zero
+	payment.Sequence = 10
+	payment.UseTicket(7)
+	c.Check(payment.Sequence, Equals, uint32(0))
+	c.Check(*payment.TicketSequence, Equals, uint32(7))
+	_, raw, err = Raw(payment)
+	c.Assert(err, IsNil)
+	c.Check(string(b2h(raw)), Matches, "12000024000000002029000000076140.*")
+}
+
+func (s *TransactionSuite) TestClawback(c *C) {
+	clawback := checkVector(c, "Clawback", "3C6E75474C0F993D8E1EEC6851146D36E3212BEC00AB0F21F26037248989A272").(*Clawback)
+
+	// The issuer slot of Amount holds the holder, not the issuer
+	c.Check(clawback.Account.String(), Equals, "rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh")
+	c.Check(clawback.Amount.String(), Equals, "314.159/USD/rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf")
+	c.Check(clawback.Holder().String(), Equals, "rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf")
+	c.Check(clawback.Validate(), IsNil)
+
+	out, err := json.Marshal(clawback)
+	c.Assert(err, IsNil)
+	c.Check(string(out), Matches, `.*"TransactionType":"Clawback".*"Amount":\{"value":"314.159","currency":"USD","issuer":"rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf"\}.*`)
+
+	for _, test := range []struct {
+		amount string
+		err    string
+	}{
+		{"1", "Clawback amount must be an issued currency"},
+		{"0/USD/rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf", "Clawback amount must be positive: .*"},
+		{"-1/USD/rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf", "Clawback amount must be positive: .*"},
+		{"1/USD/rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh", "Clawback holder cannot be the issuer: .*"},
+	} {
+		amount, err := NewAmount(test.amount)
+		c.Assert(err, IsNil)
+		clawback.Amount = *amount
+		c.Check(clawback.Validate(), ErrorMatches, test.err)
+	}
+}
+
+func (s *TransactionSuite) TestAccountDelete(c *C) {
+	test := findTransaction(c, "AccountDelete")
+	tx, err := ReadTransaction(test.Reader())
+	c.Assert(err, IsNil)
+	del, ok := tx.(*AccountDelete)
+	c.Assert(ok, Equals, true)
+	hash, raw, err := Raw(del)
+	c.Assert(err, IsNil)
+	c.Check(hash.String(), Equals, "9887CE8EDBB22BD0E8163727D13098CD3609BF573C8D038C767828ECA837FC6F")
+	c.Check(string(b2h(raw)), Equals, test.Encoded)
+	c.Check(del.Destination.String(), Equals, "rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf")
+	c.Check(*del.DestinationTag, Equals, uint32(13))
+	c.Check(del.Fee.String(), Equals, "2")
+	c.Check(del.Validate(), IsNil)
+	ok, err = CheckSignature(del)
+	c.Assert(err, IsNil)
+	c.Check(ok, Equals, true)
+
+	increment, err := NewValue("2.", true)
+	c.Assert(err, IsNil)
+	fee, err := del.DeletionFee(*increment)
+	c.Assert(err, IsNil)
+	c.Check(fee.Equals(del.Fee), Equals, true)
+	_, err = del.DeletionFee(*increment.Negate())
+	c.Check(err, ErrorMatches, "Reserve increment must be a positive native value: .*")
+
+	del.Destination = del.Account
+	c.Check(del.Validate(), ErrorMatches, "AccountDelete cannot send to the deleted account: .*")
+	del.Destination = Account{}
+	c.Check(del.Validate(), ErrorMatches, "AccountDelete requires a Destination")
+}
+
+func (s *TransactionSuite) TestAccountReserve(c *C) {
+	c.Check(AccountReserve(0, 10000000, 2000000), Equals, uint64(10000000))
+	c.Check(AccountReserve(3, 10000000, 2000000), Equals, uint64(16000000))
+	c.Check(AccountReserve(5, 1000000, 200000), Equals, uint64(2000000))
+}
+
+func (s *TransactionSuite) TestResultFinal(c *C) {
+	for _, result := range []TransactionResult{temMALFORMED, temBAD_FEE, tefFAILURE, tefMAX_LEDGER} {
+		c.Check(result.Final(), Equals, true, Commentf("%s", result))
+	}
+	for _, result := range []TransactionResult{tesSUCCESS, tecPATH_DRY, telINSUF_FEE_P, terQUEUED, terPRE_SEQ, tefPAST_SEQ, tefALREADY} {
+		c.Check(result.Final(), Equals, false, Commentf("%s", result))
+	}
+}
+
+func (s *TransactionSuite) TestOfferCreateHelpers(c *C) {
+	offer := TxFactory[OFFER_CREATE]().(*OfferCreate)
+	c.Check(offer.Expiration, IsNil)
+
+	expiry := time.Date(2014, time.May, 30, 5, 29, 26, 500, time.UTC)
+	c.Assert(offer.SetExpiration(expiry), IsNil)
+	c.Check(offer.Expiration.Uint32(), Equals, uint32(454742966))
+	c.Check(offer.Expiration.Time().Equal(expiry.Truncate(time.Second)), Equals, true)
+	c.Check(offer.Expiration.Unix(), Equals, expiry.Unix())
+	c.Check(offer.SetExpiration(time.Unix(946684799, 0)), ErrorMatches, "Time out of range for the Ripple epoch: 1999-Dec-31 23:59:59 UTC")
+	c.Check(offer.SetExpiration(time.Unix(946684800+1<<32, 0)), ErrorMatches, "Time out of range for the Ripple epoch: .*")
+	c.Check(offer.Expiration.Uint32(), Equals, uint32(454742966))
+
+	offer.SetSell()
+	offer.SetPassive()
+	offer.SetImmediateOrCancel()
+	c.Check(*offer.Flags, Equals, TxSell|TxPassive|TxImmediateOrCancel)
+	c.Check(offer.Validate(), IsNil)
+	offer.SetFillOrKill()
+	c.Check(*offer.Flags, Equals, TxSell|TxPassive|TxFillOrKill)
+	c.Check(offer.HasFlags(TxSell|TxFillOrKill), Equals, true)
+	c.Check(offer.HasFlags(TxSell|TxImmediateOrCancel), Equals, false)
+	offer.RemoveFlags(TxPassive)
+	c.Check(*offer.Flags, Equals, TxSell|TxFillOrKill)
+	offer.AddFlags(TxImmediateOrCancel)
+	c.Check(offer.Validate(), ErrorMatches, "OfferCreate cannot be both ImmediateOrCancel and FillOrKill")
+}
+
+func (s *TransactionSuite) TestTrustSet(c *C) {
+	trust := TxFactory[TRUST_SET]().(*TrustSet)
+	c.Check(trust.Validate(), ErrorMatches, "TrustSet requires a LimitAmount")
+	c.Check(trust.SetLimit(*amountCheck("1/XRP")), ErrorMatches, "TrustSet limit must be an issued currency: 1/XRP")
+	c.Check(trust.SetLimit(*amountCheck("-1/USD/rNPRNzBB92BVpAhhZr4iXDTveCgV5Pofm9")), ErrorMatches, "TrustSet limit must not be negative: .*")
+	c.Assert(trust.SetLimit(*amountCheck("0/USD/rNPRNzBB92BVpAhhZr4iXDTveCgV5Pofm9")), IsNil)
+	c.Check(trust.Validate(), IsNil)
+
+	trust.SetNoRipple(true)
+	trust.SetNoRipple(false)
+	trust.SetFreeze(true)
+	trust.SetAuth()
+	c.Check(*trust.Flags, Equals, TxClearNoRipple|TxSetFreeze|TxSetAuth)
+	c.Check(trust.Validate(), IsNil)
+	trust.AddFlags(TxSetNoRipple)
+	c.Check(trust.Validate(), ErrorMatches, "TrustSet cannot both set and clear NoRipple")
+	trust.SetNoRipple(true)
+	trust.AddFlags(TxClearFreeze)
+	c.Check(trust.Validate(), ErrorMatches, "TrustSet cannot both set and clear Freeze")
+	trust.SetFreeze(false)
+	c.Check(*trust.Flags, Equals, TxSetNoRipple|TxClearFreeze|TxSetAuth)
+
+	for rate, quality := range map[string]uint32{"1": QualityOne, "0.998": 998000000, "1.002": 1002000000, "0": 0} {
+		q, err := TrustLineQuality(rate)
+		c.Check(err, IsNil)
+		c.Check(q, Equals, quality, Commentf("%s", rate))
+	}
+	for _, rate := range []string{"-1", "0.0000000001", "5", "x"} {
+		_, err := TrustLineQuality(rate)
+		c.Check(err, NotNil, Commentf("%s", rate))
+	}
+	trust.SetQualityOut(QualityOne)
+	c.Check(*trust.QualityOut, Equals, QualityOne)
+	c.Check(trust.QualityIn, IsNil)
+}
+
+func (s *TransactionSuite) TestAccountSet(c *C) {
+	set := TxFactory[ACCOUNT_SET]().(*AccountSet)
+	set.Account = zeroAccount
+	c.Assert(set.SetDomain("example.com"), IsNil)
+	set.SetEmailHash(" Someone@Example.com ")
+	c.Check(set.EmailHash.String(), Equals, "16D113840F999444259F73BAC9AB8B10")
+	c.Check(set.SetDomain(strings.Repeat("a", 257)), ErrorMatches, "AccountSet Domain is longer than 256 bytes: a*")
+	c.Check(set.SetMessageKey([]byte{2}), ErrorMatches, "AccountSet MessageKey must be 33 bytes: 02")
+	c.Check(set.SetMessageKey(nil), IsNil)
+
+	for _, rate := range []uint32{0, QualityOne, 1002000000, MaxTransferRate} {
+		c.Check(set.SetTransferRate(rate), IsNil, Commentf("%d", rate))
+	}
+	for _, rate := range []uint32{1, QualityOne - 1, MaxTransferRate + 1} {
+		c.Check(set.SetTransferRate(rate), ErrorMatches, "AccountSet TransferRate must be 0 or between 1000000000 and 2000000000: .*")
+	}
+	c.Check(*set.TransferRate, Equals, MaxTransferRate)
+	c.Check(set.SetTickSize(2), ErrorMatches, "AccountSet TickSize must be 0 or between 3 and 15: 2")
+	c.Check(set.SetTickSize(5), IsNil)
+
+	set.SetAccountFlag(TxDefaultRipple)
+	set.ClearAccountFlag(TxDepositAuth)
+	c.Check(set.Validate(), IsNil)
+
+	b, err := json.Marshal(set)
+	c.Assert(err, IsNil)
+	c.Check(string(b), Matches, `.*"Domain":"6578616D706C652E636F6D".*"SetFlag":8,"ClearFlag":9.*`)
+
+	set.ClearAccountFlag(TxDefaultRipple)
+	c.Check(set.Validate(), ErrorMatches, "AccountSet cannot both set and clear flag: 8")
+	set.ClearFlag = nil
+	rate := uint32(1)
+	set.TransferRate = &rate
+	c.Check(set.Validate(), ErrorMatches, "AccountSet TransferRate must be .*")
+	set.TransferRate = nil
+
+	minter, err := NewAccountFromAddress("rNPRNzBB92BVpAhhZr4iXDTveCgV5Pofm9")
+	c.Assert(err, IsNil)
+	set.SetNFTokenMinter(*minter)
+	c.Check(*set.SetFlag, Equals, uint32(TxAuthorizedNFTokenMinter))
+	c.Check(set.Validate(), IsNil)
+	_, raw, err := Raw(set)
+	c.Assert(err, IsNil)
+	decoded, err := ReadTransaction(bytes.NewReader(raw))
+	c.Assert(err, IsNil)
+	c.Check(decoded.(*AccountSet).NFTokenMinter.String(), Equals, minter.String())
+	c.Check(string(*decoded.(*AccountSet).Domain), Equals, "example.com")
+
+	set.SetAccountFlag(TxDefaultRipple)
+	c.Check(set.Validate(), ErrorMatches, "AccountSet NFTokenMinter requires SetFlag 10")
+	set.ClearNFTokenMinter()
+	c.Check(*set.ClearFlag, Equals, uint32(TxAuthorizedNFTokenMinter))
+	set.ClearFlag = nil
+	set.SetAccountFlag(TxAuthorizedNFTokenMinter)
+	c.Check(set.Validate(), ErrorMatches, "AccountSet SetFlag 10 requires an NFTokenMinter")
+}
+
+func ExampleTrustSet() {
+	limit, _ := NewAmount("1000/USD/rNPRNzBB92BVpAhhZr4iXDTveCgV5Pofm9")
+	quality, _ := TrustLineQuality("0.998")
+	trust := TxFactory[TRUST_SET]().(*TrustSet)
+	trust.SetLimit(*limit)
+	trust.SetQualityIn(quality)
+	trust.SetNoRipple(true)
+	fmt.Println(trust.Validate())
+	fmt.Println(trust.LimitAmount, *trust.QualityIn, trust.Flags.Explain(trust))
+	// Output:
+	// <nil>
+	// 1000/USD/rNPRNzBB92BVpAhhZr4iXDTveCgV5Pofm9 998000000 [SetNoRipple]
+}
+
+func (s *TransactionSuite) TestResultCodes(c *C) {
+	for result, code := range map[TransactionResult]int{
+		tesSUCCESS:                     0,
+		tecPATH_DRY:                    128,
+		tecINSUFFICIENT_PAYMENT:        161,
+		tecBAD_CREDENTIALS:             193,
+		telCAN_NOT_QUEUE_FULL:          -387,
+		telENV_RPC_FAILED:              -383,
+		temBAD_SEND_XRP_LIMIT:          -288,
+		temUNCERTAIN:                   -265,
+		temARRAY_TOO_LARGE:             -252,
+		tefPAST_SEQ:                    -190,
+		tefNFTOKEN_IS_NOT_TRANSFERABLE: -179,
+		terQUEUED:                      -89,
+		terNO_AMM:                      -87,
+	} {
+		c.Check(int(result), Equals, code, Commentf("%s", result))
+	}
+	for result, name := range resultNames {
+		var decoded TransactionResult
+		c.Check(decoded.UnmarshalText([]byte(name.Token)), IsNil)
+		c.Check(decoded, Equals, result)
+		c.Check(name.Human, Not(Equals), "", Commentf("%s", result))
+	}
+}
+
+func (s *TransactionSuite) TestResultClasses(c *C) {
+	for _, t := range []struct {
+		result                                                     TransactionResult
+		success, claimed, applied, malformed, local, failed, retry bool
+	}{
+		{tesSUCCESS, true, false, true, false, false, false, false},
+		{tecPATH_DRY, false, true, true, false, false, false, false},
+		{tecINSUFFICIENT_PAYMENT, false, true, true, false, false, false, false},
+		{telINSUF_FEE_P, false, false, false, false, true, false, false},
+		{temBAD_FEE, false, false, false, true, false, false, false},
+		{tefPAST_SEQ, false, false, false, false, false, true, false},
+		{terQUEUED, false, false, false, false, false, false, true},
+	} {
+		comment := Commentf("%s", t.result)
+		c.Check(t.result.Success(), Equals, t.success, comment)
+		c.Check(t.result.Claimed(), Equals, t.claimed, comment)
+		c.Check(t.result.Applied(), Equals, t.applied, comment)
+		c.Check(t.result.Malformed(), Equals, t.malformed, comment)
+		c.Check(t.result.Local(), Equals, t.local, comment)
+		c.Check(t.result.Failed(), Equals, t.failed, comment)
+		c.Check(t.result.Retry(), Equals, t.retry, comment)
+	}
+	c.Check(terQUEUED.Queued(), Equals, true)
+	c.Check(terPRE_SEQ.Queued(), Equals, false)
+}
+
+func (s *TransactionSuite) TestMemos(c *C) {
+	amount, err := NewAmount("1000000")
+	c.Assert(err, IsNil)
+	payment := &Payment{
+		TxBase:      TxBase{TransactionType: PAYMENT},
+		Destination: zeroAccount,
+		Amount:      *amount,
+	}
+	payment.AddMemo("text/plain", "deposit 1234", "")
+	payment.AddMemo("", "\x00\xff", "application/octet-stream")
+	payment = checkRoundTrip(c, payment).(*Payment)
+	c.Assert(payment.Memos, HasLen, 2)
+	c.Check(string(payment.Memos[0].Memo.MemoType), Equals, "text/plain")
+	c.Check(string(payment.Memos[0].Memo.MemoData), Equals, "deposit 1234")
+	c.Check(payment.Memos[0].Memo.MemoFormat, HasLen, 0)
+	c.Check(payment.Memos[1].Memo.MemoType, HasLen, 0)
+	c.Check([]byte(payment.Memos[1].Memo.MemoData), DeepEquals, []byte{0x00, 0xff})
+
+	// STArray of STObjects, with empty fields left out
+	_, raw, err := Raw(payment)
+	c.Assert(err, IsNil)
+	c.Check(string(b2h(raw)), Matches, ".*F9EA7C0A746578742F706C61696E7D0C6465706F7369742031323334E1EA7D0200FF7E186170706C69636174696F6E2F6F637465742D73747265616DE1F1.*")
+
+	out, err := json.Marshal(payment.Memos)
+	c.Assert(err, IsNil)
+	c.Check(string(out), Equals, `[{"Memo":{"MemoType":"746578742F706C61696E","MemoData":"6465706F7369742031323334"}},{"Memo":{"MemoData":"00FF","MemoFormat":"6170706C69636174696F6E2F6F637465742D73747265616D"}}]`)
+}
+
+func (s *TransactionSuite) TestEscrow(c *C) {
+	preimage := []byte("a secret known only to the destination")
+	condition := VariableLength(crypto.PreimageSha256Condition(preimage))
+	fulfillment := VariableLength(crypto.PreimageSha256Fulfillment(preimage))
+	owner, err := NewAccountFromAddress("rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf")
+	c.Assert(err, IsNil)
+	amount, err := NewAmount("25000000")
+	c.Assert(err, IsNil)
+	cancelAfter, finishAfter := NewRippleTime(533257958), NewRippleTime(533171558)
+
+	create := checkRoundTrip(c, &EscrowCreate{
+		TxBase:      TxBase{TransactionType: ESCROW_CREATE},
+		Destination: *owner,
+		Amount:      *amount,
+		Condition:   &condition,
+		CancelAfter: cancelAfter,
+		FinishAfter: finishAfter,
+	}).(*EscrowCreate)
+	c.Check(create.Condition.String(), Equals, condition.String())
+	c.Check(*create.CancelAfter, Equals, *cancelAfter)
+	c.Check(*create.FinishAfter, Equals, *finishAfter)
+
+	finish := checkRoundTrip(c, &EscrowFinish{
+		TxBase:        TxBase{TransactionType: ESCROW_FINISH},
+		Owner:         *owner,
+		OfferSequence: 7,
+		Condition:     &condition,
+		Fulfillment:   &fulfillment,
+	}).(*EscrowFinish)
+	c.Check(finish.Condition.String(), Equals, condition.String())
+	c.Check(finish.Fulfillment.String(), Equals, fulfillment.String())
+
+	cancel := checkRoundTrip(c, &EscrowCancel{
+		TxBase:        TxBase{TransactionType: ESCROW_CANCEL},
+		Owner:         *owner,
+		OfferSequence: 7,
+	}).(*EscrowCancel)
+	c.Check(cancel.OfferSequence, Equals, uint32(7))
+
+	base, err := NewNativeValue(10)
+	c.Assert(err, IsNil)
+	// 42 byte fulfillment: 10 * (33 + 42/16)
+	fee, err := finish.FulfillmentFee(*base)
+	c.Assert(err, IsNil)
+	c.Check(fee.String(), Equals, "0.00035")
+	unconditional := &EscrowFinish{Owner: *owner, OfferSequence: 7}
+	fee, err = unconditional.FulfillmentFee(*base)
+	c.Assert(err, IsNil)
+	c.Check(fee.Equals(*base), Equals, true)
+}
+
+func (s *TransactionSuite) TestValidateFlags(c *C) {
+	for _, test := range []struct {
+		txType TransactionType
+		flags  TransactionFlag
+		err    string
+	}{
+		{PAYMENT, TxPartialPayment | TxNoDirectRipple, ""},
+		{PAYMENT, TxCanonicalSignature | TxLimitQuality, ""},
+		{PAYMENT, TxDisallowXRP, "Invalid flags for Payment: 00100000"},
+		{OFFER_CREATE, TxPassive | TxSell, ""},
+		{OFFER_CREATE, TxDisallowXRP | TxPassive, "Invalid flags for OfferCreate: 00100000"},
+		{ACCOUNT_SET, TxRequireDestTag | TxOptionalAuth, ""},
+		{ACCOUNT_SET, TxSetRequireDest, "Invalid flags for AccountSet: 00000001"},
+		{CLAWBACK, TxCanonicalSignature, ""},
+		{CLAWBACK, TxSetAuth, "Invalid flags for Clawback: 00010000"},
+		{NFTOKEN_MINT, TxTransferable | TxMutable, ""},
+		{NFTOKEN_MINT, TxMutable | 0x00000020, "Invalid flags for NFTokenMint: 00000020"},
+		{TRUST_SET, TxSetFreeze | TxSetDeepFreeze, ""},
+		{TRUST_SET, TxClearFreeze | TxClearDeepFreeze, ""},
+		{TRUST_SET, TxSetDeepFreeze | 0x01000000, "Invalid flags for TrustSet: 01000000"},
+	} {
+		base := TxFactory[test.txType]().GetBase()
+		c.Check(base.ValidateFlags(), IsNil)
+		base.Flags = &test.flags
+		err := base.ValidateFlags()
+		if test.err == "" {
+			c.Check(err, IsNil)
+		} else {
+			c.Check(err, ErrorMatches, test.err)
+		}
+	}
+}
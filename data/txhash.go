@@ -0,0 +1,137 @@
+package data
+
+// Hash and SigningHash are defined for each transaction rather than on TxBase,
+// as the encoding covers the fields of the whole transaction. Hash shadows
+// the Hash field of TxBase, which GetHash still returns.
+
+func (t *Payment) Hash() (Hash256, error)        { return TransactionHash(t) }
+func (t *Payment) SigningHash() (Hash256, error) { return TransactionSigningHash(t) }
+
+func (t *AccountSet) Hash() (Hash256, error)        { return TransactionHash(t) }
+func (t *AccountSet) SigningHash() (Hash256, error) { return TransactionSigningHash(t) }
+
+func (t *AccountDelete) Hash() (Hash256, error)        { return TransactionHash(t) }
+func (t *AccountDelete) SigningHash() (Hash256, error) { return TransactionSigningHash(t) }
+
+func (t *SetRegularKey) Hash() (Hash256, error)        { return TransactionHash(t) }
+func (t *SetRegularKey) SigningHash() (Hash256, error) { return TransactionSigningHash(t) }
+
+func (t *OfferCreate) Hash() (Hash256, error)        { return TransactionHash(t) }
+func (t *OfferCreate) SigningHash() (Hash256, error) { return TransactionSigningHash(t) }
+
+func (t *OfferCancel) Hash() (Hash256, error)        { return TransactionHash(t) }
+func (t *OfferCancel) SigningHash() (Hash256, error) { return TransactionSigningHash(t) }
+
+func (t *TrustSet) Hash() (Hash256, error)        { return TransactionHash(t) }
+func (t *TrustSet) SigningHash() (Hash256, error) { return TransactionSigningHash(t) }
+
+func (t *Amendment) Hash() (Hash256, error)        { return TransactionHash(t) }
+func (t *Amendment) SigningHash() (Hash256, error) { return TransactionSigningHash(t) }
+
+func (t *SetFee) Hash() (Hash256, error)        { return TransactionHash(t) }
+func (t *SetFee) SigningHash() (Hash256, error) { return TransactionSigningHash(t) }
+
+func (t *UNLModify) Hash() (Hash256, error)        { return TransactionHash(t) }
+func (t *UNLModify) SigningHash() (Hash256, error) { return TransactionSigningHash(t) }
+
+func (t *TicketCreate) Hash() (Hash256, error)        { return TransactionHash(t) }
+func (t *TicketCreate) SigningHash() (Hash256, error) { return TransactionSigningHash(t) }
+
+func (t *EscrowCreate) Hash() (Hash256, error)        { return TransactionHash(t) }
+func (t *EscrowCreate) SigningHash() (Hash256, error) { return TransactionSigningHash(t) }
+
+func (t *EscrowFinish) Hash() (Hash256, error)        { return TransactionHash(t) }
+func (t *EscrowFinish) SigningHash() (Hash256, error) { return TransactionSigningHash(t) }
+
+func (t *EscrowCancel) Hash() (Hash256, error)        { return TransactionHash(t) }
+func (t *EscrowCancel) SigningHash() (Hash256, error) { return TransactionSigningHash(t) }
+
+func (t *SignerListSet) Hash() (Hash256, error)        { return TransactionHash(t) }
+func (t *SignerListSet) SigningHash() (Hash256, error) { return TransactionSigningHash(t) }
+
+func (t *PaymentChannelCreate) Hash() (Hash256, error)        { return TransactionHash(t) }
+func (t *PaymentChannelCreate) SigningHash() (Hash256, error) { return TransactionSigningHash(t) }
+
+func (t *PaymentChannelFund) Hash() (Hash256, error)        { return TransactionHash(t) }
+func (t *PaymentChannelFund) SigningHash() (Hash256, error) { return TransactionSigningHash(t) }
+
+func (t *PaymentChannelClaim) Hash() (Hash256, error)        { return TransactionHash(t) }
+func (t *PaymentChannelClaim) SigningHash() (Hash256, error) { return TransactionSigningHash(t) }
+
+func (t *CheckCreate) Hash() (Hash256, error)        { return TransactionHash(t) }
+func (t *CheckCreate) SigningHash() (Hash256, error) { return TransactionSigningHash(t) }
+
+func (t *CheckCash) Hash() (Hash256, error)        { return TransactionHash(t) }
+func (t *CheckCash) SigningHash() (Hash256, error) { return TransactionSigningHash(t) }
+
+func (t *CheckCancel) Hash() (Hash256, error)        { return TransactionHash(t) }
+func (t *CheckCancel) SigningHash() (Hash256, error) { return TransactionSigningHash(t) }
+
+func (t *SetDepositPreAuth) Hash() (Hash256, error)        { return TransactionHash(t) }
+func (t *SetDepositPreAuth) SigningHash() (Hash256, error) { return TransactionSigningHash(t) }
+
+func (t *NFTokenMint) Hash() (Hash256, error)        { return TransactionHash(t) }
+func (t *NFTokenMint) SigningHash() (Hash256, error) { return TransactionSigningHash(t) }
+
+func (t *NFTokenBurn) Hash() (Hash256, error)        { return TransactionHash(t) }
+func (t *NFTokenBurn) SigningHash() (Hash256, error) { return TransactionSigningHash(t) }
+
+func (t *NFTokenCreateOffer) Hash() (Hash256, error)        { return TransactionHash(t) }
+func (t *NFTokenCreateOffer) SigningHash() (Hash256, error) { return TransactionSigningHash(t) }
+
+func (t *NFTokenCancelOffer) Hash() (Hash256, error)        { return TransactionHash(t) }
+func (t *NFTokenCancelOffer) SigningHash() (Hash256, error) { return TransactionSigningHash(t) }
+
+func (t *NFTokenAcceptOffer) Hash() (Hash256, error)        { return TransactionHash(t) }
+func (t *NFTokenAcceptOffer) SigningHash() (Hash256, error) { return TransactionSigningHash(t) }
+
+func (t *Clawback) Hash() (Hash256, error)        { return TransactionHash(t) }
+func (t *Clawback) SigningHash() (Hash256, error) { return TransactionSigningHash(t) }
+
+func (t *AMMCreate) Hash() (Hash256, error)        { return TransactionHash(t) }
+func (t *AMMCreate) SigningHash() (Hash256, error) { return TransactionSigningHash(t) }
+
+func (t *AMMDeposit) Hash() (Hash256, error)        { return TransactionHash(t) }
+func (t *AMMDeposit) SigningHash() (Hash256, error) { return TransactionSigningHash(t) }
+
+func (t *AMMWithdraw) Hash() (Hash256, error)        { return TransactionHash(t) }
+func (t *AMMWithdraw) SigningHash() (Hash256, error) { return TransactionSigningHash(t) }
+
+func (t *AMMVote) Hash() (Hash256, error)        { return TransactionHash(t) }
+func (t *AMMVote) SigningHash() (Hash256, error) { return TransactionSigningHash(t) }
+
+func (t *AMMBid) Hash() (Hash256, error)        { return TransactionHash(t) }
+func (t *AMMBid) SigningHash() (Hash256, error) { return TransactionSigningHash(t) }
+
+func (t *AMMDelete) Hash() (Hash256, error)        { return TransactionHash(t) }
+func (t *AMMDelete) SigningHash() (Hash256, error) { return TransactionSigningHash(t) }
+
+func (t *XChainCreateClaimID) Hash() (Hash256, error)        { return TransactionHash(t) }
+func (t *XChainCreateClaimID) SigningHash() (Hash256, error) { return TransactionSigningHash(t) }
+
+func (t *XChainCommit) Hash() (Hash256, error)        { return TransactionHash(t) }
+func (t *XChainCommit) SigningHash() (Hash256, error) { return TransactionSigningHash(t) }
+
+func (t *XChainClaim) Hash() (Hash256, error)        { return TransactionHash(t) }
+func (t *XChainClaim) SigningHash() (Hash256, error) { return TransactionSigningHash(t) }
+
+func (t *XChainAddClaimAttestation) Hash() (Hash256, error)        { return TransactionHash(t) }
+func (t *XChainAddClaimAttestation) SigningHash() (Hash256, error) { return TransactionSigningHash(t) }
+
+func (t *XChainCreateBridge) Hash() (Hash256, error)        { return TransactionHash(t) }
+func (t *XChainCreateBridge) SigningHash() (Hash256, error) { return TransactionSigningHash(t) }
+
+func (t *DIDSet) Hash() (Hash256, error)        { return TransactionHash(t) }
+func (t *DIDSet) SigningHash() (Hash256, error) { return TransactionSigningHash(t) }
+
+func (t *DIDDelete) Hash() (Hash256, error)        { return TransactionHash(t) }
+func (t *DIDDelete) SigningHash() (Hash256, error) { return TransactionSigningHash(t) }
+
+func (t *OracleSet) Hash() (Hash256, error)        { return TransactionHash(t) }
+func (t *OracleSet) SigningHash() (Hash256, error) { return TransactionSigningHash(t) }
+
+func (t *OracleDelete) Hash() (Hash256, error)        { return TransactionHash(t) }
+func (t *OracleDelete) SigningHash() (Hash256, error) { return TransactionSigningHash(t) }
+
+func (t *SetHook) Hash() (Hash256, error)        { return TransactionHash(t) }
+func (t *SetHook) SigningHash() (Hash256, error) { return TransactionSigningHash(t) }
@@ -17,6 +17,8 @@ const (
 	minValue         uint64 = 1000000000000000
 	maxValue         uint64 = 9999999999999999
 	maxNative        uint64 = 9000000000000000000
+	maxDigits        int    = 19
+	maxExponent      int64  = 1000000
 	maxNativeNetwork uint64 = 100000000000000000
 	notNative        uint64 = 0x8000000000000000
 	positive         uint64 = 0x4000000000000000
@@ -77,13 +79,13 @@ func newValue(native, negative bool, num uint64, offset int64) *Value {
 // NewNativeValue returns a Value with n drops.
 // If the value is impossible an error is returned.
 func NewNativeValue(n int64) (*Value, error) {
-	v := newValue(true, n < 0, uint64(n), 0)
+	v := newValue(true, n < 0, abs(n), 0)
 	return v, v.canonicalise()
 }
 
 // NewNonNativeValue returns a Value of n*10^offset.
 func NewNonNativeValue(n int64, offset int64) (*Value, error) {
-	v := newValue(false, n < 0, uint64(n), offset)
+	v := newValue(false, n < 0, abs(n), offset)
 	return v, v.canonicalise()
 }
 
@@ -116,21 +118,27 @@ func NewValue(s string, native bool) (*Value, error) {
 	if matches[1] == "-" {
 		v.negative = true
 	}
-	if len(matches[4]) == 0 {
-		if v.num, err = strconv.ParseUint(matches[2], 10, 64); err != nil {
-			return nil, fmt.Errorf("Invalid Number: %s Reason: %s", s, err.Error())
+	// Digits beyond the 19 which fit in a uint64 are truncated, as they
+	// would be when canonicalising
+	digits := strings.TrimLeft(matches[2]+matches[4], "0")
+	v.offset = -int64(len(matches[4]))
+	if len(digits) > maxDigits {
+		v.offset += int64(len(digits) - maxDigits)
+		digits = digits[:maxDigits]
+	}
+	if len(digits) == 0 {
+		if len(matches[2])+len(matches[4]) == 0 {
+			return nil, fmt.Errorf("Invalid Number: %s", s)
 		}
-		v.offset = 0
-	} else {
-		if v.num, err = strconv.ParseUint(matches[2]+matches[4], 10, 64); err != nil {
-			return nil, fmt.Errorf("Invalid Number: %s Reason: %s", s, err.Error())
-		}
-		v.offset = -int64(len(matches[4]))
+		digits = "0"
+	}
+	if v.num, err = strconv.ParseUint(digits, 10, 64); err != nil {
+		return nil, fmt.Errorf("Invalid Number: %s Reason: %s", s, err.Error())
 	}
 	if len(matches[5]) > 0 {
 		exp, err := strconv.ParseInt(matches[7], 10, 64)
-		if err != nil {
-			return nil, fmt.Errorf("Invalid Number: %s %s", s, err.Error())
+		if err != nil || exp > maxExponent {
+			return nil, fmt.Errorf("Invalid Number: %s Reason: exponent out of range", s)
 		}
 		if matches[6] == "-" {
 			v.offset -= exp
@@ -150,14 +158,21 @@ func (v *Value) canonicalise() error {
 			v.offset = 0
 			v.negative = false
 		} else {
-			for v.offset < 0 {
+			for v.offset < 0 && v.num > 0 {
 				v.num /= 10
 				v.offset++
 			}
-			for v.offset > 0 {
+			for v.offset > 0 && v.num > 0 {
+				if v.num > maxNative/10 {
+					return fmt.Errorf("Native amount out of range: %s", v.debug())
+				}
 				v.num *= 10
 				v.offset--
 			}
+			if v.num == 0 {
+				v.negative = false
+			}
+			v.offset = 0
 			if v.num > maxNative {
 				return fmt.Errorf("Native amount out of range: %s", v.debug())
 			}
@@ -180,7 +195,7 @@ func (v *Value) canonicalise() error {
 			}
 			if v.offset < minOffset || v.num < minValue {
 				v.num = 0
-				v.offset = 0
+				v.offset = -100
 				v.negative = false
 			}
 			if v.offset > maxOffset {
@@ -262,13 +277,27 @@ func (a Value) Add(b Value) (*Value, error) {
 		return b.Clone(), nil
 	case b.IsZero():
 		return a.Clone(), nil
+	case a.IsNative():
+		av, bv, ao := a.factor(b)
+		if (av > 0 && bv > math.MaxInt64-av) || (av < 0 && bv < math.MinInt64-av) {
+			return nil, fmt.Errorf("Native value overflow: %s+%s", a.debug(), b.debug())
+		}
+		v := newValue(true, (av+bv) < 0, abs(av+bv), ao)
+		return v, v.canonicalise()
 	default:
 		av, bv, ao := a.factor(b)
-		v := newValue(a.native, (av+bv) < 0, abs(av+bv), ao)
+		sum := av + bv
+		// Like rippled, treat a sum within 10 of zero as zero, so that
+		// rounding errors do not leave dust behind
+		if sum >= -10 && sum <= 10 {
+			return zeroNonNative.Clone(), nil
+		}
+		v := newValue(false, sum < 0, abs(sum), ao)
 		return v, v.canonicalise()
 	}
 }
 
+// Subtract subtracts b from a and returns the difference as a new Value.
 func (a Value) Subtract(b Value) (*Value, error) {
 	return a.Add(*b.Negate())
 }
@@ -423,7 +452,28 @@ func (v *Value) Bytes() []byte {
 	return b[:]
 }
 
+// checkRange returns an error if the value has no canonical binary form.
+// Native amounts above the total supply of XRP do not fit in the 62 bits
+// the wire format has for drops.
+func (v *Value) checkRange() error {
+	switch {
+	case v.IsNative() && v.num > maxNativeNetwork:
+		return fmt.Errorf("Native amount out of range: %s", v.debug())
+	case v.IsNative() || v.num == 0:
+		return nil
+	case v.num < minValue || v.num > maxValue:
+		return fmt.Errorf("Value mantissa out of range: %s", v.debug())
+	case v.offset < minOffset || v.offset > maxOffset:
+		return fmt.Errorf("Value exponent out of range: %s", v.debug())
+	default:
+		return nil
+	}
+}
+
 func (v Value) MarshalBinary() ([]byte, error) {
+	if err := v.checkRange(); err != nil {
+		return nil, err
+	}
 	return v.Bytes(), nil
 }
 
@@ -453,6 +503,23 @@ func (v Value) Rat() *big.Rat {
 	return res
 }
 
+// Drops returns a native value as a signed number of drops.
+// Non-native values and values out of range return an error.
+func (v Value) Drops() (int64, error) {
+	if !v.IsNative() {
+		return 0, fmt.Errorf("Cannot convert non-native value to drops: %s", v.debug())
+	}
+	if v.num > maxNative {
+		return 0, fmt.Errorf("Native amount out of range: %s", v.debug())
+	}
+	if v.negative {
+		return -int64(v.num), nil
+	}
+	return int64(v.num), nil
+}
+
+// Float returns an approximation of the value for display purposes. Native
+// values are represented as XRP rather than drops.
 func (v Value) Float() float64 {
 	switch {
 	case v.negative && v.native:
@@ -1,6 +1,8 @@
 package data
 
 import (
+	"encoding/hex"
+
 	. "github.com/rubblelabs/ripple/testing"
 	. "gopkg.in/check.v1"
 )
@@ -233,6 +235,59 @@ var valueTests = TestSlice{
 	{checkValBinaryMarshal(valueCheck("-0.1")).String(), Equals, "-0.1", "Binary marshal -0.1"},
 
 	{checkValHex(valueCheckCanonical(false, false, 0, -15)), Equals, "8000000000000000", "Zero hex"},
+	{checkValHex(valueCheck("1e-81")), Equals, "C0438D7EA4C68000", "Minimum hex"},
+	{checkValHex(valueCheck("-1e-81")), Equals, "80438D7EA4C68000", "Negative minimum hex"},
+	{checkValHex(valueCheck("9999999999999999e80")), Equals, "EC6386F26FC0FFFF", "Maximum hex"},
+	{checkValHex(valueCheck("-9999999999999999e80")), Equals, "AC6386F26FC0FFFF", "Negative maximum hex"},
+	{checkValHex(valueCheck("1000000000000000e-96")), Equals, "C0438D7EA4C68000", "Unnormalised minimum hex"},
+	{checkValHex(valueCheck("99999999999999999e79")), Equals, "EC6386F26FC0FFFF", "Truncated maximum hex"},
+	{checkValHex(valueCheck("1e-82")), Equals, "8000000000000000", "Underflow hex"},
+	{checkValHex(valueCheck("123456789012345678901234")), Equals, "DA4462D53C8ABAC0", "Overlong mantissa hex"},
+	{checkValHex(valueCheck("n100000000000.")), Equals, "416345785D8A0000", "Native maximum hex"},
+	{ErrorCheck(valueCheck("n100000000000.000001").MarshalBinary()), ErrorMatches, "Native amount out of range: .*", "Marshal native overflow"},
+	{checkValHex(valueCheck("n-0.0000001")), Equals, "4000000000000000", "Native underflow hex"},
+	{checkValUnmarshal("C0438D7EA4C68000") == nil, Equals, true, "Unmarshal minimum"},
+	{checkValUnmarshal("EC6386F26FC0FFFF") == nil, Equals, true, "Unmarshal maximum"},
+	{checkValUnmarshal("C0438D7EA4C67FFF"), ErrorMatches, "Value mantissa out of range: .*", "Unmarshal small mantissa"},
+	{checkValUnmarshal("D4A386F26FC10000"), ErrorMatches, "Value mantissa out of range: .*", "Unmarshal large mantissa"},
+	{checkValUnmarshal("C0038D7EA4C68000"), ErrorMatches, "Value exponent out of range: .*", "Unmarshal small exponent"},
+	{checkValUnmarshal("EC838D7EA4C68000"), ErrorMatches, "Value exponent out of range: .*", "Unmarshal large exponent"},
+	{checkValUnmarshal("C000000000000000"), ErrorMatches, "Non-canonical zero value: .*", "Unmarshal positive zero"},
+	{checkValUnmarshal("0000000000000000"), ErrorMatches, "Negative native zero: .*", "Unmarshal negative native zero"},
+	{checkValUnmarshal("416345785D8A0001"), ErrorMatches, "Native amount out of range: .*", "Unmarshal native overflow"},
+	{ErrorCheck(newValue(false, false, 1, 0).MarshalBinary()), ErrorMatches, "Value mantissa out of range: .*", "Marshal non-canonical"},
+	{ErrorCheck(newValue(false, false, minValue, maxOffset+1).MarshalBinary()), ErrorMatches, "Value exponent out of range: .*", "Marshal overflow"},
+	{ErrorCheck(NewValue("99999999999e10", true)), ErrorMatches, "Native amount out of range: .*", "Parse n99999999999e10 (overflow)"},
+	{ErrorCheck(NewValue("1e9223372036854775807", false)), ErrorMatches, "Invalid Number: .*", "Parse huge exponent"},
+	{ErrorCheck(NewValue("10000000000000000e80", false)), ErrorMatches, "Value overflow: .*", "Parse 1e96 (overflow)"},
+
+	{dropsCheck(valueCheck("n1.5")), Equals, int64(1500000), "Drops n1.5"},
+	{dropsCheck(valueCheck("n-1.5")), Equals, int64(-1500000), "Drops n-1.5"},
+	{dropsCheck(valueCheck("n0")), Equals, int64(0), "Drops n0"},
+	{dropsCheck(nativeValueCheck(-25)), Equals, int64(-25), "Drops -25"},
+	{nativeValueCheck(1500000).String(), Equals, "1.5", "NewNativeValue 1500000"},
+	{nativeValueCheck(-1500000).String(), Equals, "-1.5", "NewNativeValue -1500000"},
+	{nativeValueCheck(1500000).Float(), Equals, 1.5, "Float n1.5"},
+	{valueCheck("-0.25").Float(), Equals, -0.25, "Float -0.25"},
+	{ErrorCheck(valueCheck("1.5").Drops()), ErrorMatches, "Cannot convert non-native value to drops.*", "Drops 1.5"},
+	{ErrorCheck(NewNativeValue(-9223372036854775808)), ErrorMatches, "Native amount out of range.*", "NewNativeValue MinInt64"},
+	{ErrorCheck(NewNativeValue(9000000000000000001)), ErrorMatches, "Native amount out of range.*", "NewNativeValue > max"},
+}
+
+func dropsCheck(v *Value) int64 {
+	if drops, err := v.Drops(); err != nil {
+		panic(err)
+	} else {
+		return drops
+	}
+}
+
+func nativeValueCheck(drops int64) *Value {
+	if v, err := NewNativeValue(drops); err != nil {
+		panic(err)
+	} else {
+		return v
+	}
 }
 
 func subValCheck(a, b string) *Value {
@@ -331,3 +386,12 @@ func checkValHex(v1 *Value) string {
 
 	return string(b2h(b))
 }
+
+func checkValUnmarshal(s string) error {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	var v Value
+	return v.UnmarshalBinary(b)
+}
@@ -17,14 +17,28 @@ func (v *Value) Unmarshal(r Reader) error {
 	if v.IsNative() {
 		v.num = u & ((1 << 62) - 1)
 		v.offset = 0
-	} else {
-		v.num = u & ((1 << 54) - 1)
-		v.offset = int64((u>>54)&((1<<8)-1)) - 97
+		if v.num == 0 && v.negative {
+			return fmt.Errorf("Negative native zero: %016X", u)
+		}
+		return v.checkRange()
 	}
-	return nil
+	v.num = u & ((1 << 54) - 1)
+	v.offset = int64((u>>54)&((1<<8)-1)) - 97
+	if v.num == 0 {
+		if u != notNative {
+			return fmt.Errorf("Non-canonical zero value: %016X", u)
+		}
+		v.negative = false
+		v.offset = -100
+		return nil
+	}
+	return v.checkRange()
 }
 
 func (v *Value) Marshal(w io.Writer) error {
+	if err := v.checkRange(); err != nil {
+		return err
+	}
 	return binary.Write(w, binary.BigEndian, v.Bytes())
 }
 
@@ -57,6 +71,41 @@ func (c *Currency) Marshal(w io.Writer) error {
 	return binary.Write(w, binary.BigEndian, c.Bytes())
 }
 
+func (i *Issue) Unmarshal(r Reader) error {
+	if err := unmarshalSlice(i.Currency[:], r, "Currency"); err != nil {
+		return err
+	}
+	if i.Currency.IsNative() {
+		return nil
+	}
+	return unmarshalSlice(i.Issuer[:], r, "Issuer")
+}
+
+func (i *Issue) Marshal(w io.Writer) error {
+	if i.IsNative() {
+		return binary.Write(w, binary.BigEndian, i.Currency.Bytes())
+	}
+	return writeValues(w, []interface{}{i.Currency.Bytes(), i.Issuer.Bytes()})
+}
+
+func (b *XChainBridge) Unmarshal(r Reader) error {
+	for _, v := range []Wire{&b.LockingChainDoor, &b.LockingChainIssue, &b.IssuingChainDoor, &b.IssuingChainIssue} {
+		if err := v.Unmarshal(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *XChainBridge) Marshal(w io.Writer) error {
+	for _, v := range []Wire{&b.LockingChainDoor, &b.LockingChainIssue, &b.IssuingChainDoor, &b.IssuingChainIssue} {
+		if err := v.Marshal(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (h *Hash128) Unmarshal(r Reader) error {
 	return unmarshalSlice(h[:], r, "Hash128")
 }
@@ -162,32 +211,38 @@ func (p *PathSet) Unmarshal(r Reader) error {
 	for i := 0; ; i++ {
 		*p = append(*p, Path{})
 		for b, err := r.ReadByte(); ; b, err = r.ReadByte() {
+			if err != nil {
+				return err
+			}
 			entry := pathEntry(b)
+			if (entry == PATH_BOUNDARY || entry == PATH_END) && len((*p)[i]) == 0 {
+				return fmt.Errorf("Empty path")
+			}
 			if entry == PATH_BOUNDARY {
 				break
 			}
-			if err != nil {
-				return err
-			}
 			if entry == PATH_END {
 				return nil
 			}
+			if entry&^(PATH_ACCOUNT|PATH_CURRENCY|PATH_ISSUER) != 0 {
+				return fmt.Errorf("Bad path entry: %02X", b)
+			}
 			var pe PathElem
 			if entry&PATH_ACCOUNT > 0 {
 				pe.Account = new(Account)
-				if _, err := r.Read(pe.Account.Bytes()); err != nil {
+				if err := unmarshalSlice(pe.Account.Bytes(), r, "Path account"); err != nil {
 					return err
 				}
 			}
 			if entry&PATH_CURRENCY > 0 {
 				pe.Currency = new(Currency)
-				if _, err := r.Read(pe.Currency.Bytes()); err != nil {
+				if err := unmarshalSlice(pe.Currency.Bytes(), r, "Path currency"); err != nil {
 					return err
 				}
 			}
 			if entry&PATH_ISSUER > 0 {
 				pe.Issuer = new(Account)
-				if _, err := r.Read(pe.Issuer.Bytes()); err != nil {
+				if err := unmarshalSlice(pe.Issuer.Bytes(), r, "Path issuer"); err != nil {
 					return err
 				}
 			}
@@ -198,7 +253,14 @@ func (p *PathSet) Unmarshal(r Reader) error {
 
 func (p *PathSet) Marshal(w io.Writer) error {
 	for i, path := range *p {
+		if len(path) == 0 {
+			return fmt.Errorf("Empty path")
+		}
 		for _, entry := range path {
+			// A link with no parts would be read back as the end of the set
+			if entry.pathEntry() == 0 {
+				return fmt.Errorf("Empty path element")
+			}
 			if err := write(w, entry.pathEntry()); err != nil {
 				return err
 			}
@@ -225,6 +287,14 @@ func (p *PathSet) Marshal(w io.Writer) error {
 	return nil
 }
 
+func (t *RippleTime) Marshal(w io.Writer) error {
+	return write(w, t.T)
+}
+
+func (t *RippleTime) Unmarshal(r Reader) error {
+	return binary.Read(r, binary.BigEndian, &t.T)
+}
+
 func (res *TransactionResult) Marshal(w io.Writer) error {
 	if *res > math.MaxUint8 || *res < 0 {
 		return fmt.Errorf("Cannot marshal transaction result: %d", *res)
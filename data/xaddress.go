@@ -0,0 +1,79 @@
+package data
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/rubblelabs/ripple/crypto"
+)
+
+// X-addresses combine an account and an optional destination tag
+// as described in https://github.com/XRPLF/XRPL-Standards/issues/6
+var (
+	xAddressMain = []byte{0x05, 0x44}
+	xAddressTest = []byte{0x04, 0x93}
+)
+
+const xAddressLength = 2 + 20 + 1 + 8
+
+// NewXAddress encodes account and tag for the main network, or the test
+// network if test is set. A nil tag means no destination tag.
+func NewXAddress(account Account, tag *uint32, test bool) string {
+	b := make([]byte, 0, xAddressLength)
+	if test {
+		b = append(b, xAddressTest...)
+	} else {
+		b = append(b, xAddressMain...)
+	}
+	b = append(b, account[:]...)
+	var flags byte
+	var encodedTag [8]byte
+	if tag != nil {
+		flags = 1
+		binary.LittleEndian.PutUint32(encodedTag[:], *tag)
+	}
+	b = append(b, flags)
+	b = append(b, encodedTag[:]...)
+	return crypto.Base58Encode(b, crypto.ALPHABET)
+}
+
+// DecodeXAddress returns the account, the destination tag if any, and
+// whether s is a test network address
+func DecodeXAddress(s string) (Account, *uint32, bool, error) {
+	var account Account
+	decoded, err := crypto.Base58Decode(s, crypto.ALPHABET)
+	if err != nil {
+		return account, nil, false, err
+	}
+	b := decoded[:len(decoded)-4]
+	if len(b) != xAddressLength {
+		return account, nil, false, fmt.Errorf("Bad X-address length: %d", len(b))
+	}
+	var test bool
+	switch {
+	case bytes.Equal(b[:2], xAddressMain):
+	case bytes.Equal(b[:2], xAddressTest):
+		test = true
+	default:
+		return account, nil, false, fmt.Errorf("Unknown X-address network prefix: %X", b[:2])
+	}
+	copy(account[:], b[2:22])
+	flags, encodedTag := b[22], b[23:]
+	// The upper four bytes are reserved for 64 bit tags
+	if binary.LittleEndian.Uint32(encodedTag[4:]) != 0 {
+		return account, nil, false, fmt.Errorf("Unsupported 64 bit X-address tag")
+	}
+	switch flags {
+	case 0:
+		if binary.LittleEndian.Uint32(encodedTag) != 0 {
+			return account, nil, false, fmt.Errorf("X-address has a tag but no tag flag")
+		}
+		return account, nil, test, nil
+	case 1:
+		tag := binary.LittleEndian.Uint32(encodedTag)
+		return account, &tag, test, nil
+	default:
+		return account, nil, false, fmt.Errorf("Bad X-address flags: %d", flags)
+	}
+}
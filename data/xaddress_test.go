@@ -0,0 +1,84 @@
+package data
+
+import (
+	"github.com/rubblelabs/ripple/crypto"
+	. "gopkg.in/check.v1"
+)
+
+type XAddressSuite struct{}
+
+var _ = Suite(&XAddressSuite{})
+
+func tag(t uint32) *uint32 { return &t }
+
+var xAddressTests = []struct {
+	account  string
+	tag      *uint32
+	test     bool
+	xAddress string
+}{
+	{"rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf", nil, false, "XVLhHMPHU98es4dbozjVtdWzVrDjtV5fdx1mHp98tDMoQXb"},
+	{"rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf", tag(0), false, "XVLhHMPHU98es4dbozjVtdWzVrDjtV8AqEL4xcZj5whKbmc"},
+	{"rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf", tag(1), false, "XVLhHMPHU98es4dbozjVtdWzVrDjtV8xvjGQTYPiAx6gwDC"},
+	{"r9cZA1mLK5R5Am25ArfXFmqgNwjZgnfk59", nil, true, "T719a5UwUCnEs54UsxG9CJYYDhwmFCqkr7wxCcNcfZ6p5GZ"},
+	{"r9cZA1mLK5R5Am25ArfXFmqgNwjZgnfk59", tag(1), true, "T719a5UwUCnEs54UsxG9CJYYDhwmFCvbJNZbi37gBGkRkbE"},
+}
+
+func (s *XAddressSuite) TestXAddress(c *C) {
+	for _, test := range xAddressTests {
+		account, err := NewAccountFromAddress(test.account)
+		c.Assert(err, IsNil)
+		c.Check(NewXAddress(*account, test.tag, test.test), Equals, test.xAddress)
+
+		decoded, decodedTag, isTest, err := DecodeXAddress(test.xAddress)
+		c.Assert(err, IsNil)
+		c.Check(decoded, Equals, *account)
+		c.Check(decodedTag, DeepEquals, test.tag)
+		c.Check(isTest, Equals, test.test)
+	}
+}
+
+func (s *XAddressSuite) TestBadXAddress(c *C) {
+	for _, bad := range []string{
+		"XVLhHMPHU98es4dbozjVtdWzVrDjtV5fdx1mHp98tDMoQXc", // Checksum
+		"rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf",              // Classic address
+		"",
+	} {
+		_, _, _, err := DecodeXAddress(bad)
+		c.Check(err, NotNil, Commentf(bad))
+	}
+}
+
+func (s *XAddressSuite) TestIsValidAddress(c *C) {
+	// The same account id with another version, and with a byte too many
+	account, err := NewAccountFromAddress("rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh")
+	c.Assert(err, IsNil)
+	wrongVersion := crypto.Base58Encode(append([]byte{byte(crypto.RIPPLE_NODE_PUBLIC)}, account[:]...), crypto.ALPHABET)
+	tooLong := crypto.Base58Encode(append([]byte{byte(crypto.RIPPLE_ACCOUNT_ID)}, append(account[:], 0)...), crypto.ALPHABET)
+
+	for _, test := range []struct {
+		address string
+		valid   bool
+		err     string
+	}{
+		{"rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh", true, ""},
+		{"rrrrrrrrrrrrrrrrrrrrrhoLvTp", true, ""},
+		{"rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTH", false, "Bad Base58 checksum:.*"},
+		{"rHb9CJAWyB4rj91VRWn96DkukG4bwdtyT", false, "Bad Base58 checksum:.*"},
+		{"rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh0", false, "Bad Base58 string:.*"},
+		{"snoPBrXtMeMyMHUVTgbuqAfg1SUTb", false, "Bad version for:.*got: Family seed.*"},
+		{wrongVersion, false, "Bad version for:.*got: Validation public key for node.*"},
+		{tooLong, false, "Bad length for:.*expected: 20 got: 21"},
+		{"zzzzz", false, "Base58 string too short:.*"},
+		{"XVLhHMPHU98es4dbozjVtdWzVrDjtV5fdx1mHp98tDMoQXb", false, ".*"},
+		{"", false, ".*"},
+	} {
+		c.Check(IsValidAddress(test.address), Equals, test.valid, Commentf(test.address))
+		_, err := NewAccountFromAddress(test.address)
+		if test.valid {
+			c.Check(err, IsNil)
+		} else {
+			c.Check(err, ErrorMatches, test.err, Commentf(test.address))
+		}
+	}
+}
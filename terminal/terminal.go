@@ -240,7 +240,7 @@ func newBundle(value interface{}, flag Flag) (*bundle, error) {
 		return &bundle{
 			color:  offerStyle,
 			format: "Offer: %34s %8d %s %25s %62s %62s",
-			values: []interface{}{v.Account, v.Sequence, BoolSymbol(v.Expiration != nil && *v.Expiration > 0), v.Ratio(), v.TakerPays, v.TakerGets},
+			values: []interface{}{v.Account, v.Sequence, BoolSymbol(v.Expiration != nil && v.Expiration.Uint32() > 0), v.Ratio(), v.TakerPays, v.TakerGets},
 			flag:   flag,
 		}, nil
 	case data.AccountOffer:
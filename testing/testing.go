@@ -64,6 +64,31 @@ var Transactions = []TestData{
 	{"Trust Set", "", "1200142200000000240000000120143B3F3C8063D5CE35FA931A0000000000000000000000000000434E59000000000041C8BE2C0A6AA17471B9F6D0AF92AAB1C94D5A2568400000000000000A732102B3A6B8B8C0D0857BEA137161EA5AD27D66E469E06FACD1865C529DB85BCC29727447304502200867995E37CDAD96E5D191BA4D3142BB2E22CDD0AFC3A979537F2B3E17A10367022100F13A28922970F1DCE6DCE19F85B0A509CE1741E106AF584ACEFCF7CEAD3FCCC181144D68450D20E75C86B0C375896A9B1DDDEE87F98B"},
 	{"Set Fee", "", "1200652400000000201E0000000A201F01312D002020004C4B4035000000000000000A684000000000000000730081140000000000000000000000000000000000000000"},
 	{"AccountSet with Memo", "", "12000322000000002400000E48201B0054625068400000000000000C732102EEAF2C95B668D411FC490746C52071514F6D3A7B742D91D82CB591B5443D1C5974473045022100F3B0747B1D0CD2C1DC25D172E5BD8359FBD9C50C34A405EAD3834AD83311A1E8022056CD3DCA12EA95B5AC9F21B705B32FA7A7E86AB7ED6DE95F7901EDCB7E6BDF3B811466B05AAE728123957EF8411C44B787650C27231DF9EA7C0964616E6E792E6A70677DC39F26F91CA2138F5D4E749C52AED1D15D0709FCC22298B6386FD4AC52C2D323DDD0EE7C2506DE07482B4715333954B9C8BF304EFB2BB721C9E378FC611E43D63980F41B07DEB64EC09717A319BA469867705797DB43FBF3FFB978000D1E600321C51C76C71D9F4F071EB48EA68A13BBCEEA55D059DEAF52355E80BB5ED21D8A2B70AD49E2FF9F66BCF21BD0C651EBC03ABED3E99418B12E2ADD5C42D00E3859D03656DE662ACD0D9456CB4CAFBA208EEBFB2F76D96C138E59C76CF96395C1C0D5A04D68397F81BA9303042F121F7B765F65E1C721CC4F23FC8DC3A2E66A25B6D7CB2649EF556B80FEBCE586A75138A90472698BFE5892A5C7940DCF59B3392E291823CAAF82C451507EF3040739874B4B0DD3B55CD1C12C9FD869E3DD08B835430BF0766FD583FD083372461FD8015776DEEF9879EEF633C03C644F08F2EED7514C917905468ED122D40978BD9FB76156DA52B6A3ECFD47902D572A4E15C7FB2F51708693EC9EBBFDF78BDA1B894884C70691580D2B32E9E17F5E912AA704D95AD046941CF73D96370B9CCA338D55565EA9AF417A1B248FA6C7C531051FD96C38FDBE212EFAEC74F993EFFB73648A4EBB4C16B2389B94C5C8684388243162C96E87909BC2D56654A93D7AFAA91ECCD876BE5DBEC987D01C237E861AFCBAB6B821A7AD3AF22CA2BC60E97164AC0FB5A16E56447C4541BEEED84FFC77F0AF224A1756C07E9ABC6E59C93AC7E450316F13DD525034D2B5C1598247BBD21629A28A157F773ADF0199C2D2AF358225E18C5E3D47E171548A2B0DEE0BCA2BDBE03B78CC506E6FF87EEE1FEFE4A67A614B7E1FF2ECFA9697511EA7F196BAA122A0CC27BC4415FA6959EC915191AC7200C690D1BCB74F27559804278CF449B2D3D1E0C5001C6A46F2873840D37FD1E4E93CC55D507748B5D2EEBB2FAED04BD8826D065C6045C59696F14E6583128F1B258F6599539B72C64C7CFAFF7E173071499A8A3337872BF2B68CF373795435BD98BD45383F71BF778ED193566B90BC6F05C6C3E2B2D2F1308EB121E4670D7E0872706CC76F54666B92FE18F4F1C44AFC339A04F7E99710802DE87948F2EB06F38E6DE93073A354D78D8370E5C8E29106C9B67F1B03591A459A9F278E5950DFAC933E9E2EA78F51D7A48455293C5DC722BE2CA0EB5B34FDA8970CD7161B63997A13F3AAD59B1EED0BA14B7F56DDBB178D06954488BBE8E1F1"},
+	{"CheckCreate", "", "12001024000000012A21FB3DF12E0000000168400000000000000C69D5038D7EA4C680000000000000000000000000005553440000000000B5F762798A53D543A014CAF8B297CFF8F2F937E873210330E7FC9D56BB25D6893BA3F317AE5BCF33B3291BD63DB32654A313222F7FD02074463044022051AA9D8A6BDF0FB3DB04B3B68F194D77C657072305B6DB5A3DD73A601077015102202E5071B5AC1F225C186D7FDE70F4122B73A3881C3F6EA40B50EB6067B6454CA18114B5F762798A53D543A014CAF8B297CFF8F2F937E88314AA066C988C712815CC37AF71472B7CBBBD4E2A0A"},
+	{"CheckCash", "", "12001124000000025018838766BA2B995C00744175F69A1B11E32C3DBC40E64801A4056FCBD657F5733461D5038D7EA4C680000000000000000000000000005553440000000000B5F762798A53D543A014CAF8B297CFF8F2F937E868400000000000000C73210330E7FC9D56BB25D6893BA3F317AE5BCF33B3291BD63DB32654A313222F7FD02074473045022100EB243F119B66A0AFC002C81277A43AA92BB30A50B5B805DDDA869C67545C1F5B02204E9391554F890970161C351C710052363355343045E09F64A47E99DEB82C2E618114B5F762798A53D543A014CAF8B297CFF8F2F937E8"},
+	{"CheckCancel", "", "12001224000000035018838766BA2B995C00744175F69A1B11E32C3DBC40E64801A4056FCBD657F5733468400000000000000C73210330E7FC9D56BB25D6893BA3F317AE5BCF33B3291BD63DB32654A313222F7FD02074463044022074D974E12CAB9DE31257BD29F488F8E14CC6F05D56966D8E477BAC281599B1540220377AFDC244B391B957C8BBAB6EB8D5036C1AA747B0FA6501225134D5996C60128114B5F762798A53D543A014CAF8B297CFF8F2F937E8"},
+	{"DepositPreauth", "", "120013240000000468400000000000000C73210330E7FC9D56BB25D6893BA3F317AE5BCF33B3291BD63DB32654A313222F7FD0207446304402200EF6F418C614454B6C76AD9597E8A04E987703D25145795EB9BCFD17B312490D0220167B0A7CB345EFFB061378809AFF4F7AC40AA44C765EC8B095064ED1DAB3CF9D8114B5F762798A53D543A014CAF8B297CFF8F2F937E88514AA066C988C712815CC37AF71472B7CBBBD4E2A0A"},
+	{"TicketCreate", "", "12000A240000000520280000000568400000000000000C73210330E7FC9D56BB25D6893BA3F317AE5BCF33B3291BD63DB32654A313222F7FD020744630440220389CB27ED6D32ECDB2981B4D33067B1DF13E817DED0D839EE9CC6BACEEC7CE5202203B1BF73B9E4691E9D67A0BC6C74F45F0F42599B1FB9B405E914053341B2DAF638114B5F762798A53D543A014CAF8B297CFF8F2F937E8"},
+	{"Ticketed Payment", "", "12000024000000002029000000066140000000000F424068400000000000000C73210330E7FC9D56BB25D6893BA3F317AE5BCF33B3291BD63DB32654A313222F7FD0207447304502210095A832CD25C617E26D7200A19755A0A26FC9334A47FCC7286DCCC1EE5950B89902206997548C6DD25202A30EBE3E0EBB76D707F07B423604BA61FA9540AAE2F3231A8114B5F762798A53D543A014CAF8B297CFF8F2F937E88314AA066C988C712815CC37AF71472B7CBBBD4E2A0A"},
+	{"Clawback", "", "12001E240000000761D50B29426BFADC000000000000000000000000005553440000000000AA066C988C712815CC37AF71472B7CBBBD4E2A0A68400000000000000C73210330E7FC9D56BB25D6893BA3F317AE5BCF33B3291BD63DB32654A313222F7FD02074463044022043CE4ED7A8C635D4976D60AD21D72E69118940EC0A5E8962EE7B9B94CFAF5CD302203DC0949A06EFB8D1AD10C36E5ABE44A8FCA16243C551DAEABC2785608F7158BA8114B5F762798A53D543A014CAF8B297CFF8F2F937E8"},
+	{"DIDSet", "", "1200312280000000240000000868400000000000000C73210330E7FC9D56BB25D6893BA3F317AE5BCF33B3291BD63DB32654A313222F7FD02074473045022100C71C8A6D16BCBB596BC58D119720DBA9E140B8940F8FDA0C97163B4E3D988A1402207FDDFC44881A8892E8173AD37348B673FED04DB154CA9A3B923569A98CA4EF0C7542697066733A2F2F62616679626569676479727A74357366703775646D37687537367568377932366E6633656675796C71616266336F636C67747179353566627A6469701A2B7B2240636F6E74657874223A2268747470733A2F2F7777772E77332E6F72672F6E732F6469642F7631227D701B0B6174746573746174696F6E8114B5F762798A53D543A014CAF8B297CFF8F2F937E8"},
+	{"AccountDelete", "", "120015240025B3092E0000000D6840000000001E848073210330E7FC9D56BB25D6893BA3F317AE5BCF33B3291BD63DB32654A313222F7FD0207446304402201936A3FAF7227EBCD6A37DD1B73C01E879D85C2F2FA5D5F3B330F018D61CAD0C02206315BBA9FB1B401F2BD4E7F8B8D85A1D86FE28CC449DA572BB1ACEE370179A138114B5F762798A53D543A014CAF8B297CFF8F2F937E88314AA066C988C712815CC37AF71472B7CBBBD4E2A0A"},
+	{"NFTokenMint", "", "1200191413882280000009240000000A202A0000002A68400000000000000C73210330E7FC9D56BB25D6893BA3F317AE5BCF33B3291BD63DB32654A313222F7FD0207446304402204F20189C3DAC54CCFF1FE1340234B5B0F94567AF87655A12C8B8D69446FF76F3022068C038D7B9217BA11E4C2D3AFC06E8103E9C8EC82E9EC1F9FA58140AF6057D587542697066733A2F2F62616679626569676479727A74357366703775646D37687537367568377932366E6633656675796C71616266336F636C67747179353566627A64698114B5F762798A53D543A014CAF8B297CFF8F2F937E8"},
+	{"NFTokenCreateOffer", "", "12001B2280000001240000000B2A2E0D2CE85A000B013A95F14B0044F78A264E41713C64B5F89242540EE208C3098E00000D656140000000000F424068400000000000000C73210330E7FC9D56BB25D6893BA3F317AE5BCF33B3291BD63DB32654A313222F7FD02074473045022100D47D560D8122B65659BE102CF25E75C816602D68ADECAA026E1215B5F78236BF022012B68ED038B8F929EDDAC7A37D5164A45583C98A4BF8ADF986901874176EFC228114B5F762798A53D543A014CAF8B297CFF8F2F937E88314AA066C988C712815CC37AF71472B7CBBBD4E2A0A"},
+	{"NFTokenAcceptOffer", "", "12001D2280000000240000000C501C3A35B2A4EDF3F3BEE8B1BA9E1D3B3A8E7A5D3C49A4E3D1A1B2C3D4E5F6071829501D68CD1F6F906494EA08C9CB5CAFA64DFA90D4E834B7151899B73231DE5A0C3B7768400000000000000C6013D4838D7EA4C6800000000000000000000000000055534400000000000A20B3C85F482532A9578DBB3950B85CA06594D173210330E7FC9D56BB25D6893BA3F317AE5BCF33B3291BD63DB32654A313222F7FD02074463044022055E35197B08646803351D3411AD442961440C9A0EF68182E01BDB0FE49B058DC02204090F05975636038767FE3D9E1ECA59D65F3854A521C06F9BFF94FF3899594A58114B5F762798A53D543A014CAF8B297CFF8F2F937E8"},
+	{"NFTokenCancelOffer", "", "12001C2280000000240000000D68400000000000000C73210330E7FC9D56BB25D6893BA3F317AE5BCF33B3291BD63DB32654A313222F7FD02074463044022070CBEC262B632F5D6604A099481C4D05A4DEC828837DDDD01FAA6F9B48F2DF6402207116517C7E1DB1919DCAC4C2076715DEC50025D74F734AE0C37097E672DE8C278114B5F762798A53D543A014CAF8B297CFF8F2F937E804134068CD1F6F906494EA08C9CB5CAFA64DFA90D4E834B7151899B73231DE5A0C3B773A35B2A4EDF3F3BEE8B1BA9E1D3B3A8E7A5D3C49A4E3D1A1B2C3D4E5F6071829"},
+	{"AMMCreate", "", "1200231501F42280000000240000000E61400000000098968068400000000000000C6BD5038D7EA4C6800000000000000000000000000055534400000000000A20B3C85F482532A9578DBB3950B85CA06594D173210330E7FC9D56BB25D6893BA3F317AE5BCF33B3291BD63DB32654A313222F7FD02074473045022100C397EFFB593D445A015F6B145672DD119B527848B3EF2ACA4D0ECD00E701EAF2022035E5A3F60F6B65DE061FF7CA9D5BFD7F8A294987DB1055BEC58C67AAFA14B0018114B5F762798A53D543A014CAF8B297CFF8F2F937E8"},
+	{"AMMDeposit LPToken", "", "1200242280010000240000000F68400000000000000C6019D5438D7EA4C6800003930D02208264E2E40EC1B0C09E4DB96EE197B1DE1731B2A34154F0EDADEDABD671B60CB965994973210330E7FC9D56BB25D6893BA3F317AE5BCF33B3291BD63DB32654A313222F7FD02074473045022100DE00B84A8DB8E5C518AA045E800F469D06B55EDF66476354B86EFEDF103FA19702206DDC1DBC86D35937B4CC8A0220F0568A7E9CF4709BAB786E4A61A098A900F4EF8114B5F762798A53D543A014CAF8B297CFF8F2F937E803180000000000000000000000000000000000000000041800000000000000000000000055534400000000000A20B3C85F482532A9578DBB3950B85CA06594D1"},
+	{"AMMDeposit TwoAsset", "", "120024228010000024000000106140000000004C4B4068400000000000000C6BD4D1C37937E0800000000000000000000000000055534400000000000A20B3C85F482532A9578DBB3950B85CA06594D173210330E7FC9D56BB25D6893BA3F317AE5BCF33B3291BD63DB32654A313222F7FD0207446304402202F24F16D5DF04C0A65616E90DBEF86E0F4E8E417BFAA738C0038C15CAE6476740220575E6452BB99516A313C71905E24C22F696542501905C38261BDF211B8D87C278114B5F762798A53D543A014CAF8B297CFF8F2F937E803180000000000000000000000000000000000000000041800000000000000000000000055534400000000000A20B3C85F482532A9578DBB3950B85CA06594D1"},
+	{"AMMWithdraw LimitLPToken", "", "120025228040000024000000116140000000004C4B4068400000000000000C601B40000000000009C473210330E7FC9D56BB25D6893BA3F317AE5BCF33B3291BD63DB32654A313222F7FD0207446304402203A9CDE75A8243BCCEFF2C2F39C5F5C221E219F9CDAA130BE73DBD35013CB48AB02200E1365195D0C2F4282B9CFE7D546C4198B1B2D73A356275E6E794882561864F58114B5F762798A53D543A014CAF8B297CFF8F2F937E803180000000000000000000000000000000000000000041800000000000000000000000055534400000000000A20B3C85F482532A9578DBB3950B85CA06594D1"},
+	{"AMMVote", "", "1200261502582280000000240000001268400000000000000C73210330E7FC9D56BB25D6893BA3F317AE5BCF33B3291BD63DB32654A313222F7FD020744730450221008295799F4F1282858760785C07FA52C589DC6B02248D9C43E147E6DBCD29C85D02204510D6EBCEC0A0A76C0B35B6FC16C74A38EC4FECA8C1EEB52D43B1C4BAC0B8AB8114B5F762798A53D543A014CAF8B297CFF8F2F937E803180000000000000000000000000000000000000000041800000000000000000000000055534400000000000A20B3C85F482532A9578DBB3950B85CA06594D1"},
+	{"AMMBid", "", "1200272280000000240000001368400000000000000C6CD5038D7EA4C6800003930D02208264E2E40EC1B0C09E4DB96EE197B1DE1731B2A34154F0EDADEDABD671B60CB96599496DD508E1BC9BF0400003930D02208264E2E40EC1B0C09E4DB96EE197B1DE1731B2A34154F0EDADEDABD671B60CB965994973210330E7FC9D56BB25D6893BA3F317AE5BCF33B3291BD63DB32654A313222F7FD02074463044022047B1A5D484D1BC0062D6BA61A43C8D47810D439C06E74A927D2EFC44B587F060022061CA3AB0B07BA71F3477A28E32A0D8030D0B115D6AC625F8C91753F1039AF2AA8114B5F762798A53D543A014CAF8B297CFF8F2F937E8F019E01B8114AA066C988C712815CC37AF71472B7CBBBD4E2A0AE1E01B81140A20B3C85F482532A9578DBB3950B85CA06594D1E1F103180000000000000000000000000000000000000000041800000000000000000000000055534400000000000A20B3C85F482532A9578DBB3950B85CA06594D1"},
+	{"AMMDelete", "", "1200282280000000240000001468400000000000000C73210330E7FC9D56BB25D6893BA3F317AE5BCF33B3291BD63DB32654A313222F7FD0207446304402201C149FCF3ECE96395FC3D67B56E233D09E9CDB5F51FAC33CCDFBA96605E556C9022012FC0100C4378D315510A8F0DB01284E7A47213D9AE7C07AE4B1A78664D6799F8114B5F762798A53D543A014CAF8B297CFF8F2F937E803180000000000000000000000000000000000000000041800000000000000000000000055534400000000000A20B3C85F482532A9578DBB3950B85CA06594D1"},
+	{"OracleSet", "", "120033228000000024000000152F66CF74B420330000002268400000000000000C73210330E7FC9D56BB25D6893BA3F317AE5BCF33B3291BD63DB32654A313222F7FD02074463044022017C3B7474107E2B0A77A22FA483EDF8643321332CD25289D5A768527DC7ACB0A022033EFFBC60F347E4D94F006683AFE9AD3B9478854927248754CBB791867B3CF81701C0863757272656E6379701D09636861696E6C696E6B8114B5F762798A53D543A014CAF8B297CFF8F2F937E8F018E020301700000000000002E4041003011A0000000000000000000000000000000000000000021A0000000000000000000000005553440000000000E1E020011A0000000000000000000000000000000000000000021A0000000000000000000000004555520000000000E1F1"},
+	{"XChainCommit", "", "12002A228000000024000000163014000000000000000D61400000000098968068400000000000000C73210330E7FC9D56BB25D6893BA3F317AE5BCF33B3291BD63DB32654A313222F7FD02074473045022100AB5DE581566145355A80AA5B0A7A277D01AA3ADC72AC02DC7F7E99BED01BFE03022004FBED9B4A9D1C3794E7DB6C588E84B571523F0C78EE6D0BF4A9AA311F1916008114B5F762798A53D543A014CAF8B297CFF8F2F937E88013140A20B3C85F482532A9578DBB3950B85CA06594D1011914B5F762798A53D543A014CAF8B297CFF8F2F937E8000000000000000000000000000000000000000014AA066C988C712815CC37AF71472B7CBBBD4E2A0A0000000000000000000000000000000000000000"},
+	{"XChainClaim", "", "12002B228000000024000000172E000000073014000000000000000D61400000000098968068400000000000000C73210330E7FC9D56BB25D6893BA3F317AE5BCF33B3291BD63DB32654A313222F7FD02074473045022100B5A78BE5560445AB6A0B7B48EDE023C3B2710BD43525268835394B2E08F39E5D02206AD93574C6EE6933C9F605F39EF3DED05EF2AB3CC27D291940DC66F5159BA6C38114B5F762798A53D543A014CAF8B297CFF8F2F937E883140A20B3C85F482532A9578DBB3950B85CA06594D1011914B5F762798A53D543A014CAF8B297CFF8F2F937E8000000000000000000000000000000000000000014AA066C988C712815CC37AF71472B7CBBBD4E2A0A0000000000000000000000000000000000000000"},
+	{"XChainAddClaimAttestation", "", "12002D228000000024000000183014000000000000000D61400000000098968068400000000000000C7103ED010273210330E7FC9D56BB25D6893BA3F317AE5BCF33B3291BD63DB32654A313222F7FD020744630440220497D39E5B69FB03AA5684B17E9B234B47F0985523B7FF90F390BA118DA6BA24B02207DFBE27047E22D99C8B14EA510146C3BDF99B74D8B04E34EA5B23F84D8683791760203048114B5F762798A53D543A014CAF8B297CFF8F2F937E883140A20B3C85F482532A9578DBB3950B85CA06594D18012140A20B3C85F482532A9578DBB3950B85CA06594D1801414AA066C988C712815CC37AF71472B7CBBBD4E2A0A801514B5F762798A53D543A014CAF8B297CFF8F2F937E800101301011914B5F762798A53D543A014CAF8B297CFF8F2F937E8000000000000000000000000000000000000000014AA066C988C712815CC37AF71472B7CBBBD4E2A0A0000000000000000000000000000000000000000"},
+	{"SetHook", "", "1200162280000000240000001968400000000000000C73210330E7FC9D56BB25D6893BA3F317AE5BCF33B3291BD63DB32654A313222F7FD02074473045022100DF40B5934C442987139904998E8CA5A4972810BDD16846576311557378D4A98402204EFBD525A5137437CE3E06B969359E3B4A2E2443A02FF35E30FAE899FCF68B3B8114B5F762798A53D543A014CAF8B297CFF8F2F937E8FBEE1014000022000000015014FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFBFFFFE5020CAFECAFECAFECAFECAFECAFECAFECAFECAFECAFECAFECAFECAFECAFECAFECAFE7B040061736DF013E0177018046E616D6570190576616C7565E1F1F014E018501F0123456789ABCDEF0123456789ABCDEF0123456789ABCDEF0123456789ABCDEFE1F1E1EEE1F1"},
 }
 
 var Validations = []TestData{
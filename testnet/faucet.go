@@ -0,0 +1,141 @@
+// Package testnet funds accounts on the XRP Ledger test networks from their
+// faucets, for integration tests and demos.
+package testnet
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/rubblelabs/ripple/data"
+	"github.com/rubblelabs/ripple/websockets"
+)
+
+// The faucets and JSON-RPC servers of the public test networks
+const (
+	TestnetFaucet = "https://faucet.altnet.rippletest.net/accounts"
+	TestnetServer = "https://s.altnet.rippletest.net:51234"
+	DevnetFaucet  = "https://faucet.devnet.rippletest.net/accounts"
+	DevnetServer  = "https://s.devnet.rippletest.net:51234"
+)
+
+// Server looks up transactions, as both *websockets.Remote and
+// *websockets.HTTPClient do
+type Server interface {
+	TxContext(ctx context.Context, hash data.Hash256) (*websockets.TxResult, error)
+}
+
+type funder struct {
+	faucet string
+	server Server
+	http   *http.Client
+}
+
+// Optional settings for FundAccount
+type FundOption func(*funder)
+
+// Ask the faucet at url, such as DevnetFaucet, instead of TestnetFaucet.
+// The server should be set to one of the same network with FundServer.
+func FundFaucet(url string) FundOption {
+	return func(f *funder) { f.faucet = url }
+}
+
+// Wait for the payment using server, instead of a client of TestnetServer
+func FundServer(server Server) FundOption {
+	return func(f *funder) { f.server = server }
+}
+
+// Use client for the request to the faucet
+func FundHTTPClient(client *http.Client) FundOption {
+	return func(f *funder) { f.http = client }
+}
+
+// How often FundAccount checks on the payment, about once a ledger
+var fundPollInterval = 4 * time.Second
+
+// The body of a faucet response, of which only the payment is used
+type faucetResponse struct {
+	TransactionHash data.Hash256 `json:"transactionHash"`
+}
+
+// FundAccount asks the faucet to pay account, creating it if it does not
+// exist, and waits until the payment is in a validated ledger. This can take
+// some seconds, so ctx should allow for a few ledgers.
+func FundAccount(ctx context.Context, account data.Account, opts ...FundOption) error {
+	f := &funder{
+		faucet: TestnetFaucet,
+		http:   http.DefaultClient,
+	}
+	for _, opt := range opts {
+		opt(f)
+	}
+	if f.server == nil {
+		f.server = websockets.NewHTTPClient(TestnetServer)
+	}
+	hash, err := f.request(ctx, account)
+	if err != nil {
+		return err
+	}
+	return f.wait(ctx, hash)
+}
+
+// request asks the faucet to pay account, and returns the hash of the payment
+func (f *funder) request(ctx context.Context, account data.Account) (data.Hash256, error) {
+	body, err := json.Marshal(map[string]string{"destination": account.String()})
+	if err != nil {
+		return data.Hash256{}, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, f.faucet, bytes.NewReader(body))
+	if err != nil {
+		return data.Hash256{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := f.http.Do(req)
+	if err != nil {
+		return data.Hash256{}, err
+	}
+	defer resp.Body.Close()
+	if body, err = io.ReadAll(resp.Body); err != nil {
+		return data.Hash256{}, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return data.Hash256{}, fmt.Errorf("Unexpected response: %s %s", resp.Status, bytes.TrimSpace(body))
+	}
+	var response faucetResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return data.Hash256{}, err
+	}
+	if response.TransactionHash.IsZero() {
+		return data.Hash256{}, fmt.Errorf("Faucet response without a transaction hash: %s", bytes.TrimSpace(body))
+	}
+	return response.TransactionHash, nil
+}
+
+// wait polls the server until the payment is validated
+func (f *funder) wait(ctx context.Context, hash data.Hash256) error {
+	for {
+		select {
+		case <-time.After(fundPollInterval):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		txm, err := f.server.TxContext(ctx, hash)
+		if e, ok := err.(*websockets.CommandError); ok && e.Name == "txnNotFound" {
+			continue // Not applied to a ledger yet
+		}
+		if err != nil {
+			return err
+		}
+		if !txm.Validated {
+			continue
+		}
+		if result := txm.MetaData.TransactionResult; !result.Success() {
+			return fmt.Errorf("Funding payment %s failed: %s %s", hash, result, result.Human())
+		}
+		return nil
+	}
+}
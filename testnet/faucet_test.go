@@ -0,0 +1,119 @@
+package testnet
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/rubblelabs/ripple/data"
+	"github.com/rubblelabs/ripple/websockets"
+	. "gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { TestingT(t) }
+
+type FaucetSuite struct{}
+
+var _ = Suite(&FaucetSuite{})
+
+const paymentHash = "C6A5CA0E8E512E8BFFBD4E3B2D5DC1BDB262F1FB3B2CB7F6A1F1E441A5C5C8E4"
+
+// Answers with each of results in turn, then with the last of them
+type testServer struct {
+	c       *C
+	results []*websockets.TxResult
+}
+
+func (s *testServer) TxContext(ctx context.Context, hash data.Hash256) (*websockets.TxResult, error) {
+	s.c.Check(hash.String(), Equals, paymentHash)
+	result := s.results[0]
+	if len(s.results) > 1 {
+		s.results = s.results[1:]
+	}
+	if result == nil {
+		return nil, &websockets.CommandError{Name: "txnNotFound", Code: 29}
+	}
+	return result, nil
+}
+
+func txResult(validated bool, result data.TransactionResult) *websockets.TxResult {
+	txm := &websockets.TxResult{}
+	txm.Validated = validated
+	txm.MetaData.TransactionResult = result
+	return txm
+}
+
+func newTestFaucet(c *C, destination string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		c.Check(req.Method, Equals, http.MethodPost)
+		var request map[string]interface{}
+		c.Assert(json.NewDecoder(req.Body).Decode(&request), IsNil)
+		c.Check(request, DeepEquals, map[string]interface{}{"destination": destination})
+		c.Assert(json.NewEncoder(w).Encode(map[string]interface{}{
+			"account":         map[string]interface{}{"address": destination, "classicAddress": destination},
+			"amount":          100,
+			"transactionHash": paymentHash,
+		}), IsNil)
+	}))
+}
+
+func (s *FaucetSuite) SetUpSuite(c *C) {
+	fundPollInterval = time.Millisecond
+}
+
+func (s *FaucetSuite) TestFundAccount(c *C) {
+	account, err := data.NewAccountFromAddress("rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh")
+	c.Assert(err, IsNil)
+	faucet := newTestFaucet(c, account.String())
+	defer faucet.Close()
+
+	server := &testServer{c: c, results: []*websockets.TxResult{
+		nil,
+		txResult(false, data.TransactionResult(0)),
+		txResult(true, data.TransactionResult(0)),
+	}}
+	err = FundAccount(context.Background(), *account, FundFaucet(faucet.URL), FundServer(server))
+	c.Assert(err, IsNil)
+	c.Check(server.results, HasLen, 1)
+}
+
+func (s *FaucetSuite) TestFundAccountFailed(c *C) {
+	account, err := data.NewAccountFromAddress("rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh")
+	c.Assert(err, IsNil)
+	faucet := newTestFaucet(c, account.String())
+	defer faucet.Close()
+
+	server := &testServer{c: c, results: []*websockets.TxResult{
+		txResult(true, data.TransactionResult(104)),
+	}}
+	err = FundAccount(context.Background(), *account, FundFaucet(faucet.URL), FundServer(server))
+	c.Assert(err, ErrorMatches, "Funding payment "+paymentHash+" failed: tecUNFUNDED_PAYMENT .*")
+}
+
+func (s *FaucetSuite) TestFundAccountCancelled(c *C) {
+	account, err := data.NewAccountFromAddress("rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh")
+	c.Assert(err, IsNil)
+	faucet := newTestFaucet(c, account.String())
+	defer faucet.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	server := &testServer{c: c, results: []*websockets.TxResult{nil}}
+	err = FundAccount(ctx, *account, FundFaucet(faucet.URL), FundServer(server))
+	c.Assert(err, Equals, context.DeadlineExceeded)
+}
+
+func (s *FaucetSuite) TestUnexpectedResponse(c *C) {
+	faucet := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		http.Error(w, "Too many requests", http.StatusTooManyRequests)
+	}))
+	defer faucet.Close()
+
+	account, err := data.NewAccountFromAddress("rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh")
+	c.Assert(err, IsNil)
+	err = FundAccount(context.Background(), *account, FundFaucet(faucet.URL), FundServer(&testServer{c: c}))
+	c.Assert(err, ErrorMatches, "Unexpected response: 429 Too Many Requests Too many requests")
+}
@@ -48,8 +48,7 @@ func main() {
 	checkErr(err)
 	pays, err := data.NewAsset(os.Args[2])
 	checkErr(err)
-	var zeroAccount data.Account
-	result, err := remote.BookOffers(zeroAccount, "closed", *pays, *gets)
+	result, err := remote.BookOffers(*pays, *gets, websockets.BookLedger("closed"))
 	checkErr(err)
 	// fmt.Println(*result.LedgerSequence) //TODO: wait for nikb fix
 	for _, offer := range result.Offers {
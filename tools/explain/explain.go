@@ -110,7 +110,7 @@ func main() {
 	case len(matches[2]) > 0:
 		seq, err := strconv.ParseUint(matches[2], 10, 32)
 		checkErr(err)
-		ledger, err := r.Ledger(seq, true)
+		ledger, err := r.Ledger(seq, websockets.LedgerTransactions(), websockets.LedgerExpand())
 		checkErr(err)
 		fmt.Println("Getting transactions for: ", seq)
 		for _, txm := range ledger.Ledger.Transactions {
@@ -120,7 +120,7 @@ func main() {
 		account, err := data.NewAccountFromAddress(matches[3])
 		checkErr(err)
 		fmt.Println("Getting transactions for: ", account.String())
-		for txm := range r.AccountTx(*account, *pageSize, -1, -1) {
+		for txm := range r.AccountTx(*account, -1, -1, websockets.AccountTxLimit(*pageSize)) {
 			explain(txm, terminal.ShowLedgerSequence)
 		}
 	case len(matches[4]) > 0:
@@ -0,0 +1,205 @@
+package websockets
+
+import (
+	"context"
+
+	"github.com/rubblelabs/ripple/data"
+)
+
+var xrpAsset = data.Asset{Currency: "XRP"}
+
+// BridgedOffer is a step of a combined book. It is either an offer of the
+// direct book, or the parts of an offer from each of the books bridged by
+// XRP which a taker crosses together, when In and Out are set instead.
+// TakerPays and TakerGets are what is left to cross of the step.
+type BridgedOffer struct {
+	TakerPays data.Amount
+	TakerGets data.Amount
+	Quality   data.Quality
+	Direct    *data.OrderBookOffer
+	In        *data.OrderBookOffer // Pays the currency the taker pays for XRP
+	Out       *data.OrderBookOffer // Pays XRP for the currency the taker gets
+}
+
+// IsBridged reports whether the step crosses an offer of each XRP book
+func (o *BridgedOffer) IsBridged() bool {
+	return o.Direct == nil
+}
+
+type BridgedBookResult struct {
+	LedgerSequence uint32
+	Offers         []BridgedOffer
+}
+
+// Synchronously requests the book between pays and gets along with the books
+// between each of them and XRP, and combines them into the steps a taker
+// would cross, best first, as rippled does when it autobridges. When neither
+// pays nor gets is XRP, all three books are read from the same ledger.
+// Transfer fees are not applied, so the steps are at the rates of the offers.
+func (r *client) BookOffersBridged(pays, gets data.Asset, opts ...BookOption) (*BridgedBookResult, error) {
+	return r.BookOffersBridgedContext(context.Background(), pays, gets, opts...)
+}
+
+// BookOffersBridgedContext is the context aware version of BookOffersBridged
+func (r *client) BookOffersBridgedContext(ctx context.Context, pays, gets data.Asset, opts ...BookOption) (*BridgedBookResult, error) {
+	direct, err := r.BookOffersContext(ctx, pays, gets, opts...)
+	if err != nil {
+		return nil, err
+	}
+	result := &BridgedBookResult{LedgerSequence: direct.LedgerSequence}
+	if pays.IsNative() || gets.IsNative() {
+		result.Offers, err = bridgeBooks(direct.Offers, nil, nil)
+		return result, err
+	}
+	if direct.LedgerSequence != 0 {
+		opts = append(opts, BookLedger(direct.LedgerSequence))
+	}
+	in, err := r.BookOffersContext(ctx, pays, xrpAsset, opts...)
+	if err != nil {
+		return nil, err
+	}
+	out, err := r.BookOffersContext(ctx, xrpAsset, gets, opts...)
+	if err != nil {
+		return nil, err
+	}
+	result.Offers, err = bridgeBooks(direct.Offers, in.Offers, out.Offers)
+	return result, err
+}
+
+// fundedAmounts returns what is left to cross of an offer
+func fundedAmounts(offer *data.OrderBookOffer) (data.Amount, data.Amount) {
+	pays, gets := *offer.TakerPays, *offer.TakerGets
+	if offer.TakerPaysFunded != nil {
+		pays = *offer.TakerPaysFunded
+	}
+	if offer.TakerGetsFunded != nil {
+		gets = *offer.TakerGetsFunded
+	}
+	return pays, gets
+}
+
+// A partly crossed offer of one of the XRP books
+type bridgeLeg struct {
+	offers    []data.OrderBookOffer
+	pays      data.Amount
+	gets      data.Amount
+	remaining bool
+}
+
+// next moves on to the next funded offer, if there is one
+func (l *bridgeLeg) next() {
+	for l.remaining = false; len(l.offers) > 0 && !l.remaining; {
+		l.pays, l.gets = fundedAmounts(&l.offers[0])
+		l.remaining = !l.pays.IsZero() && !l.gets.IsZero()
+		if !l.remaining {
+			l.offers = l.offers[1:]
+		}
+	}
+}
+
+// take crosses the part of the current offer which pays or is paid xrp,
+// returning what the taker pays and gets for it
+func (l *bridgeLeg) take(xrp data.Value, native data.Amount) (*data.Amount, error) {
+	rest, err := native.Value.Subtract(xrp)
+	if err != nil {
+		return nil, err
+	}
+	if rest.IsZero() {
+		// All of the offer is taken, so none of it is lost to rounding
+		other := l.pays
+		if l.pays.IsNative() {
+			other = l.gets
+		}
+		l.offers = l.offers[1:]
+		l.next()
+		return &other, nil
+	}
+	part, err := xrpRatio(xrp, *native.Value)
+	if err != nil {
+		return nil, err
+	}
+	if l.pays.IsNative() {
+		return l.cross(&l.gets, &l.pays, part, rest)
+	}
+	return l.cross(&l.pays, &l.gets, part, rest)
+}
+
+// cross takes part of other and leaves rest of native
+func (l *bridgeLeg) cross(other, native *data.Amount, part *data.Value, rest *data.Value) (*data.Amount, error) {
+	value, err := other.Value.Multiply(*part)
+	if err != nil {
+		return nil, err
+	}
+	left, err := other.Value.Subtract(*value)
+	if err != nil {
+		return nil, err
+	}
+	taken := data.Amount{Value: value, Currency: other.Currency, Issuer: other.Issuer}
+	other.Value, native.Value = left, rest
+	return &taken, nil
+}
+
+// xrpRatio returns a/b of two native values as a non-native value
+func xrpRatio(a, b data.Value) (*data.Value, error) {
+	num, err := a.NonNative()
+	if err != nil {
+		return nil, err
+	}
+	den, err := b.NonNative()
+	if err != nil {
+		return nil, err
+	}
+	return num.Divide(*den)
+}
+
+// bridgeBooks merges the direct book with the steps of crossing the in and
+// out books together, in the order a taker would cross them. A direct offer
+// goes first when it is as good as a bridged step.
+func bridgeBooks(direct, in, out []data.OrderBookOffer) ([]BridgedOffer, error) {
+	var steps []BridgedOffer
+	inLeg, outLeg := &bridgeLeg{offers: in}, &bridgeLeg{offers: out}
+	inLeg.next()
+	outLeg.next()
+	for inLeg.remaining && outLeg.remaining {
+		// The XRP bought from the in offer is sold to the out offer
+		xrp := *inLeg.gets.Value
+		if outLeg.pays.Value.Less(xrp) {
+			xrp = *outLeg.pays.Value
+		}
+		step := BridgedOffer{In: &inLeg.offers[0], Out: &outLeg.offers[0]}
+		pays, err := inLeg.take(xrp, inLeg.gets)
+		if err != nil {
+			return nil, err
+		}
+		gets, err := outLeg.take(xrp, outLeg.pays)
+		if err != nil {
+			return nil, err
+		}
+		if step.Quality, err = data.NewQuality(*pays, *gets); err != nil {
+			return nil, err
+		}
+		step.TakerPays, step.TakerGets = *pays, *gets
+		steps = append(steps, step)
+	}
+	merged := make([]BridgedOffer, 0, len(direct)+len(steps))
+	for i := range direct {
+		pays, gets := fundedAmounts(&direct[i])
+		if pays.IsZero() || gets.IsZero() {
+			continue
+		}
+		quality, err := data.NewQuality(pays, gets)
+		if err != nil {
+			return nil, err
+		}
+		for len(steps) > 0 && steps[0].Quality.Less(quality) {
+			merged, steps = append(merged, steps[0]), steps[1:]
+		}
+		merged = append(merged, BridgedOffer{
+			TakerPays: pays,
+			TakerGets: gets,
+			Quality:   quality,
+			Direct:    &direct[i],
+		})
+	}
+	return append(merged, steps...), nil
+}
@@ -0,0 +1,103 @@
+package websockets
+
+import (
+	"github.com/rubblelabs/ripple/data"
+	. "gopkg.in/check.v1"
+)
+
+type BridgeSuite struct{}
+
+var _ = Suite(&BridgeSuite{})
+
+const bridgeIssuer = "rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B"
+
+func issued(value, currency string) map[string]interface{} {
+	return map[string]interface{}{"value": value, "currency": currency, "issuer": bridgeIssuer}
+}
+
+func bookOffer(pays, gets interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"LedgerEntryType": "Offer",
+		"Account":         "rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh",
+		"TakerPays":       pays,
+		"TakerGets":       gets,
+		"owner_funds":     "1000000000",
+		"quality":         "1",
+	}
+}
+
+func (s *BridgeSuite) TestBookOffersBridged(c *C) {
+	books := map[string][]interface{}{
+		"USD>EUR": {
+			bookOffer(issued("10", "USD"), issued("100", "EUR")),
+			bookOffer(issued("120", "USD"), issued("100", "EUR")),
+		},
+		"USD>XRP": {
+			bookOffer(issued("10", "USD"), "100000000"),
+		},
+		"XRP>EUR": {
+			bookOffer("50000000", issued("45", "EUR")),
+			bookOffer("100000000", issued("80", "EUR")),
+		},
+	}
+	ledgers := make(chan interface{}, 3)
+	server := newTestRPCServer(c, func(request map[string]interface{}) interface{} {
+		c.Check(request["method"], Equals, "book_offers")
+		params := request["params"].([]interface{})[0].(map[string]interface{})
+		ledgers <- params["ledger_index"]
+		pays := params["taker_pays"].(map[string]interface{})["currency"]
+		gets := params["taker_gets"].(map[string]interface{})["currency"]
+		return map[string]interface{}{
+			"status":       "success",
+			"ledger_index": 100,
+			"offers":       books[pays.(string)+">"+gets.(string)],
+		}
+	})
+	defer server.Close()
+
+	usd := data.Asset{Currency: "USD", Issuer: bridgeIssuer}
+	eur := data.Asset{Currency: "EUR", Issuer: bridgeIssuer}
+	result, err := NewHTTPClient(server.URL).BookOffersBridged(usd, eur)
+	c.Assert(err, IsNil)
+	c.Check(result.LedgerSequence, Equals, uint32(100))
+	c.Check(<-ledgers, IsNil)
+	c.Check(<-ledgers, Equals, float64(100))
+	c.Check(<-ledgers, Equals, float64(100))
+
+	var steps []string
+	for _, offer := range result.Offers {
+		steps = append(steps, offer.TakerPays.Value.String()+">"+offer.TakerGets.Value.String())
+	}
+	c.Check(steps, DeepEquals, []string{"10>100", "5>45", "5>40", "120>100"})
+	c.Check(result.Offers[0].IsBridged(), Equals, false)
+	c.Check(result.Offers[1].IsBridged(), Equals, true)
+	c.Check(result.Offers[1].In.TakerGets.String(), Equals, "100/XRP")
+	c.Check(result.Offers[1].Out.TakerPays.String(), Equals, "50/XRP")
+	c.Check(result.Offers[2].Out.TakerPays.String(), Equals, "100/XRP")
+	c.Check(result.Offers[3].IsBridged(), Equals, false)
+	for i := 1; i < len(result.Offers); i++ {
+		c.Check(result.Offers[i].Quality.Less(result.Offers[i-1].Quality), Equals, false)
+	}
+}
+
+func (s *BridgeSuite) TestBookOffersBridgedXRP(c *C) {
+	requests := 0
+	server := newTestRPCServer(c, func(request map[string]interface{}) interface{} {
+		requests++
+		return map[string]interface{}{
+			"status":       "success",
+			"ledger_index": 100,
+			"offers": []interface{}{
+				bookOffer(issued("10", "USD"), "100000000"),
+			},
+		}
+	})
+	defer server.Close()
+
+	usd := data.Asset{Currency: "USD", Issuer: bridgeIssuer}
+	result, err := NewHTTPClient(server.URL).BookOffersBridged(usd, xrpAsset)
+	c.Assert(err, IsNil)
+	c.Check(requests, Equals, 1)
+	c.Assert(result.Offers, HasLen, 1)
+	c.Check(result.Offers[0].IsBridged(), Equals, false)
+}
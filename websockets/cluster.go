@@ -0,0 +1,183 @@
+package websockets
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// Number of recent stream messages remembered to drop duplicates
+const clusterRecentMessages = 10000
+
+// Cluster spreads commands over several Remotes and merges their streams.
+// Commands are dispatched round-robin, and move on to the next Remote when
+// the connection to one is lost. A Remote which has closed is skipped, so
+// Remotes without a ReconnectPolicy fail over rather than wait to reconnect.
+type Cluster struct {
+	// Incoming receives the stream messages of every Remote, without the
+	// duplicates sent by more than one of them
+	Incoming chan interface{}
+	remotes  []*Remote
+	next     uint32
+	wg       sync.WaitGroup
+	settings *Remote
+}
+
+// NewCluster connects to every endpoint it can, and fails only if none of
+// them can be reached. To close all the connections, use Close(). The
+// options apply to each Remote, and the Incoming buffer and policy to the
+// Incoming channel of the Cluster as well.
+func NewCluster(endpoints []string, opts ...RemoteOption) (*Cluster, error) {
+	settings := &Remote{logger: DefaultLogger, buffers: defaultBuffers}
+	for _, opt := range opts {
+		opt(settings)
+	}
+	c := &Cluster{
+		Incoming: make(chan interface{}, settings.buffers.Incoming),
+		settings: settings,
+	}
+	var err error
+	for _, endpoint := range endpoints {
+		r, e := NewRemote(endpoint, opts...)
+		if e != nil {
+			settings.logger.Errorf("%s: %s", endpoint, e)
+			err = e
+			continue
+		}
+		c.remotes = append(c.remotes, r)
+	}
+	if len(c.remotes) == 0 {
+		return nil, fmt.Errorf("No endpoint could be reached: %v", err)
+	}
+	merged := newRecentMessages(clusterRecentMessages)
+	for _, r := range c.remotes {
+		c.wg.Add(1)
+		go c.forward(r, merged)
+	}
+	go func() {
+		c.wg.Wait()
+		close(c.Incoming)
+	}()
+	return c, nil
+}
+
+// Remotes returns every Remote of the Cluster, including closed ones
+func (c *Cluster) Remotes() []*Remote {
+	return c.remotes
+}
+
+// Do calls f with the next open Remote, and again with the one after that
+// each time the connection is lost before f finishes. Any other error is
+// returned straight away, as trying elsewhere would give the same answer.
+func (c *Cluster) Do(f func(*Remote) error) error {
+	start := int(atomic.AddUint32(&c.next, 1))
+	err := error(errConnectionClosed)
+	for i := range c.remotes {
+		r := c.remotes[(start+i)%len(c.remotes)]
+		if r.isClosed() {
+			continue
+		}
+		if err = f(r); !isConnectionClosed(err) {
+			return err
+		}
+	}
+	return err
+}
+
+// Each calls f with every open Remote, which is how to subscribe to streams
+// on all of them. The first error is returned once all the calls are done.
+func (c *Cluster) Each(f func(*Remote) error) error {
+	var err error
+	for _, r := range c.remotes {
+		if r.isClosed() {
+			continue
+		}
+		if e := f(r); e != nil && err == nil {
+			err = e
+		}
+	}
+	return err
+}
+
+// Close shuts down every Remote, and returns once the Incoming channel has
+// been drained and closed.
+func (c *Cluster) Close() {
+	for _, r := range c.remotes {
+		r.Close()
+	}
+	for range c.Incoming {
+	}
+}
+
+// forward sends the stream messages of r on to Incoming, unless another
+// Remote has already sent the same one
+func (c *Cluster) forward(r *Remote, merged *recentMessages) {
+	defer c.wg.Done()
+	for msg := range r.Incoming {
+		if key := streamMessageKey(msg); key != "" && merged.seen(key) {
+			continue
+		}
+		deliver(c.Incoming, msg, c.settings.policy, c.settings.logger, nil)
+	}
+}
+
+func (r *Remote) isClosed() bool {
+	select {
+	case <-r.closed:
+		return true
+	default:
+		return false
+	}
+}
+
+func isConnectionClosed(err error) bool {
+	e, ok := err.(*CommandError)
+	return ok && e.Name == errConnectionClosed.Name && e.Message == errConnectionClosed.Message
+}
+
+// streamMessageKey identifies messages which are the same from every server.
+// Transactions are seen both proposed and validated, so the status is part
+// of their key. Other messages are specific to the server which sent them.
+func streamMessageKey(msg interface{}) string {
+	switch msg := msg.(type) {
+	case *TransactionStreamMsg:
+		return fmt.Sprintf("transaction %s %s", msg.Transaction.GetHash(), msg.Status)
+	case *LedgerStreamMsg:
+		return fmt.Sprintf("ledger %s", msg.LedgerHash)
+	case *ValidationStreamMsg:
+		return fmt.Sprintf("validation %s %s", msg.LedgerHash, msg.ValidationPublicKey)
+	case *ManifestStreamMsg:
+		return fmt.Sprintf("manifest %s %d", msg.MasterKey, msg.Sequence)
+	default:
+		return ""
+	}
+}
+
+// A fixed number of the most recent keys, safe for concurrent use
+type recentMessages struct {
+	sync.Mutex
+	keys  map[string]struct{}
+	order []string
+	next  int
+}
+
+func newRecentMessages(n int) *recentMessages {
+	return &recentMessages{
+		keys:  make(map[string]struct{}, n),
+		order: make([]string, n),
+	}
+}
+
+// seen reports whether key is one of the most recent, and makes it so
+func (m *recentMessages) seen(key string) bool {
+	m.Lock()
+	defer m.Unlock()
+	if _, ok := m.keys[key]; ok {
+		return true
+	}
+	delete(m.keys, m.order[m.next])
+	m.order[m.next] = key
+	m.keys[key] = struct{}{}
+	m.next = (m.next + 1) % len(m.order)
+	return false
+}
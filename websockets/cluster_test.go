@@ -0,0 +1,107 @@
+package websockets
+
+import (
+	"io/ioutil"
+	"strings"
+
+	"github.com/gorilla/websocket"
+	. "gopkg.in/check.v1"
+)
+
+type ClusterSuite struct{}
+
+var _ = Suite(&ClusterSuite{})
+
+func (s *ClusterSuite) TestFailover(c *C) {
+	answer := func(ws *websocket.Conn) {
+		for {
+			var request map[string]interface{}
+			if err := ws.ReadJSON(&request); err != nil {
+				return
+			}
+			c.Assert(ws.WriteJSON(map[string]interface{}{
+				"id":     request["id"],
+				"status": "success",
+				"type":   "response",
+				"result": map[string]interface{}{"expected_ledger_size": "24"},
+			}), IsNil)
+		}
+	}
+	dropped := newTestServer(c, func(ws *websocket.Conn) {
+		// Drop the connection without answering
+		readRequest(c, ws)
+	})
+	defer dropped.Close()
+	healthy := newTestServer(c, answer)
+	defer healthy.Close()
+
+	cluster, err := NewCluster([]string{
+		strings.Replace(dropped.URL, "http", "ws", 1),
+		strings.Replace(healthy.URL, "http", "ws", 1),
+		"ws://127.0.0.1:1",
+	})
+	c.Assert(err, IsNil)
+	defer cluster.Close()
+	c.Assert(cluster.Remotes(), HasLen, 2)
+
+	// Whichever Remote is tried first, every command is answered
+	for i := 0; i < 4; i++ {
+		var result *FeeResult
+		c.Assert(cluster.Do(func(r *Remote) error {
+			result, err = r.Fee()
+			return err
+		}), IsNil)
+		c.Assert(result.ExpectedLedgerSize, Equals, uint32(24))
+	}
+}
+
+func (s *ClusterSuite) TestNoEndpoints(c *C) {
+	_, err := NewCluster([]string{"ws://127.0.0.1:1"})
+	c.Assert(err, ErrorMatches, "No endpoint could be reached: .*")
+}
+
+func (s *ClusterSuite) TestMergedStreams(c *C) {
+	transaction, err := ioutil.ReadFile("testdata/transactions_stream.json")
+	c.Assert(err, IsNil)
+	ledger, err := ioutil.ReadFile("testdata/ledger_stream.json")
+	c.Assert(err, IsNil)
+	stream := func(ws *websocket.Conn) {
+		c.Assert(ws.WriteMessage(websocket.TextMessage, transaction), IsNil)
+		c.Assert(ws.WriteMessage(websocket.TextMessage, ledger), IsNil)
+		ws.ReadMessage() // Wait for the client to hang up
+	}
+	a := newTestServer(c, stream)
+	defer a.Close()
+	b := newTestServer(c, stream)
+	defer b.Close()
+
+	cluster, err := NewCluster([]string{
+		strings.Replace(a.URL, "http", "ws", 1),
+		strings.Replace(b.URL, "http", "ws", 1),
+	})
+	c.Assert(err, IsNil)
+
+	var transactions, ledgers int
+	for i := 0; i < 2; i++ {
+		switch (<-cluster.Incoming).(type) {
+		case *TransactionStreamMsg:
+			transactions++
+		case *LedgerStreamMsg:
+			ledgers++
+		}
+	}
+	cluster.Close()
+	c.Assert(transactions, Equals, 1)
+	c.Assert(ledgers, Equals, 1)
+}
+
+func (s *ClusterSuite) TestRecentMessages(c *C) {
+	recent := newRecentMessages(2)
+	c.Assert(recent.seen("a"), Equals, false)
+	c.Assert(recent.seen("a"), Equals, true)
+	c.Assert(recent.seen("b"), Equals, false)
+	c.Assert(recent.seen("c"), Equals, false)
+	// "a" has been forgotten
+	c.Assert(recent.seen("a"), Equals, false)
+	c.Assert(recent.seen("c"), Equals, true)
+}
@@ -1,20 +1,28 @@
 package websockets
 
 import (
+	"bytes"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"math"
+	"regexp"
 	"sync/atomic"
 
+	"github.com/rubblelabs/ripple/crypto"
 	"github.com/rubblelabs/ripple/data"
 )
 
 var counter uint64
 
 type Syncer interface {
+	CommandId() uint64
 	Done()
 	Fail(message string)
 }
 
+// CommandError holds the error fields of a failed response,
+// or describes why no response could be received
 type CommandError struct {
 	Name      string `json:"error"`
 	Code      int    `json:"error_code"`
@@ -32,9 +40,30 @@ type Command struct {
 }
 
 func (c *Command) Done() {
+	if c.Status == "error" && c.CommandError == nil {
+		c.CommandError = &CommandError{
+			Name:    "unknownError",
+			Code:    -1,
+			Message: "Error response without details",
+		}
+	}
 	c.Ready <- struct{}{}
 }
 
+func (c *Command) command() *Command {
+	return c
+}
+
+// CommandId returns the id which the response to the command will have
+func (c *Command) CommandId() uint64 {
+	return c.Id
+}
+
+// SetCommandId sets the id the server answers the command with
+func (c *Command) SetCommandId(id uint64) {
+	c.Id = id
+}
+
 func (c *Command) Fail(message string) {
 	c.CommandError = &CommandError{
 		Name:    "Client Error",
@@ -44,19 +73,27 @@ func (c *Command) Fail(message string) {
 	c.Ready <- struct{}{}
 }
 
+// Deprecated: a Remote gives each command the next of its own ids as it is
+// sent, which replaces this one
 func (c *Command) IncrementId() {
 	c.Id = atomic.AddUint64(&counter, 1)
 }
 
+var errConnectionClosed = &CommandError{
+	Name:    "Client Error",
+	Code:    -1,
+	Message: "Connection Closed",
+}
+
 func (e *CommandError) Error() string {
 	return fmt.Sprintf("%s %d %s %s", e.Name, e.Code, e.Message, e.Exception)
 }
 
+// The Id is left for the Remote to assign when the command is sent
 func newCommand(command string) *Command {
 	return &Command{
-		Id:    atomic.AddUint64(&counter, 1),
 		Name:  command,
-		Ready: make(chan struct{}),
+		Ready: make(chan struct{}, 1), // Never blocks the run loop when nobody waits
 	}
 }
 
@@ -77,15 +114,95 @@ type AccountTxResult struct {
 	Transactions data.TransactionSlice  `json:"transactions,omitempty"`
 }
 
-func newAccountTxCommand(account data.Account, pageSize int, marker map[string]interface{}, minLedger, maxLedger int64) *AccountTxCommand {
-	return &AccountTxCommand{
+// Form of each transaction returned when "binary" is true
+type binaryAccountTx struct {
+	TxBlob         string `json:"tx_blob"`
+	Meta           string `json:"meta"`
+	LedgerSequence uint32 `json:"ledger_index"`
+	Validated      bool   `json:"validated"`
+}
+
+// Decodes transactions in both the JSON and binary forms
+// returned by `account_tx`
+func (r *AccountTxResult) UnmarshalJSON(b []byte) error {
+	var extract struct {
+		Marker       map[string]interface{} `json:"marker,omitempty"`
+		Transactions []json.RawMessage      `json:"transactions,omitempty"`
+	}
+	if err := json.Unmarshal(b, &extract); err != nil {
+		return err
+	}
+	r.Marker = extract.Marker
+	r.Transactions = make(data.TransactionSlice, len(extract.Transactions))
+	for i, raw := range extract.Transactions {
+		txm := &data.TransactionWithMetaData{}
+		var probe struct {
+			TxBlob *string `json:"tx_blob"`
+		}
+		if err := json.Unmarshal(raw, &probe); err != nil {
+			return err
+		}
+		if probe.TxBlob == nil {
+			if err := json.Unmarshal(raw, txm); err != nil {
+				return err
+			}
+			r.Transactions[i] = txm
+			continue
+		}
+		var bin binaryAccountTx
+		if err := json.Unmarshal(raw, &bin); err != nil {
+			return err
+		}
+		tx, err := hex.DecodeString(bin.TxBlob)
+		if err != nil {
+			return err
+		}
+		meta, err := hex.DecodeString(bin.Meta)
+		if err != nil {
+			return err
+		}
+		var hash data.Hash256
+		copy(hash[:], crypto.Sha512Half(append(data.HP_TRANSACTION_ID.Bytes(), tx...)))
+		if txm, err = data.ReadTransactionAndMetadata(bytes.NewReader(tx), bytes.NewReader(meta), hash, bin.LedgerSequence); err != nil {
+			return err
+		}
+		txm.Validated = bin.Validated
+		r.Transactions[i] = txm
+	}
+	return nil
+}
+
+// Optional parameters for `account_tx`
+type AccountTxOption func(*AccountTxCommand)
+
+// Maximum number of transactions returned per page
+func AccountTxLimit(limit int) AccountTxOption {
+	return func(cmd *AccountTxCommand) { cmd.Limit = limit }
+}
+
+// Return transactions oldest first
+func AccountTxForward() AccountTxOption {
+	return func(cmd *AccountTxCommand) { cmd.Forward = true }
+}
+
+// Request transactions as hex blobs which are decoded
+// by the data package rather than from JSON
+func AccountTxBinary() AccountTxOption {
+	return func(cmd *AccountTxCommand) { cmd.Binary = true }
+}
+
+func newAccountTxCommand(account data.Account, marker map[string]interface{}, minLedger, maxLedger int64, opts []AccountTxOption) *AccountTxCommand {
+	cmd := &AccountTxCommand{
 		Command:   newCommand("account_tx"),
 		Account:   account,
 		MinLedger: minLedger,
 		MaxLedger: maxLedger,
-		Limit:     pageSize,
 		Marker:    marker,
 	}
+	for _, opt := range opts {
+		opt(cmd)
+	}
+	return cmd
 }
 
 func newBinaryLedgerDataCommand(ledger interface{}, marker *data.Hash256) *BinaryLedgerDataCommand {
@@ -103,45 +220,148 @@ type TxCommand struct {
 	Result      *TxResult    `json:"result,omitempty"`
 }
 
+// Validated is false when the tx result is provisional
 type TxResult struct {
 	data.TransactionWithMetaData
-	Validated bool `json:"validated"`
 }
 
-// A shim to populate the Validated field before passing
-// control on to TransactionWithMetaData.UnmarshalJSON
-func (txr *TxResult) UnmarshalJSON(b []byte) error {
-	var extract map[string]interface{}
+type TxHistoryCommand struct {
+	*Command
+	Start  uint32           `json:"start"`
+	Result *TxHistoryResult `json:"result,omitempty"`
+}
+
+type TxHistoryResult struct {
+	Index        uint32                `json:"index"`
+	Transactions data.TransactionSlice `json:"txs"`
+}
+
+type TransactionEntryCommand struct {
+	*Command
+	TxHash      data.Hash256            `json:"tx_hash"`
+	LedgerIndex uint32                  `json:"ledger_index"`
+	Result      *TransactionEntryResult `json:"result,omitempty"`
+}
+
+type TransactionEntryResult struct {
+	data.TransactionWithMetaData
+	LedgerHash data.Hash256
+}
+
+// The transaction and its metadata are returned as "tx_json" and
+// "metadata", with the ledger alongside
+func (r *TransactionEntryResult) UnmarshalJSON(b []byte) error {
+	var extract struct {
+		TxJson         json.RawMessage `json:"tx_json"`
+		MetaData       data.MetaData   `json:"metadata"`
+		LedgerHash     data.Hash256    `json:"ledger_hash"`
+		LedgerSequence uint32          `json:"ledger_index"`
+		Validated      bool            `json:"validated"`
+	}
 	if err := json.Unmarshal(b, &extract); err != nil {
 		return err
 	}
-	// "validated" can be absent, when tx result is provisional.
-	validated, ok := extract["validated"]
-	if !ok {
-		txr.Validated = false
-	} else {
-		txr.Validated = validated.(bool)
+	if err := json.Unmarshal(extract.TxJson, &r.TransactionWithMetaData); err != nil {
+		return err
 	}
-	return json.Unmarshal(b, &txr.TransactionWithMetaData)
+	r.MetaData = extract.MetaData
+	r.LedgerHash = extract.LedgerHash
+	r.LedgerSequence = extract.LedgerSequence
+	r.Validated = extract.Validated
+	return nil
 }
 
 type SubmitCommand struct {
 	*Command
-	TxBlob string        `json:"tx_blob"`
-	Result *SubmitResult `json:"result,omitempty"`
+	TxBlob   string        `json:"tx_blob"`
+	FailHard bool          `json:"fail_hard,omitempty"`
+	Result   *SubmitResult `json:"result,omitempty"`
+}
+
+// Optional parameters for `submit`
+type SubmitOption func(*SubmitCommand)
+
+// Do not retry the transaction or relay it to the network unless it applies
+// to the server's open ledger straight away
+func SubmitFailHard() SubmitOption {
+	return func(cmd *SubmitCommand) { cmd.FailHard = true }
+}
+
+type SubmitMultisignedCommand struct {
+	*Command
+	TxJson map[string]interface{} `json:"tx_json"`
+	Result *SubmitResult          `json:"result,omitempty"`
 }
 
+// The EngineResult is provisional, from applying the transaction to the
+// server's open ledger. The flags record what the server then did with it.
 type SubmitResult struct {
 	EngineResult        data.TransactionResult `json:"engine_result"`
 	EngineResultCode    int                    `json:"engine_result_code"`
 	EngineResultMessage string                 `json:"engine_result_message"`
 	TxBlob              string                 `json:"tx_blob"`
 	Tx                  interface{}            `json:"tx_json"`
+	Accepted            bool                   `json:"accepted"`
+	Applied             bool                   `json:"applied"`
+	Broadcast           bool                   `json:"broadcast"`
+	Kept                bool                   `json:"kept"`
+	Queued              bool                   `json:"queued"`
+}
+
+type SimulateCommand struct {
+	*Command
+	TxBlob string          `json:"tx_blob"`
+	Result *SimulateResult `json:"result,omitempty"`
+}
+
+// The outcome of applying a transaction to a copy of the open ledger, which
+// is thrown away afterwards
+type SimulateResult struct {
+	EngineResult        data.TransactionResult `json:"engine_result"`
+	EngineResultCode    int                    `json:"engine_result_code"`
+	EngineResultMessage string                 `json:"engine_result_message"`
+	Applied             bool                   `json:"applied"`
+	LedgerSequence      uint32                 `json:"ledger_index"`
+	Tx                  interface{}            `json:"tx_json"`
+	MetaData            *data.MetaData         `json:"meta,omitempty"`
+}
+
+type SignCommand struct {
+	*Command
+	TxJson map[string]interface{} `json:"tx_json"`
+	Secret string                 `json:"secret"`
+	Result *SignResult            `json:"result,omitempty"`
+}
+
+type SignResult struct {
+	TxBlob string
+	Tx     data.Transaction // Signed by the server, including its hash
+}
+
+// Decodes tx_json into its concrete type
+func (r *SignResult) UnmarshalJSON(b []byte) error {
+	var extract struct {
+		TxBlob string          `json:"tx_blob"`
+		TxJson json.RawMessage `json:"tx_json"`
+	}
+	if err := json.Unmarshal(b, &extract); err != nil {
+		return err
+	}
+	var txType struct {
+		TransactionType data.TransactionType
+	}
+	if err := json.Unmarshal(extract.TxJson, &txType); err != nil {
+		return err
+	}
+	r.TxBlob = extract.TxBlob
+	r.Tx = data.TxFactory[txType.TransactionType]()
+	return json.Unmarshal(extract.TxJson, r.Tx)
 }
 
 type LedgerCommand struct {
 	*Command
-	LedgerIndex  interface{}   `json:"ledger_index"`
+	LedgerIndex  interface{}   `json:"ledger_index,omitempty"`
+	LedgerHash   *data.Hash256 `json:"ledger_hash,omitempty"`
 	Accounts     bool          `json:"accounts"`
 	Transactions bool          `json:"transactions"`
 	Expand       bool          `json:"expand"`
@@ -149,7 +369,62 @@ type LedgerCommand struct {
 }
 
 type LedgerResult struct {
-	Ledger data.Ledger
+	Ledger    data.Ledger
+	Validated bool `json:"validated"`
+	// Populated instead of Ledger.Transactions and Ledger.AccountState
+	// when the ledger is requested without expand
+	TransactionHashes  []data.Hash256 `json:"-"`
+	AccountStateHashes []data.Hash256 `json:"-"`
+}
+
+// isHashList reports whether raw is a non-empty JSON array of strings
+func isHashList(raw json.RawMessage) bool {
+	return bytes.HasPrefix(bytes.TrimLeft(raw, "[ \t\r\n"), []byte(`"`))
+}
+
+func (r *LedgerResult) UnmarshalJSON(b []byte) error {
+	var result struct {
+		Ledger    map[string]json.RawMessage `json:"ledger"`
+		Validated bool                       `json:"validated"`
+	}
+	if err := json.Unmarshal(b, &result); err != nil {
+		return err
+	}
+	r.Validated = result.Validated
+	for key, hashes := range map[string]*[]data.Hash256{
+		"transactions": &r.TransactionHashes,
+		"accountState": &r.AccountStateHashes,
+	} {
+		if raw, ok := result.Ledger[key]; ok && isHashList(raw) {
+			if err := json.Unmarshal(raw, hashes); err != nil {
+				return err
+			}
+			delete(result.Ledger, key)
+		}
+	}
+	ledger, err := json.Marshal(result.Ledger)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(ledger, &r.Ledger)
+}
+
+// Optional parameters for `ledger`
+type LedgerOption func(*LedgerCommand)
+
+// Include the transactions of the ledger
+func LedgerTransactions() LedgerOption {
+	return func(cmd *LedgerCommand) { cmd.Transactions = true }
+}
+
+// Include the complete state tree of the ledger
+func LedgerAccounts() LedgerOption {
+	return func(cmd *LedgerCommand) { cmd.Accounts = true }
+}
+
+// Return full transactions and ledger entries rather than their hashes
+func LedgerExpand() LedgerOption {
+	return func(cmd *LedgerCommand) { cmd.Expand = true }
 }
 
 type LedgerHeaderCommand struct {
@@ -169,6 +444,7 @@ type LedgerDataCommand struct {
 	*Command
 	Ledger interface{}       `json:"ledger"`
 	Marker *data.Hash256     `json:"marker,omitempty"`
+	Limit  int               `json:"limit,omitempty"`
 	Result *LedgerDataResult `json:"result,omitempty"`
 }
 
@@ -202,7 +478,7 @@ type BinaryLedgerDataResult struct {
 type RipplePathFindCommand struct {
 	*Command
 	SrcAccount    data.Account          `json:"source_account"`
-	SrcCurrencies *[]data.Currency      `json:"source_currencies,omitempty"`
+	SrcCurrencies []SourceCurrency      `json:"source_currencies,omitempty"`
 	DestAccount   data.Account          `json:"destination_account"`
 	DestAmount    data.Amount           `json:"destination_amount"`
 	Result        *RipplePathFindResult `json:"result,omitempty"`
@@ -220,28 +496,56 @@ type RipplePathFindResult struct {
 
 type AccountInfoCommand struct {
 	*Command
-	Account data.Account       `json:"account"`
-	Result  *AccountInfoResult `json:"result,omitempty"`
+	Account     data.Account       `json:"account"`
+	LedgerIndex interface{}        `json:"ledger_index,omitempty"`
+	Result      *AccountInfoResult `json:"result,omitempty"`
 }
 
 type AccountInfoResult struct {
-	LedgerSequence uint32           `json:"ledger_current_index"`
-	AccountData    data.AccountRoot `json:"account_data"`
+	// Populated when the current (open) ledger is queried
+	LedgerSequence uint32 `json:"ledger_current_index"`
+	// Populated when a closed or validated ledger is queried
+	LedgerIndex *uint32          `json:"ledger_index,omitempty"`
+	Validated   bool             `json:"validated"`
+	AccountData data.AccountRoot `json:"account_data"`
+}
+
+// Returns the balance in drops which the account can spend without going
+// below its reserve, given the base and owner reserves of the network
+func (r *AccountInfoResult) SpendableBalance(base, inc uint64) (uint64, error) {
+	if r.AccountData.Balance == nil {
+		return 0, fmt.Errorf("Account has no balance")
+	}
+	balance, err := r.AccountData.Balance.Drops()
+	if err != nil {
+		return 0, err
+	}
+	var ownerCount uint32
+	if r.AccountData.OwnerCount != nil {
+		ownerCount = *r.AccountData.OwnerCount
+	}
+	reserve := data.AccountReserve(ownerCount, base, inc)
+	if balance < 0 || uint64(balance) <= reserve {
+		return 0, nil
+	}
+	return uint64(balance) - reserve, nil
 }
 
 type AccountLinesCommand struct {
 	*Command
 	Account     data.Account        `json:"account"`
+	Peer        *data.Account       `json:"peer,omitempty"`
 	Limit       uint32              `json:"limit"`
 	LedgerIndex interface{}         `json:"ledger_index,omitempty"`
-	Marker      *data.Hash256       `json:"marker,omitempty"`
+	Marker      interface{}         `json:"marker,omitempty"`
 	Result      *AccountLinesResult `json:"result,omitempty"`
 }
 
+// Marker is opaque and should be passed back unchanged to get the next page
 type AccountLinesResult struct {
 	LedgerSequence *uint32               `json:"ledger_index"`
 	Account        data.Account          `json:"account"`
-	Marker         *data.Hash256         `json:"marker"`
+	Marker         interface{}           `json:"marker"`
 	Lines          data.AccountLineSlice `json:"lines"`
 }
 
@@ -261,13 +565,349 @@ type AccountOffersResult struct {
 	Offers         data.AccountOfferSlice `json:"offers"`
 }
 
+type AccountCurrenciesCommand struct {
+	*Command
+	Account data.Account             `json:"account"`
+	Result  *AccountCurrenciesResult `json:"result,omitempty"`
+}
+
+type AccountCurrenciesResult struct {
+	LedgerSequence    *uint32         `json:"ledger_index"`
+	Validated         bool            `json:"validated"`
+	SendCurrencies    []data.Currency `json:"send_currencies"`
+	ReceiveCurrencies []data.Currency `json:"receive_currencies"`
+}
+
+type NoRippleCheckCommand struct {
+	*Command
+	Account      data.Account         `json:"account"`
+	Role         string               `json:"role"`
+	Transactions bool                 `json:"transactions,omitempty"`
+	Limit        int                  `json:"limit,omitempty"`
+	Result       *NoRippleCheckResult `json:"result,omitempty"`
+}
+
+type NoRippleCheckResult struct {
+	LedgerSequence uint32
+	Validated      bool
+	Problems       []string
+	// Populated when transactions are requested
+	Transactions []data.Transaction
+}
+
+var noRippleCheckFeeRegex = regexp.MustCompile(`"Fee"\s*:\s*(\d+)`)
+
+// Decodes the suggested transactions into their concrete types.
+// rippled returns their Fee as a number rather than a string.
+func (r *NoRippleCheckResult) UnmarshalJSON(b []byte) error {
+	var extract struct {
+		LedgerSequence uint32            `json:"ledger_current_index"`
+		Validated      bool              `json:"validated"`
+		Problems       []string          `json:"problems"`
+		Transactions   []json.RawMessage `json:"transactions"`
+	}
+	if err := json.Unmarshal(b, &extract); err != nil {
+		return err
+	}
+	r.LedgerSequence = extract.LedgerSequence
+	r.Validated = extract.Validated
+	r.Problems = extract.Problems
+	r.Transactions = nil
+	for _, raw := range extract.Transactions {
+		var txType struct {
+			TransactionType data.TransactionType
+		}
+		if err := json.Unmarshal(raw, &txType); err != nil {
+			return err
+		}
+		tx := data.TxFactory[txType.TransactionType]()
+		raw = noRippleCheckFeeRegex.ReplaceAll(raw, []byte(`"Fee":"$1"`))
+		if err := json.Unmarshal(raw, tx); err != nil {
+			return err
+		}
+		r.Transactions = append(r.Transactions, tx)
+	}
+	return nil
+}
+
+type DepositAuthorizedCommand struct {
+	*Command
+	SourceAccount      data.Account             `json:"source_account"`
+	DestinationAccount data.Account             `json:"destination_account"`
+	LedgerIndex        interface{}              `json:"ledger_index,omitempty"`
+	Result             *DepositAuthorizedResult `json:"result,omitempty"`
+}
+
+type DepositAuthorizedResult struct {
+	// Populated when the current (open) ledger is queried
+	LedgerCurrentIndex *uint32 `json:"ledger_current_index,omitempty"`
+	// Populated when a closed or validated ledger is queried
+	LedgerIndex        *uint32       `json:"ledger_index,omitempty"`
+	LedgerHash         *data.Hash256 `json:"ledger_hash,omitempty"`
+	Validated          bool          `json:"validated"`
+	DepositAuthorized  bool          `json:"deposit_authorized"`
+	SourceAccount      data.Account  `json:"source_account"`
+	DestinationAccount data.Account  `json:"destination_account"`
+}
+
+type LedgerEntryCommand struct {
+	*Command
+	LedgerIndex    interface{}                `json:"ledger_index,omitempty"`
+	Index          *data.Hash256              `json:"index,omitempty"`
+	AccountRoot    *data.Account              `json:"account_root,omitempty"`
+	Offer          *ledgerEntryOffer          `json:"offer,omitempty"`
+	RippleState    *ledgerEntryRippleState    `json:"ripple_state,omitempty"`
+	Directory      *ledgerEntryDirectory      `json:"directory,omitempty"`
+	Escrow         *ledgerEntryEscrow         `json:"escrow,omitempty"`
+	DepositPreauth *ledgerEntryDepositPreauth `json:"deposit_preauth,omitempty"`
+	Ticket         *ledgerEntryTicket         `json:"ticket,omitempty"`
+	Result         *LedgerEntryResult         `json:"result,omitempty"`
+}
+
+type ledgerEntryOffer struct {
+	Account  data.Account `json:"account"`
+	Sequence uint32       `json:"seq"`
+}
+
+type ledgerEntryRippleState struct {
+	Accounts [2]data.Account `json:"accounts"`
+	Currency data.Currency   `json:"currency"`
+}
+
+type ledgerEntryDirectory struct {
+	Owner    *data.Account `json:"owner,omitempty"`
+	DirRoot  *data.Hash256 `json:"dir_root,omitempty"`
+	SubIndex uint64        `json:"sub_index,omitempty"`
+}
+
+type ledgerEntryEscrow struct {
+	Owner    data.Account `json:"owner"`
+	Sequence uint32       `json:"seq"`
+}
+
+type ledgerEntryDepositPreauth struct {
+	Owner      data.Account `json:"owner"`
+	Authorized data.Account `json:"authorized"`
+}
+
+type ledgerEntryTicket struct {
+	Account        data.Account `json:"account"`
+	TicketSequence uint32       `json:"ticket_seq"`
+}
+
+// Selects the ledger entry requested by `ledger_entry`
+type LedgerEntrySelector func(*LedgerEntryCommand)
+
+// Any ledger entry by its index
+func LedgerEntryIndex(index data.Hash256) LedgerEntrySelector {
+	return func(cmd *LedgerEntryCommand) { cmd.Index = &index }
+}
+
+// The AccountRoot of account
+func LedgerEntryAccountRoot(account data.Account) LedgerEntrySelector {
+	return func(cmd *LedgerEntryCommand) { cmd.AccountRoot = &account }
+}
+
+// The Offer created by account with sequence
+func LedgerEntryOffer(account data.Account, sequence uint32) LedgerEntrySelector {
+	return func(cmd *LedgerEntryCommand) {
+		cmd.Offer = &ledgerEntryOffer{Account: account, Sequence: sequence}
+	}
+}
+
+// The RippleState for currency between a and b, in either order
+func LedgerEntryRippleState(a, b data.Account, currency data.Currency) LedgerEntrySelector {
+	return func(cmd *LedgerEntryCommand) {
+		cmd.RippleState = &ledgerEntryRippleState{Accounts: [2]data.Account{a, b}, Currency: currency}
+	}
+}
+
+// The page subIndex of the owner directory of account
+func LedgerEntryOwnerDirectory(owner data.Account, subIndex uint64) LedgerEntrySelector {
+	return func(cmd *LedgerEntryCommand) {
+		cmd.Directory = &ledgerEntryDirectory{Owner: &owner, SubIndex: subIndex}
+	}
+}
+
+// The page subIndex of the directory with the given root, such as an order book
+func LedgerEntryDirectory(root data.Hash256, subIndex uint64) LedgerEntrySelector {
+	return func(cmd *LedgerEntryCommand) {
+		cmd.Directory = &ledgerEntryDirectory{DirRoot: &root, SubIndex: subIndex}
+	}
+}
+
+// The Escrow created by owner with sequence
+func LedgerEntryEscrow(owner data.Account, sequence uint32) LedgerEntrySelector {
+	return func(cmd *LedgerEntryCommand) {
+		cmd.Escrow = &ledgerEntryEscrow{Owner: owner, Sequence: sequence}
+	}
+}
+
+// The DepositPreauth by which owner authorizes deposits from authorized
+func LedgerEntryDepositPreauth(owner, authorized data.Account) LedgerEntrySelector {
+	return func(cmd *LedgerEntryCommand) {
+		cmd.DepositPreauth = &ledgerEntryDepositPreauth{Owner: owner, Authorized: authorized}
+	}
+}
+
+// The Ticket of account with ticketSequence
+func LedgerEntryTicket(account data.Account, ticketSequence uint32) LedgerEntrySelector {
+	return func(cmd *LedgerEntryCommand) {
+		cmd.Ticket = &ledgerEntryTicket{Account: account, TicketSequence: ticketSequence}
+	}
+}
+
+// The SignerList of owner, which ledger_entry can only find by its index
+func LedgerEntrySignerList(owner data.Account) LedgerEntrySelector {
+	return func(cmd *LedgerEntryCommand) {
+		if index, err := data.GetSignerListIndex(owner); err == nil {
+			cmd.Index = index
+		}
+	}
+}
+
+type LedgerEntryResult struct {
+	// Populated when the current (open) ledger is queried
+	LedgerCurrentIndex *uint32
+	// Populated when a closed or validated ledger is queried
+	LedgerIndex *uint32
+	LedgerHash  *data.Hash256
+	Validated   bool
+	Index       data.Hash256
+	Node        data.LedgerEntry
+}
+
+// Decodes the node into the concrete type of its LedgerEntryType
+func (r *LedgerEntryResult) UnmarshalJSON(b []byte) error {
+	var extract struct {
+		LedgerCurrentIndex *uint32         `json:"ledger_current_index"`
+		LedgerIndex        *uint32         `json:"ledger_index"`
+		LedgerHash         *data.Hash256   `json:"ledger_hash"`
+		Validated          bool            `json:"validated"`
+		Index              data.Hash256    `json:"index"`
+		Node               json.RawMessage `json:"node"`
+	}
+	if err := json.Unmarshal(b, &extract); err != nil {
+		return err
+	}
+	r.LedgerCurrentIndex = extract.LedgerCurrentIndex
+	r.LedgerIndex = extract.LedgerIndex
+	r.LedgerHash = extract.LedgerHash
+	r.Validated = extract.Validated
+	r.Index = extract.Index
+	r.Node = nil
+	if len(extract.Node) == 0 {
+		return nil
+	}
+	var leType struct {
+		LedgerEntryType data.LedgerEntryType
+	}
+	if err := json.Unmarshal(extract.Node, &leType); err != nil {
+		return err
+	}
+	node, err := data.NewLedgerEntry(leType.LedgerEntryType)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(extract.Node, node); err != nil {
+		return err
+	}
+	r.Node = node
+	return nil
+}
+
+type AccountObjectsCommand struct {
+	*Command
+	Account data.Account          `json:"account"`
+	Type    string                `json:"type,omitempty"`
+	Marker  interface{}           `json:"marker,omitempty"`
+	Limit   int                   `json:"limit,omitempty"`
+	Result  *AccountObjectsResult `json:"result,omitempty"`
+}
+
+// Marker is opaque and should be passed back unchanged to get the next page
+type AccountObjectsResult struct {
+	LedgerSequence *uint32               `json:"ledger_index"`
+	Validated      bool                  `json:"validated"`
+	Account        data.Account          `json:"account"`
+	Marker         interface{}           `json:"marker"`
+	Limit          int                   `json:"limit"`
+	AccountObjects data.LedgerEntrySlice `json:"account_objects"`
+}
+
+type GatewayBalancesCommand struct {
+	*Command
+	Account   data.Account           `json:"account"`
+	HotWallet []data.Account         `json:"hotwallet,omitempty"`
+	Result    *GatewayBalancesResult `json:"result,omitempty"`
+}
+
+// Balances of issued currencies keyed by currency code
+type GatewayBalances map[data.Currency]data.Value
+
+// Obligations are the totals issued by the gateway, excluding the balances of
+// the hot wallets. Balances, FrozenBalances and Assets are keyed by the
+// holding account.
+type GatewayBalancesResult struct {
+	LedgerSequence *uint32
+	Validated      bool
+	Account        data.Account
+	Obligations    GatewayBalances
+	Balances       map[data.Account]GatewayBalances
+	FrozenBalances map[data.Account]GatewayBalances
+	Assets         map[data.Account]GatewayBalances
+}
+
+type gatewayBalance struct {
+	Currency data.Currency       `json:"currency"`
+	Value    data.NonNativeValue `json:"value"`
+}
+
+func newGatewayBalancesMap(accounts map[data.Account][]gatewayBalance) map[data.Account]GatewayBalances {
+	m := make(map[data.Account]GatewayBalances, len(accounts))
+	for account, balances := range accounts {
+		m[account] = make(GatewayBalances, len(balances))
+		for _, balance := range balances {
+			m[account][balance.Currency] = balance.Value.Value
+		}
+	}
+	return m
+}
+
+// Decodes issued currency values, which rippled returns as plain strings
+func (r *GatewayBalancesResult) UnmarshalJSON(b []byte) error {
+	var extract struct {
+		LedgerSequence *uint32                               `json:"ledger_index"`
+		Validated      bool                                  `json:"validated"`
+		Account        data.Account                          `json:"account"`
+		Obligations    map[data.Currency]data.NonNativeValue `json:"obligations"`
+		Balances       map[data.Account][]gatewayBalance     `json:"balances"`
+		FrozenBalances map[data.Account][]gatewayBalance     `json:"frozen_balances"`
+		Assets         map[data.Account][]gatewayBalance     `json:"assets"`
+	}
+	if err := json.Unmarshal(b, &extract); err != nil {
+		return err
+	}
+	r.LedgerSequence = extract.LedgerSequence
+	r.Validated = extract.Validated
+	r.Account = extract.Account
+	r.Obligations = make(GatewayBalances, len(extract.Obligations))
+	for currency, value := range extract.Obligations {
+		r.Obligations[currency] = value.Value
+	}
+	r.Balances = newGatewayBalancesMap(extract.Balances)
+	r.FrozenBalances = newGatewayBalancesMap(extract.FrozenBalances)
+	r.Assets = newGatewayBalancesMap(extract.Assets)
+	return nil
+}
+
 type BookOffersCommand struct {
 	*Command
-	LedgerIndex interface{}  `json:"ledger_index,omitempty"`
-	Taker       data.Account `json:"taker"`
-	TakerPays   data.Asset   `json:"taker_pays"`
-	TakerGets   data.Asset   `json:"taker_gets"`
-	Limit       uint32       `json:"limit"`
+	LedgerIndex interface{}   `json:"ledger_index,omitempty"`
+	Taker       *data.Account `json:"taker,omitempty"`
+	TakerPays   data.Asset    `json:"taker_pays"`
+	TakerGets   data.Asset    `json:"taker_gets"`
+	Limit       uint32        `json:"limit"`
 	Result      *BookOffersResult
 }
 
@@ -276,6 +916,25 @@ type BookOffersResult struct {
 	Offers         []data.OrderBookOffer `json:"offers"`
 }
 
+// Optional parameters for `book_offers`
+type BookOption func(*BookOffersCommand)
+
+// Offers are shown from the perspective of taker,
+// which affects the reported funding of the taker's own offers
+func BookTaker(taker data.Account) BookOption {
+	return func(cmd *BookOffersCommand) { cmd.Taker = &taker }
+}
+
+// Maximum number of offers returned
+func BookLimit(limit uint32) BookOption {
+	return func(cmd *BookOffersCommand) { cmd.Limit = limit }
+}
+
+// Ledger sequence, "validated", "closed" or "current"
+func BookLedger(ledgerIndex interface{}) BookOption {
+	return func(cmd *BookOffersCommand) { cmd.LedgerIndex = ledgerIndex }
+}
+
 type FeeCommand struct {
 	*Command
 	Result *FeeResult
@@ -300,3 +959,300 @@ type FeeResult struct {
 	MaxQueueSize uint32 `json:"max_queue_size,string"`
 	Status       string `json:"status"`
 }
+
+// Urgency levels accepted by SuggestedFee
+const (
+	FeeUrgencyLow    = iota // Minimum fee, the transaction may be queued
+	FeeUrgencyNormal        // Enough to enter the current open ledger
+	FeeUrgencyHigh          // At least the median fee of recent ledgers
+)
+
+// SuggestedFee returns a fee in drops for the given urgency. Urgencies above
+// FeeUrgencyHigh multiply the high fee by each further step.
+func (f *FeeResult) SuggestedFee(urgency int) (*data.Value, error) {
+	fee := f.Drops.MinimumFee
+	if urgency >= FeeUrgencyNormal && fee.Less(f.Drops.OpenLedgerFee) {
+		fee = f.Drops.OpenLedgerFee
+	}
+	if urgency >= FeeUrgencyHigh && fee.Less(f.Drops.MedianFee) {
+		fee = f.Drops.MedianFee
+	}
+	if urgency <= FeeUrgencyHigh {
+		return fee.Clone(), nil
+	}
+	factor, err := data.NewNativeValue(int64(urgency - FeeUrgencyHigh + 1))
+	if err != nil {
+		return nil, err
+	}
+	return fee.Multiply(*factor)
+}
+
+type ServerInfoCommand struct {
+	*Command
+	Result *ServerInfoResult
+}
+
+type ServerInfoResult struct {
+	Info struct {
+		BuildVersion    string  `json:"build_version"`
+		CompleteLedgers string  `json:"complete_ledgers"`
+		HostID          string  `json:"hostid"`
+		IOLatencyMs     uint32  `json:"io_latency_ms"`
+		LoadFactor      float64 `json:"load_factor"`
+		Peers           uint32  `json:"peers"`
+		PubkeyNode      string  `json:"pubkey_node"`
+		ServerState     string  `json:"server_state"`
+		Uptime          uint64  `json:"uptime"`
+		ValidatedLedger *struct {
+			Age            uint32       `json:"age"`
+			BaseFeeXRP     float64      `json:"base_fee_xrp"`
+			Hash           data.Hash256 `json:"hash"`
+			ReserveBaseXRP float64      `json:"reserve_base_xrp"`
+			ReserveIncXRP  float64      `json:"reserve_inc_xrp"`
+			Seq            uint32       `json:"seq"`
+		} `json:"validated_ledger,omitempty"`
+		ValidationQuorum uint32 `json:"validation_quorum"`
+	} `json:"info"`
+}
+
+// Returns the base and owner reserves in drops of the last validated ledger,
+// or zero if the server has not validated one
+func (s *ServerInfoResult) Reserves() (base, inc uint64) {
+	if s.Info.ValidatedLedger == nil {
+		return 0, 0
+	}
+	base = uint64(math.Round(s.Info.ValidatedLedger.ReserveBaseXRP * 1000000))
+	inc = uint64(math.Round(s.Info.ValidatedLedger.ReserveIncXRP * 1000000))
+	return base, inc
+}
+
+type AccountChannelsCommand struct {
+	*Command
+	Account            data.Account           `json:"account"`
+	DestinationAccount *data.Account          `json:"destination_account,omitempty"`
+	Marker             interface{}            `json:"marker,omitempty"`
+	Result             *AccountChannelsResult `json:"result,omitempty"`
+}
+
+// Marker is opaque and should be passed back unchanged to get the next page
+type AccountChannelsResult struct {
+	LedgerSequence *uint32          `json:"ledger_index"`
+	Validated      bool             `json:"validated"`
+	Account        data.Account     `json:"account"`
+	Marker         interface{}      `json:"marker"`
+	Limit          int              `json:"limit"`
+	Channels       []AccountChannel `json:"channels"`
+}
+
+// AccountChannel is a payment channel as account_channels returns it. The
+// Amount and Balance are in drops, and the Balance is what has been paid out
+// of the Amount.
+type AccountChannel struct {
+	ChannelID          data.Hash256     `json:"channel_id"`
+	Account            data.Account     `json:"account"`
+	DestinationAccount data.Account     `json:"destination_account"`
+	Amount             data.Value       `json:"amount"`
+	Balance            data.Value       `json:"balance"`
+	SettleDelay        uint32           `json:"settle_delay"`
+	PublicKey          *data.PublicKey  `json:"public_key_hex,omitempty"`
+	Expiration         *data.RippleTime `json:"expiration,omitempty"`
+	CancelAfter        *data.RippleTime `json:"cancel_after,omitempty"`
+	SourceTag          *uint32          `json:"source_tag,omitempty"`
+	DestinationTag     *uint32          `json:"destination_tag,omitempty"`
+}
+
+// Remaining returns the drops which can still be paid out of the channel
+func (c *AccountChannel) Remaining() (*data.Value, error) {
+	return c.Amount.Subtract(c.Balance)
+}
+
+// NFTOffersCommand is used for both nft_buy_offers and nft_sell_offers
+type NFTOffersCommand struct {
+	*Command
+	NFTokenID data.Hash256     `json:"nft_id"`
+	Marker    interface{}      `json:"marker,omitempty"`
+	Result    *NFTOffersResult `json:"result,omitempty"`
+}
+
+// Marker is opaque and should be passed back unchanged to get the next page
+type NFTOffersResult struct {
+	LedgerSequence *uint32      `json:"ledger_index"`
+	Validated      bool         `json:"validated"`
+	NFTokenID      data.Hash256 `json:"nft_id"`
+	Marker         interface{}  `json:"marker"`
+	Limit          int          `json:"limit"`
+	Offers         []NFTOffer   `json:"offers"`
+}
+
+// NFTOffer is an offer to buy or sell an NFToken for Amount. Only the
+// Destination can accept it, if it has one.
+type NFTOffer struct {
+	Index       data.Hash256         `json:"nft_offer_index"`
+	Owner       data.Account         `json:"owner"`
+	Amount      data.Amount          `json:"amount"`
+	Flags       data.LedgerEntryFlag `json:"flags"`
+	Destination *data.Account        `json:"destination,omitempty"`
+	Expiration  *data.RippleTime     `json:"expiration,omitempty"`
+}
+
+type NFTInfoCommand struct {
+	*Command
+	NFTokenID   data.Hash256   `json:"nft_id"`
+	LedgerIndex interface{}    `json:"ledger_index,omitempty"`
+	Result      *NFTInfoResult `json:"result,omitempty"`
+}
+
+// NFTInfoResult is what Clio knows of an NFToken, including one which has
+// been burned. Flags are those of the NFTokenMint which created it.
+type NFTInfoResult struct {
+	LedgerSequence uint32              `json:"ledger_index"`
+	Validated      bool                `json:"validated"`
+	NFTokenID      data.Hash256        `json:"nft_id"`
+	Owner          data.Account        `json:"owner"`
+	Issuer         data.Account        `json:"issuer"`
+	IsBurned       bool                `json:"is_burned"`
+	Flags          uint16              `json:"flags"`
+	TransferFee    uint16              `json:"transfer_fee"`
+	Taxon          uint32              `json:"nft_taxon"`
+	Serial         uint32              `json:"nft_serial"`
+	URI            data.VariableLength `json:"uri"`
+}
+
+type ChannelAuthorizeCommand struct {
+	*Command
+	ChannelID data.Hash256            `json:"channel_id"`
+	Amount    *data.Value             `json:"amount"`
+	Secret    string                  `json:"secret"`
+	KeyType   string                  `json:"key_type,omitempty"`
+	Result    *ChannelAuthorizeResult `json:"result,omitempty"`
+}
+
+type ChannelAuthorizeResult struct {
+	Signature data.VariableLength `json:"signature"`
+}
+
+type ChannelVerifyCommand struct {
+	*Command
+	ChannelID data.Hash256         `json:"channel_id"`
+	Amount    *data.Value          `json:"amount"`
+	PublicKey data.PublicKey       `json:"public_key"`
+	Signature data.VariableLength  `json:"signature"`
+	Result    *ChannelVerifyResult `json:"result,omitempty"`
+}
+
+type ChannelVerifyResult struct {
+	SignatureVerified bool `json:"signature_verified"`
+}
+
+type ServerStateCommand struct {
+	*Command
+	Result *ServerStateResult
+}
+
+type ServerStateResult struct {
+	State struct {
+		BuildVersion    string `json:"build_version"`
+		CompleteLedgers string `json:"complete_ledgers"`
+		IOLatencyMs     uint32 `json:"io_latency_ms"`
+		LoadBase        uint64 `json:"load_base"`
+		LoadFactor      uint64 `json:"load_factor"`
+		Peers           uint32 `json:"peers"`
+		PubkeyNode      string `json:"pubkey_node"`
+		ServerState     string `json:"server_state"`
+		Uptime          uint64 `json:"uptime"`
+		ValidatedLedger *struct {
+			BaseFee     uint64          `json:"base_fee"`
+			CloseTime   data.RippleTime `json:"close_time"`
+			Hash        data.Hash256    `json:"hash"`
+			ReserveBase uint64          `json:"reserve_base"`
+			ReserveInc  uint64          `json:"reserve_inc"`
+			Seq         uint32          `json:"seq"`
+		} `json:"validated_ledger,omitempty"`
+		ValidationQuorum uint32 `json:"validation_quorum"`
+	} `json:"state"`
+}
+
+// Returns the cost in drops of a reference transaction at the current load
+func (s *ServerStateResult) TransactionCost() uint64 {
+	if s.State.ValidatedLedger == nil || s.State.LoadBase == 0 {
+		return 0
+	}
+	return (s.State.ValidatedLedger.BaseFee * s.State.LoadFactor) / s.State.LoadBase
+}
+
+type FeatureCommand struct {
+	*Command
+	Feature string `json:"feature,omitempty"`
+	Result  *FeatureResult
+}
+
+type FeatureStatus struct {
+	Enabled   bool   `json:"enabled"`
+	Name      string `json:"name"`
+	Supported bool   `json:"supported"`
+	Vetoed    bool   `json:"vetoed"`
+}
+
+type FeatureResult struct {
+	Features map[data.Hash256]FeatureStatus
+}
+
+// Decodes both the features of a request for all of them, which rippled
+// lists under "features", and the one feature asked for by name or id,
+// which it returns keyed by id alongside the other result fields
+func (r *FeatureResult) UnmarshalJSON(b []byte) error {
+	var extract map[string]json.RawMessage
+	if err := json.Unmarshal(b, &extract); err != nil {
+		return err
+	}
+	if features, ok := extract["features"]; ok {
+		return json.Unmarshal(features, &r.Features)
+	}
+	r.Features = make(map[data.Hash256]FeatureStatus)
+	for key, value := range extract {
+		id, err := data.NewHash256(key)
+		if err != nil {
+			continue
+		}
+		var status FeatureStatus
+		if err := json.Unmarshal(value, &status); err != nil {
+			return err
+		}
+		r.Features[*id] = status
+	}
+	return nil
+}
+
+type LedgerAcceptCommand struct {
+	*Command
+	Result *LedgerAcceptResult
+}
+
+type LedgerAcceptResult struct {
+	LedgerCurrentIndex uint32 `json:"ledger_current_index"` // The new open ledger
+}
+
+type WalletProposeCommand struct {
+	*Command
+	KeyType string         `json:"key_type,omitempty"`
+	Result  *crypto.Wallet `json:"result,omitempty"`
+}
+
+// A command the library does not model, sent with the given params
+type AdminCommand struct {
+	*Command
+	Params map[string]interface{} `json:"-"`
+	Result json.RawMessage        `json:"result,omitempty"`
+}
+
+// Puts the params alongside the id and name of the command, which win over
+// params of the same name
+func (cmd *AdminCommand) MarshalJSON() ([]byte, error) {
+	fields := make(map[string]interface{}, len(cmd.Params)+2)
+	for name, value := range cmd.Params {
+		fields[name] = value
+	}
+	fields["id"] = cmd.Id
+	fields["command"] = cmd.Name
+	return json.Marshal(fields)
+}
@@ -1,10 +1,13 @@
 package websockets
 
 import (
+	"encoding/hex"
 	"encoding/json"
+	"fmt"
 	"io/ioutil"
 	"testing"
 
+	"github.com/rubblelabs/ripple/crypto"
 	"github.com/rubblelabs/ripple/data"
 	. "gopkg.in/check.v1"
 )
@@ -98,6 +101,72 @@ func (s *MessagesSuite) TestTxResponse(c *C) {
 	c.Assert(offer.Sequence, Equals, uint32(1681497))
 }
 
+func (s *MessagesSuite) TestTxHistoryResponse(c *C) {
+	msg := &TxHistoryCommand{}
+	readResponseFile(c, msg, "testdata/tx_history.json")
+
+	// Response fields
+	c.Assert(msg.Status, Equals, "success")
+	c.Assert(msg.Type, Equals, "response")
+
+	// Result fields
+	c.Assert(msg.Result.Index, Equals, uint32(0))
+	c.Assert(msg.Result.Transactions, HasLen, 2)
+
+	payment := msg.Result.Transactions[0].Transaction.(*data.Payment)
+	c.Assert(msg.Result.Transactions[0].LedgerSequence, Equals, uint32(6917763))
+	c.Assert(payment.GetHash().String(), Equals, "D8B87B6D041951C054E6BCD0297E3FF1B0A020951DFDDF987AF92A76AF8AF4AA")
+	c.Assert(payment.Amount.String(), Equals, "1/XRP")
+
+	offer := msg.Result.Transactions[1].Transaction.(*data.OfferCreate)
+	c.Assert(msg.Result.Transactions[1].LedgerSequence, Equals, uint32(6917762))
+	c.Assert(offer.Sequence, Equals, uint32(1681497))
+}
+
+func (s *MessagesSuite) TestTxHistoryRequest(c *C) {
+	cmd := &TxHistoryCommand{
+		Command: newCommand("tx_history"),
+		Start:   20,
+	}
+	b, err := json.Marshal(cmd)
+	c.Assert(err, IsNil)
+	c.Assert(string(b), Matches, `.*"command":"tx_history","start":20\}`)
+}
+
+func (s *MessagesSuite) TestTransactionEntryResponse(c *C) {
+	msg := &TransactionEntryCommand{}
+	readResponseFile(c, msg, "testdata/transaction_entry.json")
+
+	// Response fields
+	c.Assert(msg.Status, Equals, "success")
+	c.Assert(msg.Type, Equals, "response")
+
+	// Result fields
+	c.Assert(msg.Result.LedgerHash.String(), Equals, "C2B7FB1227D51AB8CD2B7CC8F3B2FA5A13B1DFF7DC2F86E2BFC79D1D3E4C9B48")
+	c.Assert(msg.Result.LedgerSequence, Equals, uint32(6917762))
+	c.Assert(msg.Result.Validated, Equals, true)
+	c.Assert(msg.Result.MetaData.AffectedNodes, HasLen, 4)
+	c.Assert(msg.Result.MetaData.TransactionResult.String(), Equals, "tesSUCCESS")
+
+	offer := msg.Result.Transaction.(*data.OfferCreate)
+	c.Assert(msg.Result.GetHash().String(), Equals, "2D0CE11154B655A2BFE7F3F857AAC344622EC7DAB11B1EBD920DCDB00E8646FF")
+	c.Assert(offer.Account.String(), Equals, "rwpxNWdpKu2QVgrh5LQXEygYLshhgnRL1Y")
+	c.Assert(offer.Sequence, Equals, uint32(1681497))
+}
+
+func (s *MessagesSuite) TestTransactionEntryRequest(c *C) {
+	hash, err := data.NewHash256("2D0CE11154B655A2BFE7F3F857AAC344622EC7DAB11B1EBD920DCDB00E8646FF")
+	c.Assert(err, IsNil)
+	cmd := &TransactionEntryCommand{
+		Command:     newCommand("transaction_entry"),
+		TxHash:      *hash,
+		LedgerIndex: 6917762,
+	}
+	b, err := json.Marshal(cmd)
+	c.Assert(err, IsNil)
+	c.Assert(string(b), Matches, `.*"command":"transaction_entry","tx_hash":"2D0CE11154B655A2BFE7F3F857AAC344622EC7DAB11B1EBD920DCDB00E8646FF","ledger_index":6917762\}`)
+}
+
 func (s *MessagesSuite) TestAccountTxResponse(c *C) {
 	msg := &AccountTxCommand{}
 	readResponseFile(c, msg, "testdata/account_tx.json")
@@ -108,10 +177,25 @@ func (s *MessagesSuite) TestAccountTxResponse(c *C) {
 
 	c.Assert(len(msg.Result.Transactions), Equals, 2)
 	c.Assert(msg.Result.Transactions[1].Date.String(), Equals, "2014-Jun-19 14:14:40 UTC")
+	c.Assert(msg.Result.Transactions[1].Validated, Equals, true)
 	offer := msg.Result.Transactions[1].Transaction.(*data.OfferCreate)
 	c.Assert(offer.TakerPays.String(), Equals, "0.034800328/BTC/rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B")
 }
 
+func (s *MessagesSuite) TestAccountTxResponseMentioningBlob(c *C) {
+	var response map[string]interface{}
+	readResponseFile(c, &response, "testdata/account_tx.json")
+	txs := response["result"].(map[string]interface{})["transactions"].([]interface{})
+	txs[0].(map[string]interface{})["warning"] = "tx_blob"
+	b, err := json.Marshal(response)
+	c.Assert(err, IsNil)
+
+	msg := &AccountTxCommand{}
+	c.Assert(json.Unmarshal(b, msg), IsNil)
+	c.Assert(msg.Result.Transactions, HasLen, 2)
+	c.Assert(msg.Result.Transactions[1].Validated, Equals, true)
+}
+
 func (s *MessagesSuite) TestLedgerDataResponse(c *C) {
 	msg := &LedgerDataCommand{}
 	readResponseFile(c, msg, "testdata/ledger_data.json")
@@ -159,4 +243,836 @@ func (s *MessagesSuite) TestAccountInfoResponse(c *C) {
 	c.Assert(msg.Result.AccountData.LedgerEntryType, Equals, data.ACCOUNT_ROOT)
 	c.Assert(*msg.Result.AccountData.Sequence, Equals, uint32(546))
 	c.Assert(msg.Result.AccountData.Balance.String(), Equals, "10321199.422233")
+
+	spendable, err := msg.Result.SpendableBalance(10000000, 2000000)
+	c.Assert(err, IsNil)
+	c.Assert(spendable, Equals, uint64(10321189422233))
+	spendable, err = msg.Result.SpendableBalance(20000000000000, 2000000)
+	c.Assert(err, IsNil)
+	c.Assert(spendable, Equals, uint64(0))
+}
+
+func (s *MessagesSuite) TestAccountInfoValidatedResponse(c *C) {
+	msg := &AccountInfoCommand{}
+	readResponseFile(c, msg, "testdata/account_info_validated.json")
+
+	// Response fields
+	c.Assert(msg.Status, Equals, "success")
+	c.Assert(msg.Type, Equals, "response")
+
+	c.Assert(msg.Result.Validated, Equals, true)
+	c.Assert(*msg.Result.LedgerIndex, Equals, uint32(7636500))
+	c.Assert(*msg.Result.AccountData.OwnerCount, Equals, uint32(3))
+	c.Assert(*msg.Result.AccountData.Flags, Equals, data.LsRequireDestTag)
+	c.Assert(msg.Result.AccountData.Balance.String(), Equals, "10321199.422233")
+}
+
+func (s *MessagesSuite) TestAccountLinesResponse(c *C) {
+	msg := &AccountLinesCommand{}
+	readResponseFile(c, msg, "testdata/account_lines.json")
+
+	// Response fields
+	c.Assert(msg.Status, Equals, "success")
+	c.Assert(msg.Type, Equals, "response")
+
+	c.Assert(*msg.Result.LedgerSequence, Equals, uint32(7636500))
+	c.Assert(msg.Result.Account.String(), Equals, "rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B")
+	c.Assert(msg.Result.Marker, Equals, "7CA522AFADD2B493108BC4B4B1AA6E4EC8A9B579F1BAA2A4EE374A49D8C08E3F,0")
+	c.Assert(msg.Result.Lines, HasLen, 2)
+	c.Assert(msg.Result.Lines[0].Currency.String(), Equals, "USD")
+	c.Assert(msg.Result.Lines[0].Balance.String(), Equals, "-1.5")
+	c.Assert(msg.Result.Lines[0].LimitPeer.String(), Equals, "100")
+	c.Assert(msg.Result.Lines[0].NoRipple, Equals, true)
+	c.Assert(msg.Result.Lines[1].QualityIn, Equals, uint32(1002000000))
+	c.Assert(msg.Result.Lines[1].QualityOut, Equals, uint32(998000000))
+}
+
+func (s *MessagesSuite) TestAccountTxBinaryResponse(c *C) {
+	msg := &AccountTxCommand{}
+	readResponseFile(c, msg, "testdata/account_tx_binary.json")
+
+	// Response fields
+	c.Assert(msg.Status, Equals, "success")
+	c.Assert(msg.Type, Equals, "response")
+
+	c.Assert(msg.Result.Marker["ledger"], Equals, float64(6917762))
+	c.Assert(msg.Result.Transactions, HasLen, 1)
+	txm := msg.Result.Transactions[0]
+	c.Assert(txm.LedgerSequence, Equals, uint32(6917762))
+	c.Assert(txm.Validated, Equals, true)
+	c.Assert(txm.GetHash().String(), Equals, "2D0CE11154B655A2BFE7F3F857AAC344622EC7DAB11B1EBD920DCDB00E8646FF")
+	c.Assert(txm.MetaData.AffectedNodes, HasLen, 4)
+	c.Assert(txm.MetaData.TransactionResult.String(), Equals, "tesSUCCESS")
+	offer := txm.Transaction.(*data.OfferCreate)
+	c.Assert(offer.Account.String(), Equals, "rwpxNWdpKu2QVgrh5LQXEygYLshhgnRL1Y")
+	c.Assert(offer.Sequence, Equals, uint32(1681497))
+}
+
+func (s *MessagesSuite) TestAccountTxOptions(c *C) {
+	account, err := data.NewAccountFromAddress("rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B")
+	c.Assert(err, IsNil)
+	cmd := newAccountTxCommand(*account, nil, -1, -1, []AccountTxOption{AccountTxLimit(10), AccountTxForward(), AccountTxBinary()})
+	c.Assert(cmd.Limit, Equals, 10)
+	c.Assert(cmd.Forward, Equals, true)
+	c.Assert(cmd.Binary, Equals, true)
+}
+
+func (s *MessagesSuite) TestBookOffersResponse(c *C) {
+	msg := &BookOffersCommand{}
+	readResponseFile(c, msg, "testdata/book_offers.json")
+
+	// Response fields
+	c.Assert(msg.Status, Equals, "success")
+	c.Assert(msg.Type, Equals, "response")
+
+	c.Assert(msg.Result.LedgerSequence, Equals, uint32(7636500))
+	c.Assert(msg.Result.Offers, HasLen, 2)
+	first, second := msg.Result.Offers[0], msg.Result.Offers[1]
+	c.Assert(first.TakerPays.String(), Equals, "10000/XRP")
+	c.Assert(first.TakerGets.String(), Equals, "68.33244565086453/USD/rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B")
+	c.Assert(first.OwnerFunds.String(), Equals, "250.5")
+	c.Assert(first.Quality.String(), Equals, "146343443.4128896")
+	c.Assert(first.Quality.Less(second.Quality.Value), Equals, true)
+	c.Assert(*second.Sequence, Equals, uint32(42))
+}
+
+func (s *MessagesSuite) TestServerInfoResponse(c *C) {
+	msg := &ServerInfoCommand{}
+	readResponseFile(c, msg, "testdata/server_info.json")
+
+	// Response fields
+	c.Assert(msg.Status, Equals, "success")
+	c.Assert(msg.Type, Equals, "response")
+
+	// Result fields
+	info := msg.Result.Info
+	c.Assert(info.CompleteLedgers, Equals, "32570-75443896")
+	c.Assert(info.ServerState, Equals, "full")
+	c.Assert(info.LoadFactor, Equals, 1.5)
+	c.Assert(info.Peers, Equals, uint32(21))
+	c.Assert(info.ValidatedLedger, NotNil)
+	c.Assert(info.ValidatedLedger.Seq, Equals, uint32(75443896))
+	c.Assert(info.ValidatedLedger.Hash.String(), Equals, "B4EF7E6B485E0C926A8DA7357B5B4657F0915DC5A6CDD52DB8101DEE2B37B0E4")
+	c.Assert(info.ValidatedLedger.ReserveBaseXRP, Equals, 10.0)
+	base, inc := msg.Result.Reserves()
+	c.Assert(base, Equals, uint64(10000000))
+	c.Assert(inc, Equals, uint64(2000000))
+}
+
+func (s *MessagesSuite) TestServerStateResponse(c *C) {
+	msg := &ServerStateCommand{}
+	readResponseFile(c, msg, "testdata/server_state.json")
+
+	// Response fields
+	c.Assert(msg.Status, Equals, "success")
+	c.Assert(msg.Type, Equals, "response")
+
+	// Result fields
+	state := msg.Result.State
+	c.Assert(state.CompleteLedgers, Equals, "32570-75443896")
+	c.Assert(state.ServerState, Equals, "full")
+	c.Assert(state.LoadFactor, Equals, uint64(512))
+	c.Assert(state.ValidatedLedger, NotNil)
+	c.Assert(state.ValidatedLedger.ReserveBase, Equals, uint64(10000000))
+	c.Assert(msg.Result.TransactionCost(), Equals, uint64(20))
+}
+
+func (s *MessagesSuite) TestFeatureResponse(c *C) {
+	msg := &FeatureCommand{}
+	readResponseFile(c, msg, "testdata/feature.json")
+
+	// Response fields
+	c.Assert(msg.Status, Equals, "success")
+	c.Assert(msg.Type, Equals, "response")
+
+	// Result fields
+	c.Assert(msg.Result.Features, HasLen, 3)
+	multiSign := data.NewFeature("MultiSign")
+	c.Assert(msg.Result.Features[multiSign.Id], Equals, FeatureStatus{Enabled: true, Name: "MultiSign", Supported: true})
+	vetoed := data.NewFeature("fixInnerObjTemplate")
+	c.Assert(msg.Result.Features[vetoed.Id].Vetoed, Equals, true)
+	for id, status := range msg.Result.Features {
+		c.Assert(data.LookupFeature(id).Name, Equals, status.Name)
+	}
+
+	msg = &FeatureCommand{}
+	readResponseFile(c, msg, "testdata/feature_name.json")
+	c.Assert(msg.Result.Features, HasLen, 1)
+	c.Assert(msg.Result.Features[multiSign.Id].Name, Equals, "MultiSign")
+}
+
+func (s *MessagesSuite) TestLedgerAcceptResponse(c *C) {
+	msg := &LedgerAcceptCommand{}
+	readResponseFile(c, msg, "testdata/ledger_accept.json")
+
+	// Response fields
+	c.Assert(msg.Status, Equals, "success")
+	c.Assert(msg.Type, Equals, "response")
+
+	// Result fields
+	c.Assert(msg.Result.LedgerCurrentIndex, Equals, uint32(6))
+}
+
+func (s *MessagesSuite) TestWalletProposeRequest(c *C) {
+	cmd := &WalletProposeCommand{
+		Command: newCommand("wallet_propose"),
+		KeyType: "ed25519",
+	}
+	b, err := json.Marshal(cmd)
+	c.Assert(err, IsNil)
+	c.Assert(string(b), Matches, `.*"command":"wallet_propose","key_type":"ed25519"\}`)
+}
+
+func (s *MessagesSuite) TestWalletProposeResponse(c *C) {
+	msg := &WalletProposeCommand{}
+	readResponseFile(c, msg, "testdata/wallet_propose.json")
+
+	// Response fields
+	c.Assert(msg.Status, Equals, "success")
+	c.Assert(msg.Type, Equals, "response")
+
+	// Result fields
+	seed, err := hex.DecodeString(msg.Result.MasterSeedHex)
+	c.Assert(err, IsNil)
+	wallet, err := crypto.NewWallet(msg.Result.KeyType, seed)
+	c.Assert(err, IsNil)
+	c.Assert(msg.Result, DeepEquals, wallet)
+}
+
+func (s *MessagesSuite) TestFeeResponse(c *C) {
+	msg := &FeeCommand{}
+	readResponseFile(c, msg, "testdata/fee.json")
+
+	// Response fields
+	c.Assert(msg.Status, Equals, "success")
+	c.Assert(msg.Type, Equals, "response")
+
+	// Result fields
+	c.Assert(msg.Result.ExpectedLedgerSize, Equals, uint32(24))
+	c.Assert(msg.Result.Drops.BaseFee.String(), Equals, "0.00001")
+	c.Assert(msg.Result.Levels.OpenLedgerLevel.String(), Equals, "0.0003")
+
+	for urgency, expected := range []string{"0.00001", "0.000012", "0.005", "0.01", "0.015"} {
+		fee, err := msg.Result.SuggestedFee(urgency)
+		c.Assert(err, IsNil)
+		c.Assert(fee.String(), Equals, expected)
+	}
+}
+
+func (s *MessagesSuite) TestLedgerHashesResponse(c *C) {
+	msg := &LedgerCommand{}
+	readResponseFile(c, msg, "testdata/ledger_hashes.json")
+
+	// Response fields
+	c.Assert(msg.Status, Equals, "success")
+	c.Assert(msg.Type, Equals, "response")
+
+	// Result fields
+	c.Assert(msg.Result.Validated, Equals, true)
+	c.Assert(msg.Result.Ledger.LedgerSequence, Equals, uint32(6917762))
+	c.Assert(msg.Result.Ledger.Transactions, HasLen, 0)
+	c.Assert(msg.Result.TransactionHashes, HasLen, 2)
+	c.Assert(msg.Result.TransactionHashes[0].String(), Equals, "2D0CE11154B655A2BFE7F3F857AAC344622EC7DAB11B1EBD920DCDB00E8646FF")
+	c.Assert(msg.Result.AccountStateHashes, HasLen, 0)
+}
+
+func (s *MessagesSuite) TestPathFindStreamMsg(c *C) {
+	msg := &PathFindCreateResult{}
+	readResponseFile(c, msg, "testdata/path_find_stream.json")
+
+	c.Assert(msg.FullReply, Equals, true)
+	c.Assert(msg.DestinationAmount.String(), Equals, "0.001/USD/rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B")
+	c.Assert(msg.Alternatives, HasLen, 2)
+	c.Assert(msg.Alternatives[0].SourceAmount.String(), Equals, "0.251686/XRP")
+	c.Assert(msg.Alternatives[1].SourceAmount.String(), Equals, "0.001002/USD/r9cZA1mLK5R5Am25ArfXFmqgNwjZgnfk59")
+
+	paths := msg.PathSets()
+	c.Assert(paths, HasLen, 2)
+	c.Assert(paths[0], HasLen, 1)
+	c.Assert(paths[0][0], HasLen, 2)
+	c.Assert(paths[1][0][0].Account.String(), Equals, "rMwjYedjc7qqtKYVLiAccJSmCwih4LnE2q")
+}
+
+func (s *MessagesSuite) TestRipplePathFindRequest(c *C) {
+	src, err := data.NewAccountFromAddress("r9cZA1mLK5R5Am25ArfXFmqgNwjZgnfk59")
+	c.Assert(err, IsNil)
+	amount, err := data.NewAmount("1/USD/rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B")
+	c.Assert(err, IsNil)
+	cmd := &RipplePathFindCommand{
+		Command:       &Command{Name: "ripple_path_find"},
+		SrcAccount:    *src,
+		SrcCurrencies: []SourceCurrency{{Currency: amount.Currency.Machine()}},
+		DestAccount:   *src,
+		DestAmount:    *amount,
+	}
+	b, err := json.Marshal(cmd)
+	c.Assert(err, IsNil)
+	c.Assert(string(b), Matches, `.*"source_currencies":\[\{"currency":"USD"\}\].*`)
+}
+
+func (s *MessagesSuite) TestAccountInfoErrorResponse(c *C) {
+	msg := &AccountInfoCommand{}
+	readResponseFile(c, msg, "testdata/account_info_error.json")
+
+	// Response fields
+	c.Assert(msg.Status, Equals, "error")
+	c.Assert(msg.Type, Equals, "response")
+
+	c.Assert(msg.Result, IsNil)
+	c.Assert(msg.CommandError, NotNil)
+	c.Assert(msg.CommandError.Name, Equals, "actNotFound")
+	c.Assert(msg.CommandError.Code, Equals, 19)
+	c.Assert(msg.CommandError.Message, Equals, "Account not found.")
+}
+
+func (s *MessagesSuite) TestAccountChannelsResponse(c *C) {
+	msg := &AccountChannelsCommand{}
+	readResponseFile(c, msg, "testdata/account_channels.json")
+
+	// Response fields
+	c.Assert(msg.Status, Equals, "success")
+	c.Assert(msg.Type, Equals, "response")
+
+	// Result fields
+	c.Assert(*msg.Result.LedgerSequence, Equals, uint32(71766343))
+	c.Assert(msg.Result.Validated, Equals, true)
+	c.Assert(msg.Result.Account.String(), Equals, "rN7n7otQDd6FczFgLdSqtcsAUxDkw6fzRH")
+	c.Assert(msg.Result.Marker, Equals, "C7F634794B79DB40E87179A9D1BF05D05797AE7E92DF8E93FD6656E8C4BE3AE8,0")
+	c.Assert(msg.Result.Channels, HasLen, 2)
+
+	open := msg.Result.Channels[0]
+	c.Assert(open.ChannelID.String(), Equals, "C7F634794B79DB40E87179A9D1BF05D05797AE7E92DF8E93FD6656E8C4BE3AE7")
+	c.Assert(open.DestinationAccount.String(), Equals, "ra5nK24KXen9AHvsdFTKHSANinZseWnPcX")
+	c.Assert(open.Amount.String(), Equals, "0.001")
+	c.Assert(open.SettleDelay, Equals, uint32(60))
+	c.Assert(open.PublicKey.String(), Equals, "03CFD18E689434F032A4E84C63E2A3A6472D684EAF4FD52CA67742F3E24BAE81B2")
+	c.Assert(open.Expiration, IsNil)
+	c.Assert(open.DestinationTag, IsNil)
+
+	closing := msg.Result.Channels[1]
+	remaining, err := closing.Remaining()
+	c.Assert(err, IsNil)
+	c.Assert(remaining.String(), Equals, "7.5")
+	c.Assert(closing.Expiration.Uint32(), Equals, uint32(547073182))
+	c.Assert(closing.CancelAfter.Uint32(), Equals, uint32(662077297))
+	c.Assert(*closing.SourceTag, Equals, uint32(1))
+	c.Assert(*closing.DestinationTag, Equals, uint32(20170428))
+}
+
+func (s *MessagesSuite) TestAccountChannelsRequest(c *C) {
+	account, err := data.NewAccountFromAddress("rN7n7otQDd6FczFgLdSqtcsAUxDkw6fzRH")
+	c.Assert(err, IsNil)
+	dst, err := data.NewAccountFromAddress("rf1BiGeXwwQoi8Z2ueFYTEXSwuJYfV2Jpn")
+	c.Assert(err, IsNil)
+	cmd := &AccountChannelsCommand{
+		Command:            &Command{Name: "account_channels"},
+		Account:            *account,
+		DestinationAccount: dst,
+		Marker:             "C7F634794B79DB40E87179A9D1BF05D05797AE7E92DF8E93FD6656E8C4BE3AE8,0",
+	}
+	b, err := json.Marshal(cmd)
+	c.Assert(err, IsNil)
+	c.Assert(string(b), Matches, `.*"account":"rN7n7otQDd6FczFgLdSqtcsAUxDkw6fzRH","destination_account":"rf1BiGeXwwQoi8Z2ueFYTEXSwuJYfV2Jpn","marker":"C7F634794B79DB40E87179A9D1BF05D05797AE7E92DF8E93FD6656E8C4BE3AE8,0".*`)
+
+	cmd = &AccountChannelsCommand{
+		Command: &Command{Name: "account_channels"},
+		Account: *account,
+	}
+	b, err = json.Marshal(cmd)
+	c.Assert(err, IsNil)
+	c.Assert(string(b), Not(Matches), `.*"(destination_account|marker)".*`)
+}
+
+func (s *MessagesSuite) TestNFTOffersResponse(c *C) {
+	msg := &NFTOffersCommand{}
+	readResponseFile(c, msg, "testdata/nft_sell_offers.json")
+
+	// Response fields
+	c.Assert(msg.Status, Equals, "success")
+	c.Assert(msg.Type, Equals, "response")
+
+	// Result fields
+	c.Assert(*msg.Result.LedgerSequence, Equals, uint32(75096517))
+	c.Assert(msg.Result.NFTokenID.String(), Equals, "00090000D0B007439B080E9B05BF62403911301A7B1F0CFAA048C0A200000007")
+	c.Assert(msg.Result.Marker, Equals, "F9C4858A4A0A1AE0F3F972DFB1BB258D6CA8AB8AC8C2B54AA8E1B0D8580E8401")
+	c.Assert(msg.Result.Offers, HasLen, 2)
+
+	xrp := msg.Result.Offers[0]
+	c.Assert(xrp.Index.String(), Equals, "9E28E366573187F8E5B85CE301F229E061A619EE5A589EF740088F8843BF10A1")
+	c.Assert(xrp.Owner.String(), Equals, "rLpSRZ1E8JHyNDZeHYsQs1R5cwDCB3uuZt")
+	c.Assert(xrp.Amount.String(), Equals, "0.001/XRP")
+	c.Assert(xrp.Flags&data.LsSellNFToken, Equals, data.LsSellNFToken)
+	c.Assert(xrp.Destination, IsNil)
+	c.Assert(xrp.Expiration, IsNil)
+
+	usd := msg.Result.Offers[1]
+	c.Assert(usd.Amount.String(), Equals, "25/USD/rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B")
+	c.Assert(usd.Destination.String(), Equals, "rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh")
+	c.Assert(usd.Expiration.Uint32(), Equals, uint32(747073182))
+}
+
+func (s *MessagesSuite) TestNFTOffersRequest(c *C) {
+	id, err := data.NewHash256("00090000D0B007439B080E9B05BF62403911301A7B1F0CFAA048C0A200000007")
+	c.Assert(err, IsNil)
+	cmd := &NFTOffersCommand{
+		Command:   newCommand("nft_buy_offers"),
+		NFTokenID: *id,
+		Marker:    "F9C4858A4A0A1AE0F3F972DFB1BB258D6CA8AB8AC8C2B54AA8E1B0D8580E8401",
+	}
+	b, err := json.Marshal(cmd)
+	c.Assert(err, IsNil)
+	c.Assert(string(b), Matches, `.*"command":"nft_buy_offers".*"nft_id":"00090000D0B007439B080E9B05BF62403911301A7B1F0CFAA048C0A200000007","marker":"F9C4858A4A0A1AE0F3F972DFB1BB258D6CA8AB8AC8C2B54AA8E1B0D8580E8401".*`)
+}
+
+func (s *MessagesSuite) TestNFTInfoResponse(c *C) {
+	msg := &NFTInfoCommand{}
+	readResponseFile(c, msg, "testdata/nft_info.json")
+
+	// Response fields
+	c.Assert(msg.Status, Equals, "success")
+	c.Assert(msg.Type, Equals, "response")
+
+	// Result fields
+	c.Assert(msg.Result.LedgerSequence, Equals, uint32(270))
+	c.Assert(msg.Result.Validated, Equals, true)
+	c.Assert(msg.Result.NFTokenID.String(), Equals, "00080000B4F4AFC5FBCBD76873F18006173D2193467D3EE70000099B00000000")
+	c.Assert(msg.Result.Owner.String(), Equals, "rG9gdNygQ6npA9JvDFWBoeXbiUcTYJnEnk")
+	c.Assert(msg.Result.Issuer.String(), Equals, "rHVokeuSnjPjz718qdb47bGXBBHNMP3KDQ")
+	c.Assert(msg.Result.IsBurned, Equals, false)
+	c.Assert(msg.Result.Flags, Equals, uint16(8))
+	c.Assert(string(msg.Result.URI), Equals, "https://xrpl.org")
+}
+
+func (s *MessagesSuite) TestChannelAuthorizeResponse(c *C) {
+	msg := &ChannelAuthorizeCommand{}
+	readResponseFile(c, msg, "testdata/channel_authorize.json")
+
+	// Response fields
+	c.Assert(msg.Status, Equals, "success")
+	c.Assert(msg.Type, Equals, "response")
+
+	// Result fields
+	channel, err := data.NewHash256("5DB01B7FFED6B67E6B0414DED11E051D2EE2B7619CE0EAA6286D67A3A4D5BDB3")
+	c.Assert(err, IsNil)
+	amount, err := data.NewNativeValue(1000000)
+	c.Assert(err, IsNil)
+	var publicKey data.PublicKey
+	c.Assert(publicKey.UnmarshalText([]byte("0330E7FC9D56BB25D6893BA3F317AE5BCF33B3291BD63DB32654A313222F7FD020")), IsNil)
+	ok, err := data.CheckClaimSignature(*channel, *amount, publicKey, msg.Result.Signature)
+	c.Assert(err, IsNil)
+	c.Assert(ok, Equals, true)
+}
+
+func (s *MessagesSuite) TestChannelVerifyResponse(c *C) {
+	msg := &ChannelVerifyCommand{}
+	readResponseFile(c, msg, "testdata/channel_verify.json")
+
+	// Response fields
+	c.Assert(msg.Status, Equals, "success")
+	c.Assert(msg.Type, Equals, "response")
+
+	// Result fields
+	c.Assert(msg.Result.SignatureVerified, Equals, true)
+}
+
+func (s *MessagesSuite) TestChannelVerifyRequest(c *C) {
+	channel, err := data.NewHash256("5DB01B7FFED6B67E6B0414DED11E051D2EE2B7619CE0EAA6286D67A3A4D5BDB3")
+	c.Assert(err, IsNil)
+	amount, err := data.NewNativeValue(1000000)
+	c.Assert(err, IsNil)
+	cmd := &ChannelVerifyCommand{
+		Command:   &Command{Name: "channel_verify"},
+		ChannelID: *channel,
+		Amount:    amount,
+	}
+	b, err := json.Marshal(cmd)
+	c.Assert(err, IsNil)
+	c.Assert(string(b), Matches, `.*"channel_id":"5DB01B7FFED6B67E6B0414DED11E051D2EE2B7619CE0EAA6286D67A3A4D5BDB3","amount":"1000000".*`)
+}
+
+func (s *MessagesSuite) TestGatewayBalancesResponse(c *C) {
+	msg := &GatewayBalancesCommand{}
+	readResponseFile(c, msg, "testdata/gateway_balances.json")
+
+	// Response fields
+	c.Assert(msg.Status, Equals, "success")
+	c.Assert(msg.Type, Equals, "response")
+
+	// Result fields
+	c.Assert(*msg.Result.LedgerSequence, Equals, uint32(14483212))
+	c.Assert(msg.Result.Validated, Equals, true)
+	c.Assert(msg.Result.Account.String(), Equals, "rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh")
+
+	usd, err := data.NewCurrency("USD")
+	c.Assert(err, IsNil)
+	eur, err := data.NewCurrency("EUR")
+	c.Assert(err, IsNil)
+	btc, err := data.NewCurrency("BTC")
+	c.Assert(err, IsNil)
+
+	c.Assert(msg.Result.Obligations, HasLen, 3)
+	c.Assert(msg.Result.Obligations[eur].String(), Equals, "992471.7419793958")
+	c.Assert(msg.Result.Obligations[usd].IsNative(), Equals, false)
+
+	hot, err := data.NewAccountFromAddress("rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf")
+	c.Assert(err, IsNil)
+	other, err := data.NewAccountFromAddress("rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B")
+	c.Assert(err, IsNil)
+	c.Assert(msg.Result.Balances, HasLen, 2)
+	c.Assert(msg.Result.Balances[*hot], HasLen, 2)
+	c.Assert(msg.Result.Balances[*hot][eur].String(), Equals, "29826.1965999999")
+	c.Assert(msg.Result.Balances[*other][usd].String(), Equals, "0.1")
+	c.Assert(msg.Result.FrozenBalances[*other][usd].String(), Equals, "0.1")
+
+	// Values can be summed without losing precision
+	sum, err := msg.Result.Balances[*hot][usd].Add(msg.Result.Obligations[usd])
+	c.Assert(err, IsNil)
+	c.Assert(sum.String(), Equals, "26203.60416")
+
+	asset, err := data.NewAccountFromAddress("rNDKeo9RrCiRdfsMG8AdoZvNZxHASGzbZL")
+	c.Assert(err, IsNil)
+	c.Assert(msg.Result.Assets[*asset][btc].String(), Equals, "544416651e-19")
+}
+
+func (s *MessagesSuite) TestGatewayBalancesRequest(c *C) {
+	account, err := data.NewAccountFromAddress("rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh")
+	c.Assert(err, IsNil)
+	hot, err := data.NewAccountFromAddress("rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf")
+	c.Assert(err, IsNil)
+	cmd := &GatewayBalancesCommand{
+		Command:   &Command{Name: "gateway_balances"},
+		Account:   *account,
+		HotWallet: []data.Account{*hot},
+	}
+	b, err := json.Marshal(cmd)
+	c.Assert(err, IsNil)
+	c.Assert(string(b), Matches, `.*"account":"rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh","hotwallet":\["rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf"\].*`)
+}
+
+func (s *MessagesSuite) TestAccountObjectsResponse(c *C) {
+	msg := &AccountObjectsCommand{}
+	readResponseFile(c, msg, "testdata/account_objects.json")
+
+	// Response fields
+	c.Assert(msg.Status, Equals, "success")
+	c.Assert(msg.Type, Equals, "response")
+
+	// Result fields
+	c.Assert(*msg.Result.LedgerSequence, Equals, uint32(14380381))
+	c.Assert(msg.Result.Validated, Equals, true)
+	c.Assert(msg.Result.Limit, Equals, 5)
+	c.Assert(msg.Result.Account.String(), Equals, "rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh")
+	c.Assert(msg.Result.Marker, Equals, "F60ADF645E78B69857D2E4AEC8B7742FEABC8431BD8611D099B428C3E816DF93,94A9F05FEF9A153229E2E997E64919FD75AAE2028C8153E8EBDB4440BD3ECBB5")
+	c.Assert(msg.Result.AccountObjects, HasLen, 5)
+
+	offer, ok := msg.Result.AccountObjects[0].(*data.Offer)
+	c.Assert(ok, Equals, true)
+	c.Assert(*offer.Sequence, Equals, uint32(3))
+	c.Assert(offer.TakerPays.String(), Equals, "1/BTC/rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B")
+
+	line, ok := msg.Result.AccountObjects[1].(*data.RippleState)
+	c.Assert(ok, Equals, true)
+	c.Assert(line.Balance.String(), Equals, "-5/USD/rrrrrrrrrrrrrrrrrrrrBZbvji")
+
+	escrow, ok := msg.Result.AccountObjects[2].(*data.Escrow)
+	c.Assert(ok, Equals, true)
+	c.Assert(escrow.Destination.String(), Equals, "rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf")
+	c.Assert(escrow.FinishAfter.Uint32(), Equals, uint32(545354132))
+
+	check, ok := msg.Result.AccountObjects[3].(*data.Check)
+	c.Assert(ok, Equals, true)
+	c.Assert(check.SendMax.String(), Equals, "100/XRP")
+	c.Assert(check.GetLedgerIndex().String(), Equals, "49647F0D748DC3FE26BDACBC57F251AADEFFF391403EC9BF87C97F67E9977FB0")
+
+	signers, ok := msg.Result.AccountObjects[4].(*data.SignerList)
+	c.Assert(ok, Equals, true)
+	c.Assert(*signers.SignerQuorum, Equals, uint32(3))
+	c.Assert(signers.SignerEntries, HasLen, 2)
+	c.Assert(*signers.SignerEntries[0].SignerEntry.SignerWeight, Equals, uint16(2))
+}
+
+func (s *MessagesSuite) TestAccountObjectsRequest(c *C) {
+	account, err := data.NewAccountFromAddress("rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh")
+	c.Assert(err, IsNil)
+	cmd := &AccountObjectsCommand{
+		Command: &Command{Name: "account_objects"},
+		Account: *account,
+		Type:    "escrow",
+		Marker:  "F60ADF645E78B69857D2E4AEC8B7742FEABC8431BD8611D099B428C3E816DF93,0",
+		Limit:   10,
+	}
+	b, err := json.Marshal(cmd)
+	c.Assert(err, IsNil)
+	c.Assert(string(b), Matches, `.*"account":"rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh","type":"escrow","marker":"F60ADF645E78B69857D2E4AEC8B7742FEABC8431BD8611D099B428C3E816DF93,0","limit":10.*`)
+
+	cmd = &AccountObjectsCommand{
+		Command: &Command{Name: "account_objects"},
+		Account: *account,
+	}
+	b, err = json.Marshal(cmd)
+	c.Assert(err, IsNil)
+	c.Assert(string(b), Not(Matches), `.*"(type|marker|limit)".*`)
+}
+
+func (s *MessagesSuite) TestAccountCurrenciesResponse(c *C) {
+	msg := &AccountCurrenciesCommand{}
+	readResponseFile(c, msg, "testdata/account_currencies.json")
+
+	// Response fields
+	c.Assert(msg.Status, Equals, "success")
+	c.Assert(msg.Type, Equals, "response")
+
+	// Result fields
+	c.Assert(*msg.Result.LedgerSequence, Equals, uint32(11775844))
+	c.Assert(msg.Result.Validated, Equals, true)
+	c.Assert(msg.Result.ReceiveCurrencies, HasLen, 5)
+	c.Assert(msg.Result.ReceiveCurrencies[0].String(), Equals, "BTC")
+	c.Assert(msg.Result.ReceiveCurrencies[2].Type(), Equals, data.CT_DEMURRAGE)
+	c.Assert(msg.Result.ReceiveCurrencies[2].Machine(), Equals, "015841551A748AD2C1F76FF6ECB0CCCD00000000")
+	c.Assert(msg.Result.SendCurrencies, HasLen, 4)
+	c.Assert(msg.Result.SendCurrencies[2].IsHex(), Equals, true)
+	c.Assert(msg.Result.SendCurrencies[2].Machine(), Equals, "815841551A748AD2C1F76FF6ECB0CCCD00000000")
+	c.Assert(msg.Result.SendCurrencies[3].String(), Equals, "USD")
+}
+
+func (s *MessagesSuite) TestNoRippleCheckResponse(c *C) {
+	msg := &NoRippleCheckCommand{}
+	readResponseFile(c, msg, "testdata/noripple_check.json")
+
+	// Response fields
+	c.Assert(msg.Status, Equals, "success")
+	c.Assert(msg.Type, Equals, "response")
+
+	// Result fields
+	c.Assert(msg.Result.LedgerSequence, Equals, uint32(14380381))
+	c.Assert(msg.Result.Validated, Equals, false)
+	c.Assert(msg.Result.Problems, HasLen, 2)
+	c.Assert(msg.Result.Problems[0], Equals, "You should immediately set your default ripple flag")
+	c.Assert(msg.Result.Transactions, HasLen, 2)
+
+	accountSet, ok := msg.Result.Transactions[0].(*data.AccountSet)
+	c.Assert(ok, Equals, true)
+	c.Assert(accountSet.Account.String(), Equals, "rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh")
+	c.Assert(accountSet.Fee.String(), Equals, "0.01")
+	c.Assert(accountSet.Sequence, Equals, uint32(1406))
+	c.Assert(*accountSet.SetFlag, Equals, uint32(8))
+
+	trustSet, ok := msg.Result.Transactions[1].(*data.TrustSet)
+	c.Assert(ok, Equals, true)
+	c.Assert(trustSet.Sequence, Equals, uint32(1407))
+	c.Assert(*trustSet.Flags, Equals, data.TxClearNoRipple)
+	c.Assert(trustSet.LimitAmount.String(), Equals, "5/USD/rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf")
+}
+
+func (s *MessagesSuite) TestNoRippleCheckRequest(c *C) {
+	account, err := data.NewAccountFromAddress("rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh")
+	c.Assert(err, IsNil)
+	cmd := &NoRippleCheckCommand{
+		Command:      &Command{Name: "noripple_check"},
+		Account:      *account,
+		Role:         "gateway",
+		Transactions: true,
+		Limit:        2,
+	}
+	b, err := json.Marshal(cmd)
+	c.Assert(err, IsNil)
+	c.Assert(string(b), Matches, `.*"account":"rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh","role":"gateway","transactions":true,"limit":2.*`)
+}
+
+func (s *MessagesSuite) TestDepositAuthorizedResponse(c *C) {
+	msg := &DepositAuthorizedCommand{}
+	readResponseFile(c, msg, "testdata/deposit_authorized.json")
+
+	// Response fields
+	c.Assert(msg.Status, Equals, "success")
+	c.Assert(msg.Type, Equals, "response")
+
+	// Result fields
+	c.Assert(msg.Result.DepositAuthorized, Equals, true)
+	c.Assert(msg.Result.Validated, Equals, true)
+	c.Assert(*msg.Result.LedgerIndex, Equals, uint32(8))
+	c.Assert(msg.Result.LedgerCurrentIndex, IsNil)
+	c.Assert(msg.Result.LedgerHash.String(), Equals, "BD03A10653ED9D77DCA859B7A735BF0580088A8F287FA2C5403E0A19C58EF322")
+	c.Assert(msg.Result.SourceAccount.String(), Equals, "rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh")
+	c.Assert(msg.Result.DestinationAccount.String(), Equals, "rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf")
+}
+
+func (s *MessagesSuite) TestDepositAuthorizedRequest(c *C) {
+	src, err := data.NewAccountFromAddress("rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh")
+	c.Assert(err, IsNil)
+	dst, err := data.NewAccountFromAddress("rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf")
+	c.Assert(err, IsNil)
+	cmd := &DepositAuthorizedCommand{
+		Command:            &Command{Name: "deposit_authorized"},
+		SourceAccount:      *src,
+		DestinationAccount: *dst,
+		LedgerIndex:        "validated",
+	}
+	b, err := json.Marshal(cmd)
+	c.Assert(err, IsNil)
+	c.Assert(string(b), Matches, `.*"source_account":"rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh","destination_account":"rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf","ledger_index":"validated".*`)
+}
+
+func (s *MessagesSuite) TestLedgerEntryResponse(c *C) {
+	msg := &LedgerEntryCommand{}
+	readResponseFile(c, msg, "testdata/ledger_entry.json")
+
+	// Response fields
+	c.Assert(msg.Status, Equals, "success")
+	c.Assert(msg.Type, Equals, "response")
+
+	// Result fields
+	c.Assert(*msg.Result.LedgerIndex, Equals, uint32(8))
+	c.Assert(msg.Result.LedgerCurrentIndex, IsNil)
+	c.Assert(msg.Result.Validated, Equals, true)
+	c.Assert(msg.Result.Index.String(), Equals, "5A137E78A5472F9283482B507A0C3B617A140BD9A572499A3E2C3EC16991EEF9")
+
+	state, ok := msg.Result.Node.(*data.RippleState)
+	c.Assert(ok, Equals, true)
+	c.Assert(state.Balance.String(), Equals, "42.5/USD/rrrrrrrrrrrrrrrrrrrrBZbvji")
+	c.Assert(state.LowLimit.Issuer.String(), Equals, "rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf")
+
+	// The index of the node matches its contents
+	index, err := data.LedgerIndex(msg.Result.Node)
+	c.Assert(err, IsNil)
+	c.Assert(*index, Equals, msg.Result.Index)
+}
+
+func (s *MessagesSuite) TestLedgerEntryResponseUnknownType(c *C) {
+	for _, node := range []string{`{}`, `null`, `{"LedgerEntryType":"Bogus"}`} {
+		var result LedgerEntryResult
+		err := json.Unmarshal([]byte(`{"index":"0000000000000000000000000000000000000000000000000000000000000000","node":`+node+`}`), &result)
+		c.Check(err, NotNil, Commentf("%s", node))
+		c.Check(result.Node, IsNil)
+	}
+}
+
+func (s *MessagesSuite) TestLedgerEntryRequest(c *C) {
+	a, err := data.NewAccountFromAddress("rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh")
+	c.Assert(err, IsNil)
+	b, err := data.NewAccountFromAddress("rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf")
+	c.Assert(err, IsNil)
+	usd, err := data.NewCurrency("USD")
+	c.Assert(err, IsNil)
+	index, err := data.NewHash256("5A137E78A5472F9283482B507A0C3B617A140BD9A572499A3E2C3EC16991EEF9")
+	c.Assert(err, IsNil)
+
+	for _, test := range []struct {
+		selector LedgerEntrySelector
+		expected string
+	}{
+		{LedgerEntryIndex(*index), `"index":"5A137E78A5472F9283482B507A0C3B617A140BD9A572499A3E2C3EC16991EEF9"`},
+		{LedgerEntryAccountRoot(*a), `"account_root":"rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh"`},
+		{LedgerEntryOffer(*a, 5), `"offer":{"account":"rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh","seq":5}`},
+		{LedgerEntryRippleState(*a, *b, usd), `"ripple_state":{"accounts":\["rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh","rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf"\],"currency":"USD"}`},
+		{LedgerEntryOwnerDirectory(*a, 0), `"directory":{"owner":"rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh"}`},
+		{LedgerEntryDirectory(*index, 1), `"directory":{"dir_root":"5A137E78A5472F9283482B507A0C3B617A140BD9A572499A3E2C3EC16991EEF9","sub_index":1}`},
+		{LedgerEntryEscrow(*a, 7), `"escrow":{"owner":"rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh","seq":7}`},
+		{LedgerEntryDepositPreauth(*a, *b), `"deposit_preauth":{"owner":"rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh","authorized":"rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf"}`},
+		{LedgerEntryTicket(*a, 9), `"ticket":{"account":"rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh","ticket_seq":9}`},
+		{LedgerEntrySignerList(*a), `"index":"778365D5180F5DF3016817D1F318527AD7410D83F8636CF48C43E8AF72AB49BF"`},
+	} {
+		cmd := &LedgerEntryCommand{
+			Command:     &Command{Name: "ledger_entry"},
+			LedgerIndex: "validated",
+		}
+		test.selector(cmd)
+		raw, err := json.Marshal(cmd)
+		c.Assert(err, IsNil)
+		c.Check(string(raw), Matches, `.*"ledger_index":"validated",`+test.expected+`.*`)
+	}
+}
+
+func (s *MessagesSuite) TestSubmitResponse(c *C) {
+	msg := &SubmitCommand{}
+	readResponseFile(c, msg, "testdata/submit.json")
+
+	// Response fields
+	c.Assert(msg.Status, Equals, "success")
+	c.Assert(msg.Type, Equals, "response")
+
+	// Result fields
+	c.Assert(msg.Result.EngineResult.String(), Equals, "tecPATH_DRY")
+	c.Assert(msg.Result.EngineResult.Claimed(), Equals, true)
+	c.Assert(msg.Result.EngineResultCode, Equals, 128)
+	c.Assert(msg.Result.Accepted, Equals, true)
+	c.Assert(msg.Result.Applied, Equals, false)
+	c.Assert(msg.Result.Broadcast, Equals, false)
+	c.Assert(msg.Result.Kept, Equals, true)
+	c.Assert(msg.Result.Queued, Equals, false)
+}
+
+func (s *MessagesSuite) TestSubmitRequest(c *C) {
+	cmd := &SubmitCommand{Command: &Command{Name: "submit"}, TxBlob: "1200"}
+	b, err := json.Marshal(cmd)
+	c.Assert(err, IsNil)
+	c.Assert(string(b), Not(Matches), `.*"fail_hard".*`)
+	SubmitFailHard()(cmd)
+	b, err = json.Marshal(cmd)
+	c.Assert(err, IsNil)
+	c.Assert(string(b), Matches, `.*"tx_blob":"1200","fail_hard":true.*`)
+}
+
+func (s *MessagesSuite) TestSimulateResponse(c *C) {
+	msg := &SimulateCommand{}
+	readResponseFile(c, msg, "testdata/simulate.json")
+
+	// Response fields
+	c.Assert(msg.Status, Equals, "success")
+	c.Assert(msg.Type, Equals, "response")
+
+	// Result fields
+	c.Assert(msg.Result.EngineResult.String(), Equals, "tecPATH_DRY")
+	c.Assert(msg.Result.Applied, Equals, false)
+	c.Assert(msg.Result.LedgerSequence, Equals, uint32(3))
+	c.Assert(msg.Result.MetaData, NotNil)
+	c.Assert(msg.Result.MetaData.TransactionResult.String(), Equals, "tecPATH_DRY")
+	c.Assert(msg.Result.MetaData.AffectedNodes, HasLen, 1)
+}
+
+func (s *MessagesSuite) TestSignResponse(c *C) {
+	msg := &SignCommand{}
+	readResponseFile(c, msg, "testdata/sign.json")
+
+	// Response fields
+	c.Assert(msg.Status, Equals, "success")
+	c.Assert(msg.Type, Equals, "response")
+
+	c.Assert(msg.Result.TxBlob, Matches, "12000022800000002400000001.*")
+	payment, ok := msg.Result.Tx.(*data.Payment)
+	c.Assert(ok, Equals, true)
+	c.Assert(payment.Account.String(), Equals, "rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh")
+	c.Assert(payment.Amount.String(), Equals, "1/XRP")
+	c.Assert(payment.GetHash().String(), Equals, "D8B87B6D041951C054E6BCD0297E3FF1B0A020951DFDDF987AF92A76AF8AF4AA")
+
+	// The signature checks out and matches the blob
+	ok, err := data.CheckSignature(payment)
+	c.Assert(err, IsNil)
+	c.Assert(ok, Equals, true)
+	hash, raw, err := data.Raw(payment)
+	c.Assert(err, IsNil)
+	c.Assert(fmt.Sprintf("%X", raw), Equals, msg.Result.TxBlob)
+	c.Assert(hash.String(), Equals, payment.GetHash().String())
+}
+
+func (s *MessagesSuite) TestSignRequest(c *C) {
+	account, err := data.NewAccountFromAddress("rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh")
+	c.Assert(err, IsNil)
+	payment := data.TxFactory[data.PAYMENT]().(*data.Payment)
+	payment.Account = *account
+	payment.Destination = *account
+	amount, err := data.NewAmount("1/XRP")
+	c.Assert(err, IsNil)
+	payment.Amount = *amount
+	txJson, err := newTxJson(payment)
+	c.Assert(err, IsNil)
+	cmd := &SignCommand{
+		Command: newCommand("sign"),
+		TxJson:  txJson,
+		Secret:  "snoPBrXtMeMyMHUVTgbuqAfg1SUTb",
+	}
+	b, err := json.Marshal(cmd)
+	c.Assert(err, IsNil)
+	c.Assert(string(b), Matches, `.*"command":"sign","tx_json":\{.*"TransactionType":"Payment".*\},"secret":"snoPBrXtMeMyMHUVTgbuqAfg1SUTb"\}`)
+	c.Assert(string(b), Not(Matches), `.*"hash".*`)
 }
@@ -0,0 +1,31 @@
+package websockets
+
+import (
+	"fmt"
+)
+
+func ExampleRemote_Subscribe() {
+	r, err := NewRemote("wss://s1.ripple.com:443")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer r.Close()
+
+	if _, err := r.Subscribe(true, true, false, true); err != nil {
+		fmt.Println(err)
+		return
+	}
+	for msg := range r.Incoming {
+		switch msg := msg.(type) {
+		case *LedgerStreamMsg:
+			fmt.Println("Ledger closed:", msg.LedgerSequence, msg.LedgerHash)
+		case *TransactionStreamMsg:
+			fmt.Println("Transaction:", msg.Transaction.GetHash(), msg.Transaction.MetaData.TransactionResult)
+		case *ServerStreamMsg:
+			fmt.Println("Server status:", msg.Status)
+		case *ValidationStreamMsg:
+			fmt.Println("Validation:", msg.LedgerSequence, msg.ValidationPublicKey)
+		}
+	}
+}
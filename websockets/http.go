@@ -0,0 +1,158 @@
+package websockets
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// HTTPClient sends commands to a rippled JSON-RPC endpoint, such as
+// http://localhost:5005, with a POST for each. It has the same commands as
+// Remote, apart from the subscriptions, path_find and the others which need
+// a connection to stay open.
+type HTTPClient struct {
+	client
+	endpoint string
+	http     *http.Client
+	timeout  time.Duration
+	logger   Logger
+}
+
+// Optional settings for NewHTTPClient
+type HTTPClientOption func(*HTTPClient)
+
+// Use config for the TLS handshake with https:// endpoints
+func HTTPClientTLSConfig(config *tls.Config) HTTPClientOption {
+	return func(h *HTTPClient) {
+		h.http.Transport = &http.Transport{TLSClientConfig: config}
+	}
+}
+
+// Give up on commands which have not been answered within timeout, which
+// then fail with context.DeadlineExceeded. A deadline on the context passed
+// to a command overrides the timeout for that command.
+func HTTPClientTimeout(timeout time.Duration) HTTPClientOption {
+	return func(h *HTTPClient) { h.timeout = timeout }
+}
+
+// Log to logger instead of the standard library's default logger
+func HTTPClientLogger(logger Logger) HTTPClientOption {
+	return func(h *HTTPClient) { h.logger = logger }
+}
+
+// NewHTTPClient returns a client of the JSON-RPC endpoint. Nothing is sent
+// until the first command, and there is nothing to close.
+func NewHTTPClient(endpoint string, opts ...HTTPClientOption) *HTTPClient {
+	h := &HTTPClient{
+		endpoint: endpoint,
+		http:     &http.Client{},
+		logger:   DefaultLogger,
+	}
+	h.client = client{h}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// The body of a JSON-RPC request
+type httpRequest struct {
+	Method string        `json:"method"`
+	Params []interface{} `json:"params"`
+}
+
+// httpParams returns the parameters of cmd, which are the fields of the
+// WebSocket form of the command without the ones saying which it is
+func httpParams(cmd command) (map[string]interface{}, error) {
+	b, err := json.Marshal(cmd)
+	if err != nil {
+		return nil, err
+	}
+	var params map[string]interface{}
+	if err := json.Unmarshal(b, &params); err != nil {
+		return nil, err
+	}
+	delete(params, "id")
+	delete(params, "command")
+	for name, value := range params {
+		// The results, and optional fields which have not been given
+		if value == nil {
+			delete(params, name)
+		}
+	}
+	return params, nil
+}
+
+// send posts cmd to the endpoint and unmarshals the response into it. The
+// status and error of a JSON-RPC response are in the result, rather than
+// next to it as over a WebSocket.
+func (h *HTTPClient) send(ctx context.Context, cmd command) error {
+	if _, ok := ctx.Deadline(); !ok && h.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, h.timeout)
+		defer cancel()
+	}
+	params, err := httpParams(cmd)
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(httpRequest{
+		Method: cmd.command().Name,
+		Params: []interface{}{params},
+	})
+	if err != nil {
+		return err
+	}
+	h.logger.Debugf("%s", dumped(body))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := h.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if body, err = io.ReadAll(resp.Body); err != nil {
+		return err
+	}
+	h.logger.Debugf("%s", dumped(body))
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Unexpected response: %s %s", resp.Status, bytes.TrimSpace(body))
+	}
+	var response struct {
+		Result json.RawMessage `json:"result"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return err
+	}
+	if len(response.Result) == 0 {
+		return fmt.Errorf("Response without a result: %s", bytes.TrimSpace(body))
+	}
+	var status struct {
+		*CommandError
+		Status string `json:"status"`
+	}
+	if err := json.Unmarshal(response.Result, &status); err != nil {
+		return err
+	}
+	c := cmd.command()
+	c.CommandError, c.Status = status.CommandError, status.Status
+	if c.Status != "error" {
+		wrapped := append(append([]byte(`{"result":`), response.Result...), '}')
+		if err := json.Unmarshal(wrapped, cmd); err != nil {
+			return err
+		}
+	}
+	c.Done()
+	if c.CommandError != nil {
+		return c.CommandError
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package websockets
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/rubblelabs/ripple/data"
+	. "gopkg.in/check.v1"
+)
+
+type HTTPSuite struct{}
+
+var _ = Suite(&HTTPSuite{})
+
+// Answers each JSON-RPC request with the result f returns for it
+func newTestRPCServer(c *C, f func(request map[string]interface{}) interface{}) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		c.Check(req.Method, Equals, http.MethodPost)
+		var request map[string]interface{}
+		c.Assert(json.NewDecoder(req.Body).Decode(&request), IsNil)
+		c.Assert(json.NewEncoder(w).Encode(map[string]interface{}{"result": f(request)}), IsNil)
+	}))
+}
+
+// Reads a WebSocket response and moves its status and error into the result,
+// where a JSON-RPC response has them
+func readRPCResult(c *C, path string) map[string]interface{} {
+	var response map[string]interface{}
+	readResponseFile(c, &response, path)
+	result, ok := response["result"].(map[string]interface{})
+	if !ok {
+		result = response
+		delete(result, "id")
+		delete(result, "type")
+	}
+	result["status"] = response["status"]
+	return result
+}
+
+func (s *HTTPSuite) TestAccountInfo(c *C) {
+	requests := make(chan map[string]interface{}, 1)
+	server := newTestRPCServer(c, func(request map[string]interface{}) interface{} {
+		requests <- request
+		return readRPCResult(c, "testdata/account_info.json")
+	})
+	defer server.Close()
+
+	account, err := data.NewAccountFromAddress("rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B")
+	c.Assert(err, IsNil)
+	h := NewHTTPClient(server.URL)
+	result, err := h.AccountInfo(*account, "validated")
+	c.Assert(err, IsNil)
+	c.Check(result.LedgerSequence, Equals, uint32(7636529))
+	c.Check(*result.AccountData.Sequence, Equals, uint32(546))
+	c.Check(result.AccountData.Balance.String(), Equals, "10321199.422233")
+
+	c.Check(<-requests, DeepEquals, map[string]interface{}{
+		"method": "account_info",
+		"params": []interface{}{map[string]interface{}{
+			"account":      "rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B",
+			"ledger_index": "validated",
+		}},
+	})
+}
+
+func (s *HTTPSuite) TestError(c *C) {
+	server := newTestRPCServer(c, func(request map[string]interface{}) interface{} {
+		return readRPCResult(c, "testdata/account_info_error.json")
+	})
+	defer server.Close()
+
+	account, err := data.NewAccountFromAddress("r9cZA1mLK5R5Am25ArfXFmqgNwjZgnfk59")
+	c.Assert(err, IsNil)
+	_, err = NewHTTPClient(server.URL).AccountInfo(*account, nil)
+	cmdErr, ok := err.(*CommandError)
+	c.Assert(ok, Equals, true, Commentf("%v", err))
+	c.Check(cmdErr.Name, Equals, "actNotFound")
+	c.Check(cmdErr.Code, Equals, 19)
+}
+
+func (s *HTTPSuite) TestUnexpectedResponse(c *C) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		http.Error(w, "Server is overloaded", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	_, err := NewHTTPClient(server.URL).Fee()
+	c.Assert(err, ErrorMatches, "Unexpected response: 503 Service Unavailable Server is overloaded")
+}
+
+func (s *HTTPSuite) TestAdminCommand(c *C) {
+	requests := make(chan map[string]interface{}, 1)
+	server := newTestRPCServer(c, func(request map[string]interface{}) interface{} {
+		requests <- request
+		return map[string]interface{}{
+			"status": "error",
+			"error":  "noPermission",
+		}
+	})
+	defer server.Close()
+
+	_, err := NewHTTPClient(server.URL).AdminCommand("stop", nil)
+	c.Assert(err, ErrorMatches, ".*noPermission.*")
+	c.Check(<-requests, DeepEquals, map[string]interface{}{
+		"method": "stop",
+		"params": []interface{}{map[string]interface{}{}},
+	})
+}
+
+func (s *HTTPSuite) TestRipplePathFindSourceCurrencies(c *C) {
+	requests := make(chan map[string]interface{}, 1)
+	server := newTestRPCServer(c, func(request map[string]interface{}) interface{} {
+		requests <- request
+		return readRPCResult(c, "testdata/ripple_path_find.json")
+	})
+	defer server.Close()
+
+	src, err := data.NewAccountFromAddress("r9cZA1mLK5R5Am25ArfXFmqgNwjZgnfk59")
+	c.Assert(err, IsNil)
+	amount, err := data.NewAmount("1/USD/rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B")
+	c.Assert(err, IsNil)
+	usd, err := data.NewCurrency("USD")
+	c.Assert(err, IsNil)
+	demurrage, err := data.NewCurrency("0158415500000000C1F76FF6ECB0BAC600000000")
+	c.Assert(err, IsNil)
+	_, err = NewHTTPClient(server.URL).RipplePathFind(*src, *src, *amount, []data.Currency{usd, demurrage})
+	c.Assert(err, IsNil)
+
+	params := (<-requests)["params"].([]interface{})[0].(map[string]interface{})
+	c.Check(params["source_currencies"], DeepEquals, []interface{}{
+		map[string]interface{}{"currency": "USD"},
+		map[string]interface{}{"currency": "0158415500000000C1F76FF6ECB0BAC600000000"},
+	})
+}
@@ -0,0 +1,57 @@
+package websockets
+
+import (
+	"log"
+)
+
+// Logger receives the diagnostics of a Remote. Debugf is given every
+// message sent and received, and is best left quiet in production.
+type Logger interface {
+	Debugf(format string, args ...interface{})
+	Infof(format string, args ...interface{})
+	Errorf(format string, args ...interface{})
+}
+
+// Log to logger instead of the standard library's default logger
+func RemoteLogger(logger Logger) RemoteOption {
+	return func(r *Remote) { r.logger = logger }
+}
+
+// StdLogger logs Infof and Errorf with a standard library Logger,
+// and only logs Debugf when Debug is set
+type StdLogger struct {
+	*log.Logger
+	Debug bool
+}
+
+// The Logger used when none is set, which logs with the standard
+// library's default logger
+var DefaultLogger Logger = StdLogger{Logger: log.Default()}
+
+func (l StdLogger) Debugf(format string, args ...interface{}) {
+	if l.Debug {
+		l.Printf("DEBUG "+format, args...)
+	}
+}
+
+func (l StdLogger) Infof(format string, args ...interface{}) {
+	l.Printf("INFO "+format, args...)
+}
+
+func (l StdLogger) Errorf(format string, args ...interface{}) {
+	l.Printf("ERROR "+format, args...)
+}
+
+// NopLogger discards everything
+type NopLogger struct{}
+
+func (NopLogger) Debugf(format string, args ...interface{}) {}
+func (NopLogger) Infof(format string, args ...interface{})  {}
+func (NopLogger) Errorf(format string, args ...interface{}) {}
+
+// A message which is only indented when it gets logged
+type dumped []byte
+
+func (d dumped) String() string {
+	return dump(d)
+}
@@ -1,6 +1,8 @@
 package websockets
 
 import (
+	"context"
+
 	"github.com/rubblelabs/ripple/data"
 )
 
@@ -30,6 +32,15 @@ type PathFindCreateCommand struct {
 
 	// All commands have Result in their struct?
 	Result *PathFindCreateResult
+
+	// Receives the asynchronous updates, if any
+	updates chan *PathFindCreateResult
+}
+
+type PathFindCloseCommand struct {
+	*Command
+	Subcommand string `json:"subcommand"`
+	Result     *PathFindCreateResult
 }
 
 type SourceCurrency struct {
@@ -37,6 +48,11 @@ type SourceCurrency struct {
 }
 
 func (r *Remote) PathFindCreate(src, dest data.Account, amt data.Amount, sendMax *data.Amount, sourceCurrencies *[]SourceCurrency) (*PathFindCreateResult, error) {
+	return r.PathFindCreateContext(context.Background(), src, dest, amt, sendMax, sourceCurrencies)
+}
+
+// PathFindCreateContext is the context aware version of PathFindCreate
+func (r *Remote) PathFindCreateContext(ctx context.Context, src, dest data.Account, amt data.Amount, sendMax *data.Amount, sourceCurrencies *[]SourceCurrency) (*PathFindCreateResult, error) {
 	cmd := &PathFindCreateCommand{
 		Command:            newCommand("path_find"),
 		Subcommand:         "create",
@@ -46,10 +62,8 @@ func (r *Remote) PathFindCreate(src, dest data.Account, amt data.Amount, sendMax
 		SendMax:            sendMax,
 		SourceCurrencies:   sourceCurrencies,
 	}
-	r.outgoing <- cmd
-	<-cmd.Ready
-	if cmd.CommandError != nil {
-		return nil, cmd.CommandError
+	if err := r.send(ctx, cmd); err != nil {
+		return nil, err
 	}
 	return cmd.Result, nil
 }
@@ -93,14 +107,73 @@ func (r *Remote) PathFindCreate(src, dest data.Account, amt data.Amount, sendMax
 */
 
 type PathFindAlternative struct {
-	// TODO paths_computed
-	SourceAmount data.Amount `json:"source_amount"`
+	PathsComputed data.PathSet `json:"paths_computed"`
+	SourceAmount  data.Amount  `json:"source_amount"`
 }
 
 type PathFindCreateResult struct {
 	SourceAccount      data.Account `json:"source_account"`
 	DestinationAccount data.Account `json:"destination_account"`
 	DestinationAmount  data.Amount  `json:"destination_amount"`
-	// TODO
-	Alternatives []PathFindAlternative
+	Alternatives       []PathFindAlternative
+	FullReply          bool `json:"full_reply"`
+}
+
+// PathSets returns the computed paths of each alternative,
+// ready for use in a Payment
+func (r *PathFindCreateResult) PathSets() []data.PathSet {
+	paths := make([]data.PathSet, len(r.Alternatives))
+	for i := range r.Alternatives {
+		paths[i] = r.Alternatives[i].PathsComputed
+	}
+	return paths
+}
+
+// PathFindHandle is an open path_find request. Rippled only allows one
+// per connection, so creating another closes any previous handle.
+type PathFindHandle struct {
+	// The paths found in response to the create request
+	Result *PathFindCreateResult
+	// Updated paths pushed by the server, closed along with the request
+	Updates <-chan *PathFindCreateResult
+	remote  *Remote
+}
+
+// Size of the buffer for path_find updates. Updates are dropped if the
+// buffer is full.
+const pathFindBuffer = 10
+
+// Creates a path_find request and streams the updates to the returned handle
+func (r *Remote) PathFind(src, dest data.Account, amt data.Amount) (*PathFindHandle, error) {
+	return r.PathFindContext(context.Background(), src, dest, amt)
+}
+
+// PathFindContext is the context aware version of PathFind
+func (r *Remote) PathFindContext(ctx context.Context, src, dest data.Account, amt data.Amount) (*PathFindHandle, error) {
+	updates := make(chan *PathFindCreateResult, pathFindBuffer)
+	cmd := &PathFindCreateCommand{
+		Command:            newCommand("path_find"),
+		Subcommand:         "create",
+		SourceAccount:      src,
+		DestinationAccount: dest,
+		DestinationAmount:  amt,
+		updates:            updates,
+	}
+	if err := r.send(ctx, cmd); err != nil {
+		return nil, err
+	}
+	return &PathFindHandle{
+		Result:  cmd.Result,
+		Updates: updates,
+		remote:  r,
+	}, nil
+}
+
+// Close stops the path_find request and closes the Updates channel
+func (h *PathFindHandle) Close() error {
+	cmd := &PathFindCloseCommand{
+		Command:    newCommand("path_find"),
+		Subcommand: "close",
+	}
+	return h.remote.send(context.Background(), cmd)
 }
@@ -2,19 +2,22 @@ package websockets
 
 import (
 	"bytes"
+	"context"
+	"crypto/tls"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net"
 	"net/url"
-	"reflect"
 	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
-	"github.com/golang/glog"
 	"github.com/gorilla/websocket"
+	"github.com/rubblelabs/ripple/crypto"
 	"github.com/rubblelabs/ripple/data"
 )
 
@@ -33,63 +36,539 @@ const (
 )
 
 type Remote struct {
-	Incoming chan interface{}
-	outgoing chan Syncer
-	ws       *websocket.Conn
+	client
+	// Incoming receives the stream messages of subscriptions, which are one of
+	// *LedgerStreamMsg, *TransactionStreamMsg, *ServerStreamMsg,
+	// *ConsensusStreamMsg, *ValidationStreamMsg, *ManifestStreamMsg or
+	// *PathFindCreateResult.
+	// What happens when it is full is set by RemoteIncomingPolicy.
+	Incoming  chan interface{}
+	outgoing  chan command
+	cancel    chan uint64
+	closed    chan struct{}
+	ws        *websocket.Conn
+	endpoint  *url.URL
+	reconnect *ReconnectPolicy
+	tlsConfig *tls.Config
+	timeout   time.Duration
+	logger    Logger
+	onState   func(State)
+	buffers   Buffers
+	policy    IncomingPolicy
+	ids       atomic.Uint64 // The last id given to a command
+	quit      chan struct{}
+	closeOnce sync.Once
+	closeErr  error // From sending the close frame
+}
+
+// transport sends a command to the server and waits for the response, which
+// it unmarshals into cmd. A failed command is returned as its CommandError.
+type transport interface {
+	send(ctx context.Context, cmd command) error
+}
+
+// client has the commands which are answered the same way whatever the
+// transport, and which Remote and HTTPClient share
+type client struct {
+	transport
+}
+
+// ReconnectPolicy controls how a Remote re-establishes a dropped connection.
+// The delay before each attempt starts at BaseDelay and doubles after every
+// failure, up to MaxDelay if it is set.
+type ReconnectPolicy struct {
+	MaxRetries int // Attempts per dropped connection, zero for no limit
+	BaseDelay  time.Duration
+	MaxDelay   time.Duration
+}
+
+// State is a change to the connection of a Remote
+type State int
+
+const (
+	Connected    State = iota // Connected or reconnected to the server
+	Disconnected              // Lost the connection to the server
+	Reconnecting              // About to dial the server again
+	PongTimeout               // Nothing was heard from the server in time
+	Closed                    // Stopped for good, Incoming has been closed
+)
+
+var stateNames = map[State]string{
+	Connected:    "Connected",
+	Disconnected: "Disconnected",
+	Reconnecting: "Reconnecting",
+	PongTimeout:  "PongTimeout",
+	Closed:       "Closed",
+}
+
+func (s State) String() string {
+	if name, ok := stateNames[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("State(%d)", int(s))
+}
+
+// Buffers sets the capacity of the channels of a Remote
+type Buffers struct {
+	Incoming int // Stream messages waiting to be received from Incoming
+	Outgoing int // Commands waiting to be written to the connection
+}
+
+var defaultBuffers = Buffers{Incoming: 1000, Outgoing: 10}
+
+// IncomingPolicy decides what happens to stream messages when Incoming is full
+type IncomingPolicy int
+
+const (
+	// Wait for room in Incoming. Until there is, responses to commands and
+	// the rest of the stream are held up too, so nothing is lost but a
+	// stalled receiver stalls every command.
+	BlockWhenFull IncomingPolicy = iota
+	// Drop the message, and log an error. Commands carry on regardless of
+	// the receiver, which misses messages while it is behind.
+	DropWhenFull
+)
+
+// Optional settings for NewRemote
+type RemoteOption func(*Remote)
+
+// Reconnect according to policy when the connection drops. Commands
+// in flight are re-sent and subscriptions are renewed once connected.
+// Any open path_find is closed.
+func RemoteReconnect(policy ReconnectPolicy) RemoteOption {
+	return func(r *Remote) { r.reconnect = &policy }
+}
+
+// Use config for the TLS handshake with wss:// endpoints, for instance to
+// trust custom roots. ServerName defaults to the host of the endpoint.
+func RemoteTLSConfig(config *tls.Config) RemoteOption {
+	return func(r *Remote) { r.tlsConfig = config }
+}
+
+// Give up on commands which have not been answered within timeout, which
+// then fail with context.DeadlineExceeded and are forgotten. A deadline on
+// the context passed to a command overrides the timeout for that command.
+func RemoteCommandTimeout(timeout time.Duration) RemoteOption {
+	return func(r *Remote) { r.timeout = timeout }
+}
+
+// Size the channels of the Remote. Zero leaves the default size of a channel,
+// which is 1000 for Incoming and 10 for outgoing commands.
+func RemoteBuffers(buffers Buffers) RemoteOption {
+	return func(r *Remote) {
+		if buffers.Incoming > 0 {
+			r.buffers.Incoming = buffers.Incoming
+		}
+		if buffers.Outgoing > 0 {
+			r.buffers.Outgoing = buffers.Outgoing
+		}
+	}
+}
+
+// Handle stream messages according to policy when Incoming is full. The
+// default is BlockWhenFull.
+func RemoteIncomingPolicy(policy IncomingPolicy) RemoteOption {
+	return func(r *Remote) { r.policy = policy }
+}
+
+// Call f with every change to the state of the connection. f is called
+// by the goroutines serving the connection, so must return promptly.
+func RemoteOnStateChange(f func(State)) RemoteOption {
+	return func(r *Remote) { r.onState = f }
 }
 
 // NewRemote returns a new remote session connected to the specified
 // server endpoint URI. To close the connection, use Close().
-func NewRemote(endpoint string) (*Remote, error) {
-	glog.Infoln(endpoint)
+func NewRemote(endpoint string, opts ...RemoteOption) (*Remote, error) {
 	u, err := url.Parse(endpoint)
 	if err != nil {
 		return nil, err
 	}
-	c, err := net.DialTimeout("tcp", u.Host, dialTimeout)
-	if err != nil {
-		return nil, err
+	r := &Remote{
+		cancel:   make(chan uint64),
+		closed:   make(chan struct{}),
+		quit:     make(chan struct{}),
+		endpoint: u,
+		logger:   DefaultLogger,
+		buffers:  defaultBuffers,
 	}
-	ws, _, err := websocket.NewClient(c, u, nil, 1024, 1024)
-	if err != nil {
-		return nil, err
+	r.client = client{r}
+	for _, opt := range opts {
+		opt(r)
 	}
-	r := &Remote{
-		Incoming: make(chan interface{}, 1000),
-		outgoing: make(chan Syncer, 10),
-		ws:       ws,
+	r.Incoming = make(chan interface{}, r.buffers.Incoming)
+	r.outgoing = make(chan command, r.buffers.Outgoing)
+	r.logger.Infof("Connecting to %s", endpoint)
+	if r.ws, err = r.dial(); err != nil {
+		return nil, err
 	}
 
 	go r.run()
 	return r, nil
 }
 
-// Close shuts down the Remote session and blocks until all internal
-// goroutines have been cleaned up.
-// Any commands that are pending a response will return with an error.
-func (r *Remote) Close() {
-	close(r.outgoing)
+// deliver sends msg on incoming, or drops it if policy says so. Waiting for
+// room gives up when quit is closed.
+func deliver(incoming chan<- interface{}, msg interface{}, policy IncomingPolicy, logger Logger, quit <-chan struct{}) {
+	if policy == BlockWhenFull {
+		select {
+		case incoming <- msg:
+		case <-quit:
+		}
+		return
+	}
+	select {
+	case incoming <- msg:
+	default:
+		logger.Errorf("Incoming is full, dropped %T", msg)
+	}
+}
+
+func (r *Remote) stateChanged(state State) {
+	r.logger.Debugf("%s: %s", r.endpoint, state)
+	if r.onState != nil {
+		r.onState(state)
+	}
+}
+
+func (r *Remote) dial() (*websocket.Conn, error) {
+	// The dialer performs the TLS handshake for wss:// endpoints
+	dialer := websocket.Dialer{
+		NetDial: func(network, addr string) (net.Conn, error) {
+			return net.DialTimeout(network, addr, dialTimeout)
+		},
+		TLSClientConfig:  r.tlsConfig,
+		HandshakeTimeout: dialTimeout,
+		ReadBufferSize:   1024,
+		WriteBufferSize:  1024,
+	}
+	ws, _, err := dialer.Dial(r.endpoint.String(), nil)
+	return ws, err
+}
+
+// How long Close waits for the server to answer the close frame before
+// hanging up
+var closeWait = time.Second
+
+// Close shuts down the Remote session, closing the connection with a close
+// frame, and blocks until all internal goroutines have returned. Commands
+// waiting for a response, and any sent afterwards, fail with a Connection
+// Closed error. Stream messages already in Incoming can still be received
+// before it is closed, and none are added after Close returns. The error
+// sending the close frame is returned by the first call, and later calls
+// return nil.
+func (r *Remote) Close() error {
+	first := false
+	r.closeOnce.Do(func() {
+		close(r.quit)
+		first = true
+	})
+	<-r.closed
+	if !first {
+		return nil
+	}
+	return r.closeErr
+}
+
+// The state of run which outlives a single connection
+type session struct {
+	pending       map[uint64]command
+	subscriptions []*SubscribeCommand
+	pathFind      chan *PathFindCreateResult
+}
+
+// track records a command which has been, or will be, sent to the server
+func (s *session) track(cmd command) {
+	s.pending[cmd.CommandId()] = cmd
+
+	// Only one path_find can be open, so starting or
+	// closing one ends the updates of the previous
+	switch cmd.(type) {
+	case *PathFindCreateCommand, *PathFindCloseCommand:
+		s.closePathFind()
+		if create, ok := cmd.(*PathFindCreateCommand); ok {
+			s.pathFind = create.updates
+		}
+	}
+}
+
+func (s *session) closePathFind() {
+	if s.pathFind != nil {
+		close(s.pathFind)
+		s.pathFind = nil
+	}
+}
+
+// unsubscribe stops what cmd unsubscribed from being renewed after
+// reconnecting, and forgets subscriptions which are left with nothing
+func (s *session) unsubscribe(cmd *UnsubscribeCommand) {
+	var subscriptions []*SubscribeCommand
+	for _, sub := range s.subscriptions {
+		var (
+			streams                    []string
+			accounts, accountsProposed []data.Account
+			books                      []OrderBookSubscription
+		)
+		for _, stream := range sub.Streams {
+			if !containsString(cmd.Streams, stream) {
+				streams = append(streams, stream)
+			}
+		}
+		for _, account := range sub.Accounts {
+			if !containsAccount(cmd.Accounts, account) {
+				accounts = append(accounts, account)
+			}
+		}
+		for _, account := range sub.AccountsProposed {
+			if !containsAccount(cmd.AccountsProposed, account) {
+				accountsProposed = append(accountsProposed, account)
+			}
+		}
+		for _, book := range sub.Books {
+			if !unsubscribed(book, cmd.Books) {
+				books = append(books, book)
+			}
+		}
+		sub.Streams, sub.Accounts, sub.AccountsProposed, sub.Books = streams, accounts, accountsProposed, books
+		if len(streams)+len(accounts)+len(accountsProposed)+len(books) > 0 {
+			subscriptions = append(subscriptions, sub)
+		}
+	}
+	s.subscriptions = subscriptions
+}
+
+func containsString(s []string, v string) bool {
+	for i := range s {
+		if s[i] == v {
+			return true
+		}
+	}
+	return false
+}
+
+func containsAccount(s []data.Account, v data.Account) bool {
+	for i := range s {
+		if s[i].Equals(v) {
+			return true
+		}
+	}
+	return false
+}
+
+func unsubscribed(book OrderBookSubscription, books []OrderBookUnsubscription) bool {
+	for _, b := range books {
+		switch {
+		case b.TakerGets == book.TakerGets && b.TakerPays == book.TakerPays:
+			return true
+		case b.Both && b.TakerGets == book.TakerPays && b.TakerPays == book.TakerGets:
+			return true
+		}
+	}
+	return false
+}
+
+// dropped cleans up after a lost connection. The server forgets any
+// path_find, so requests to create one fail rather than being re-sent.
+func (s *session) dropped() {
+	s.closePathFind()
+	for id, command := range s.pending {
+		if _, ok := command.(*PathFindCreateCommand); ok {
+			delete(s.pending, id)
+			command.Fail("Connection Closed")
+		}
+	}
+}
+
+// Implemented by every command through the embedded *Command, which is
+// all the run loop needs of the commands it sends and answers
+type command interface {
+	Syncer
+	SetCommandId(id uint64)
+	command() *Command
+}
+
+// withTimeout applies the command timeout to ctx, unless it has a deadline
+func (r *Remote) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Deadline(); ok || r.timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, r.timeout)
+}
+
+// send posts cmd to the server and waits for its response
+func (r *Remote) send(ctx context.Context, cmd command) error {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+	if err := r.post(ctx, cmd); err != nil {
+		return err
+	}
+	return r.wait(ctx, cmd)
+}
+
+// post gives cmd the next id and queues it for sending. It fails if ctx is
+// done first.
+func (r *Remote) post(ctx context.Context, cmd command) error {
+	cmd.SetCommandId(r.ids.Add(1))
+	select {
+	case <-r.quit:
+		return errConnectionClosed
+	default:
+	}
+	select {
+	case r.outgoing <- cmd:
+		return nil
+	case <-r.quit:
+		return errConnectionClosed
+	case <-r.closed:
+		return errConnectionClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
 
-	// Drain the Incoming channel and block until it is closed,
-	// indicating that this Remote is fully cleaned up.
-	for range r.Incoming {
+// wait blocks until the response to cmd arrives, which is returned
+// as an error if it failed. If ctx is done first cmd is abandoned.
+func (r *Remote) wait(ctx context.Context, cmd command) error {
+	c := cmd.command()
+	select {
+	case <-c.Ready:
+	case <-ctx.Done():
+		r.abandon(cmd)
+		return ctx.Err()
+	case <-r.closed:
+		// The run loop fails what it knows of before it ends
+		select {
+		case <-c.Ready:
+		default:
+			return errConnectionClosed
+		}
+	}
+	if c.CommandError != nil {
+		return c.CommandError
 	}
+	return nil
+}
+
+// abandon stops the run loop waiting for the response to cmd. The loop can
+// be held up delivering to a full Incoming, so the caller is not kept
+// waiting for it to take the id.
+func (r *Remote) abandon(cmd command) {
+	id := cmd.CommandId()
+	go func() {
+		select {
+		case r.cancel <- id:
+		case <-r.closed:
+		}
+	}()
 }
 
-// run spawns the read/write pumps and then runs until Close() is called.
+// run serves the connection, reconnecting as the policy allows,
+// until Close() is called.
 func (r *Remote) run() {
+	s := &session{
+		pending: make(map[uint64]command),
+	}
+
+	defer func() {
+		s.closePathFind()
+
+		// Cancel all pending commands with an error, and those which
+		// were queued but never sent
+		for _, c := range s.pending {
+			c.Fail("Connection Closed")
+		}
+		for queued := true; queued; {
+			select {
+			case c := <-r.outgoing:
+				c.Fail("Connection Closed")
+			default:
+				queued = false
+			}
+		}
+		close(r.Incoming)
+		r.stateChanged(Closed)
+		close(r.closed)
+	}()
+
+	for ws := r.ws; ws != nil; ws = r.redial(s) {
+		r.stateChanged(Connected)
+		if closed := r.serve(ws, s); closed {
+			return
+		}
+		r.stateChanged(Disconnected)
+		s.dropped()
+	}
+}
+
+// redial waits for each delay of the reconnect policy and dials again.
+// Commands sent in the meantime are held for the new connection.
+// Returns nil if the policy is exhausted or Close() is called.
+func (r *Remote) redial(s *session) *websocket.Conn {
+	if r.reconnect == nil {
+		return nil
+	}
+	delay := r.reconnect.BaseDelay
+	for attempt := 1; r.reconnect.MaxRetries == 0 || attempt <= r.reconnect.MaxRetries; attempt++ {
+		timer := time.NewTimer(delay)
+	wait:
+		for {
+			select {
+			case <-timer.C:
+				break wait
+			case <-r.quit:
+				timer.Stop()
+				return nil
+			case command := <-r.outgoing:
+				s.track(command)
+			case id := <-r.cancel:
+				delete(s.pending, id)
+			}
+		}
+		r.stateChanged(Reconnecting)
+		ws, err := r.dial()
+		if err == nil {
+			r.logger.Infof("Reconnected to %s after %d attempts", r.endpoint, attempt)
+			return ws
+		}
+		r.logger.Errorf("Reconnect attempt %d: %s", attempt, err)
+		if delay *= 2; r.reconnect.MaxDelay > 0 && delay > r.reconnect.MaxDelay {
+			delay = r.reconnect.MaxDelay
+		}
+	}
+	return nil
+}
+
+// serve spawns the read/write pumps for ws and runs until either the
+// connection is lost or Close() is called, in which case it returns true.
+func (r *Remote) serve(ws *websocket.Conn, s *session) (closed bool) {
 	outbound := make(chan interface{})
 	inbound := make(chan []byte)
-	pending := make(map[uint64]Syncer)
+	writing := make(chan struct{})
+	var writeErr error
 
 	defer func() {
-		close(outbound) // Shuts down the writePump
-		close(r.Incoming)
+		close(outbound) // Shuts down the writePump, which sends a close frame
+		<-writing
 
-		// Cancel all pending commands with an error
-		for _, c := range pending {
-			c.Fail("Connection Closed")
+		// Give the server a moment to answer the close frame, which is
+		// what ends the readPump when closing cleanly
+		if closed {
+			r.closeErr = writeErr
+			timeout := time.NewTimer(closeWait)
+			defer timeout.Stop()
+		wait:
+			for {
+				select {
+				case _, ok := <-inbound:
+					if !ok {
+						break wait
+					}
+				case <-timeout.C:
+					break wait
+				}
+			}
 		}
+		ws.Close()
 
 		// Drain the inbound channel and block until it is closed,
 		// indicating that the readPump has returned.
@@ -97,36 +576,76 @@ func (r *Remote) run() {
 		}
 	}()
 
-	// Spawn read/write goroutines
+	// Spawn read/write goroutines. The connection is closed as soon as
+	// writing fails, so that reading fails too.
 	go func() {
-		defer r.ws.Close()
-		r.writePump(outbound)
+		defer close(writing)
+		if writeErr = r.writePump(ws, outbound); writeErr != nil {
+			ws.Close()
+		}
 	}()
 	go func() {
 		defer close(inbound)
-		r.readPump(inbound)
+		r.readPump(ws, inbound)
 	}()
 
+	send := func(message interface{}) bool {
+		select {
+		case outbound <- message:
+			return true
+		case <-writing:
+			return false
+		}
+	}
+
+	// Anything still pending was sent on a previous connection, which
+	// also took the subscriptions down with it
+	for _, command := range s.pending {
+		if !send(command) {
+			return false
+		}
+	}
+	subscriptions := s.subscriptions
+	s.subscriptions = nil
+	for _, sub := range subscriptions {
+		cmd := &SubscribeCommand{
+			Command:          newCommand("subscribe"),
+			Streams:          sub.Streams,
+			Accounts:         sub.Accounts,
+			AccountsProposed: sub.AccountsProposed,
+			Books:            sub.Books,
+		}
+		cmd.SetCommandId(r.ids.Add(1))
+		s.track(cmd)
+		if !send(cmd) {
+			return false
+		}
+	}
+
 	// Main run loop
 	var response Command
 	for {
 		select {
-		case command, ok := <-r.outgoing:
-			if !ok {
-				return
+		case <-r.quit:
+			return true
+
+		case command := <-r.outgoing:
+			s.track(command)
+			if !send(command) {
+				return false
 			}
-			outbound <- command
-			id := reflect.ValueOf(command).Elem().FieldByName("Id").Uint()
-			pending[id] = command
+
+		case id := <-r.cancel:
+			delete(s.pending, id)
 
 		case in, ok := <-inbound:
 			if !ok {
-				glog.Errorln("Connection closed by server")
-				return
+				r.logger.Errorf("Connection closed by server")
+				return false
 			}
 
 			if err := json.Unmarshal(in, &response); err != nil {
-				glog.Errorln(err.Error())
+				r.logger.Errorf("%s", err)
 				continue
 			}
 			// Stream message
@@ -134,55 +653,113 @@ func (r *Remote) run() {
 			if ok {
 				cmd := factory()
 				if err := json.Unmarshal(in, &cmd); err != nil {
-					glog.Errorln(err.Error(), string(in))
+					r.logger.Errorf("%s %s", err, in)
 					continue
 				}
-				r.Incoming <- cmd
+				if update, ok := cmd.(*PathFindCreateResult); ok && s.pathFind != nil {
+					select {
+					case s.pathFind <- update:
+					default:
+						r.logger.Infof("Dropped path_find update")
+					}
+					continue
+				}
+				deliver(r.Incoming, cmd, r.policy, r.logger, r.quit)
 				continue
 			}
 
 			// Command response message
-			cmd, ok := pending[response.Id]
+			cmd, ok := s.pending[response.Id]
 			if !ok {
-				glog.Errorf("Unexpected message: %+v", response)
+				r.logger.Errorf("Unexpected message: %+v", response)
 				continue
 			}
-			delete(pending, response.Id)
+			delete(s.pending, response.Id)
 			if err := json.Unmarshal(in, &cmd); err != nil {
-				glog.Errorln(err.Error())
+				r.logger.Errorf("%s", err)
+				cmd.Fail(err.Error())
 				continue
 			}
+			// Remember subscriptions to renew them after reconnecting
+			if sub, ok := cmd.(*SubscribeCommand); ok && sub.CommandError == nil {
+				s.subscriptions = append(s.subscriptions, sub)
+			}
+			if unsub, ok := cmd.(*UnsubscribeCommand); ok && unsub.CommandError == nil {
+				s.unsubscribe(unsub)
+			}
 			cmd.Done()
 		}
 	}
 }
 
 // Synchronously get a single transaction
-func (r *Remote) Tx(hash data.Hash256) (*TxResult, error) {
+func (r *client) Tx(hash data.Hash256) (*TxResult, error) {
+	return r.TxContext(context.Background(), hash)
+}
+
+// TxContext is the context aware version of Tx
+func (r *client) TxContext(ctx context.Context, hash data.Hash256) (*TxResult, error) {
 	cmd := &TxCommand{
 		Command:     newCommand("tx"),
 		Transaction: hash,
 	}
-	r.outgoing <- cmd
-	<-cmd.Ready
-	if cmd.CommandError != nil {
-		return nil, cmd.CommandError
+	if err := r.send(ctx, cmd); err != nil {
+		return nil, err
 	}
 	return cmd.Result, nil
 }
 
-func (r *Remote) accountTx(account data.Account, c chan *data.TransactionWithMetaData, pageSize int, minLedger, maxLedger int64) {
+// Synchronously get a page of the most recent transactions, starting
+// start transactions back from the newest
+func (r *client) TxHistory(start uint32) (*TxHistoryResult, error) {
+	return r.TxHistoryContext(context.Background(), start)
+}
+
+// TxHistoryContext is the context aware version of TxHistory
+func (r *client) TxHistoryContext(ctx context.Context, start uint32) (*TxHistoryResult, error) {
+	cmd := &TxHistoryCommand{
+		Command: newCommand("tx_history"),
+		Start:   start,
+	}
+	if err := r.send(ctx, cmd); err != nil {
+		return nil, err
+	}
+	return cmd.Result, nil
+}
+
+// Synchronously get a transaction and its metadata from a specific ledger.
+// Unlike Tx, only that ledger is searched.
+func (r *client) TransactionEntry(hash data.Hash256, ledger uint32) (*TransactionEntryResult, error) {
+	return r.TransactionEntryContext(context.Background(), hash, ledger)
+}
+
+// TransactionEntryContext is the context aware version of TransactionEntry
+func (r *client) TransactionEntryContext(ctx context.Context, hash data.Hash256, ledger uint32) (*TransactionEntryResult, error) {
+	cmd := &TransactionEntryCommand{
+		Command:     newCommand("transaction_entry"),
+		TxHash:      hash,
+		LedgerIndex: ledger,
+	}
+	if err := r.send(ctx, cmd); err != nil {
+		return nil, err
+	}
+	return cmd.Result, nil
+}
+
+func (r *Remote) accountTx(ctx context.Context, account data.Account, c chan *data.TransactionWithMetaData, minLedger, maxLedger int64, opts []AccountTxOption) {
 	defer close(c)
-	cmd := newAccountTxCommand(account, pageSize, nil, minLedger, maxLedger)
-	for ; ; cmd = newAccountTxCommand(account, pageSize, cmd.Result.Marker, minLedger, maxLedger) {
-		r.outgoing <- cmd
-		<-cmd.Ready
-		if cmd.CommandError != nil {
-			glog.Errorln(cmd.Error())
+	cmd := newAccountTxCommand(account, nil, minLedger, maxLedger, opts)
+	for ; ; cmd = newAccountTxCommand(account, cmd.Result.Marker, minLedger, maxLedger, opts) {
+		if err := r.send(ctx, cmd); err != nil {
+			r.logger.Errorf("%s", err)
 			return
 		}
 		for _, tx := range cmd.Result.Transactions {
-			c <- tx
+			select {
+			case c <- tx:
+			case <-ctx.Done():
+				return
+			}
 		}
 		if cmd.Result.Marker == nil {
 			return
@@ -198,14 +775,41 @@ func (r *Remote) accountTx(account data.Account, c chan *data.TransactionWithMet
 //
 // Use minLedger -1 for the earliest ledger available.
 // Use maxLedger -1 for the most recent validated ledger.
-func (r *Remote) AccountTx(account data.Account, pageSize int, minLedger, maxLedger int64) chan *data.TransactionWithMetaData {
+func (r *Remote) AccountTx(account data.Account, minLedger, maxLedger int64, opts ...AccountTxOption) chan *data.TransactionWithMetaData {
+	return r.AccountTxContext(context.Background(), account, minLedger, maxLedger, opts...)
+}
+
+// AccountTxContext is the context aware version of AccountTx.
+// The channel is closed early when ctx is done.
+func (r *Remote) AccountTxContext(ctx context.Context, account data.Account, minLedger, maxLedger int64, opts ...AccountTxOption) chan *data.TransactionWithMetaData {
 	c := make(chan *data.TransactionWithMetaData)
-	go r.accountTx(account, c, pageSize, minLedger, maxLedger)
+	go r.accountTx(ctx, account, c, minLedger, maxLedger, opts)
 	return c
 }
 
+// Synchronously retrieve a single page of transactions for an account.
+// Pass the Marker from the previous result to get the next page,
+// or nil for the first page.
+func (r *client) AccountTxPage(account data.Account, minLedger, maxLedger int64, marker map[string]interface{}, opts ...AccountTxOption) (*AccountTxResult, error) {
+	return r.AccountTxPageContext(context.Background(), account, minLedger, maxLedger, marker, opts...)
+}
+
+// AccountTxPageContext is the context aware version of AccountTxPage
+func (r *client) AccountTxPageContext(ctx context.Context, account data.Account, minLedger, maxLedger int64, marker map[string]interface{}, opts ...AccountTxOption) (*AccountTxResult, error) {
+	cmd := newAccountTxCommand(account, marker, minLedger, maxLedger, opts)
+	if err := r.send(ctx, cmd); err != nil {
+		return nil, err
+	}
+	return cmd.Result, nil
+}
+
 // Synchronously submit a single transaction
-func (r *Remote) Submit(tx data.Transaction) (*SubmitResult, error) {
+func (r *client) Submit(tx data.Transaction, opts ...SubmitOption) (*SubmitResult, error) {
+	return r.SubmitContext(context.Background(), tx, opts...)
+}
+
+// SubmitContext is the context aware version of Submit
+func (r *client) SubmitContext(ctx context.Context, tx data.Transaction, opts ...SubmitOption) (*SubmitResult, error) {
 	_, raw, err := data.Raw(tx)
 	if err != nil {
 		return nil, err
@@ -214,16 +818,308 @@ func (r *Remote) Submit(tx data.Transaction) (*SubmitResult, error) {
 		Command: newCommand("submit"),
 		TxBlob:  fmt.Sprintf("%X", raw),
 	}
-	r.outgoing <- cmd
-	<-cmd.Ready
-	if cmd.CommandError != nil {
-		return nil, cmd.CommandError
+	for _, opt := range opts {
+		opt(cmd)
+	}
+	if err := r.send(ctx, cmd); err != nil {
+		return nil, err
+	}
+	return cmd.Result, nil
+}
+
+// Synchronously finds out what submitting tx would do, without submitting
+// it. The transaction must not be signed, and the server fills in any
+// missing Fee, Sequence and SigningPubKey, which the returned Tx shows.
+func (r *client) Simulate(tx data.Transaction) (*SimulateResult, error) {
+	return r.SimulateContext(context.Background(), tx)
+}
+
+// SimulateContext is the context aware version of Simulate
+func (r *client) SimulateContext(ctx context.Context, tx data.Transaction) (*SimulateResult, error) {
+	if tx.GetBase().TxnSignature != nil || len(tx.GetBase().Signers) > 0 {
+		return nil, fmt.Errorf("Cannot simulate a signed transaction")
+	}
+	_, raw, err := data.Raw(tx)
+	if err != nil {
+		return nil, err
+	}
+	cmd := &SimulateCommand{
+		Command: newCommand("simulate"),
+		TxBlob:  fmt.Sprintf("%X", raw),
+	}
+	if err := r.send(ctx, cmd); err != nil {
+		return nil, err
+	}
+	return cmd.Result, nil
+}
+
+// Synchronously submit a transaction which has collected its Signers,
+// see data.AddSigner. The fee must already account for the number of
+// signers, as rippled charges the base fee once per signer plus once
+// for the transaction itself.
+func (r *client) SubmitMultisigned(tx data.Transaction) (*SubmitResult, error) {
+	return r.SubmitMultisignedContext(context.Background(), tx)
+}
+
+// SubmitMultisignedContext is the context aware version of SubmitMultisigned
+func (r *client) SubmitMultisignedContext(ctx context.Context, tx data.Transaction) (*SubmitResult, error) {
+	if err := checkMultisigned(tx); err != nil {
+		return nil, err
+	}
+	txJson, err := newTxJson(tx)
+	if err != nil {
+		return nil, err
+	}
+	cmd := &SubmitMultisignedCommand{
+		Command: newCommand("submit_multisigned"),
+		TxJson:  txJson,
+	}
+	if err := r.send(ctx, cmd); err != nil {
+		return nil, err
+	}
+	return cmd.Result, nil
+}
+
+// newTxJson returns the fields of tx for the tx_json of a command
+func newTxJson(tx data.Transaction) (map[string]interface{}, error) {
+	b, err := json.Marshal(tx)
+	if err != nil {
+		return nil, err
+	}
+	var txJson map[string]interface{}
+	if err := json.Unmarshal(b, &txJson); err != nil {
+		return nil, err
+	}
+	// rippled rejects unknown fields and the hash is not a field
+	delete(txJson, "hash")
+	return txJson, nil
+}
+
+// Synchronously sign tx on the server with secret, which rippled fills in
+// the missing Fee, Sequence and SigningPubKey for.
+//
+// UNSAFE: the secret is sent to the server, which can then sign anything
+// for the account. Only use it with a server you run yourself, over a
+// connection nobody else can read, such as for testing against a local
+// rippled. Otherwise sign locally with data.Sign.
+func (r *client) Sign(tx data.Transaction, secret string) (*SignResult, error) {
+	return r.SignContext(context.Background(), tx, secret)
+}
+
+// SignContext is the context aware version of Sign
+func (r *client) SignContext(ctx context.Context, tx data.Transaction, secret string) (*SignResult, error) {
+	txJson, err := newTxJson(tx)
+	if err != nil {
+		return nil, err
+	}
+	cmd := &SignCommand{
+		Command: newCommand("sign"),
+		TxJson:  txJson,
+		Secret:  secret,
+	}
+	if err := r.send(ctx, cmd); err != nil {
+		return nil, err
 	}
 	return cmd.Result, nil
 }
 
-// Synchronously submit multiple transactions
-func (r *Remote) SubmitBatch(txs []data.Transaction) ([]*SubmitResult, error) {
+func checkMultisigned(tx data.Transaction) error {
+	base := tx.GetBase()
+	if base.SigningPubKey != nil && !base.SigningPubKey.IsZero() {
+		return fmt.Errorf("Multisigned transaction must have an empty SigningPubKey")
+	}
+	if len(base.Signers) == 0 {
+		return fmt.Errorf("Multisigned transaction has no Signers")
+	}
+	for i := 1; i < len(base.Signers); i++ {
+		if !base.Signers[i-1].Signer.Account.Less(base.Signers[i].Signer.Account) {
+			return fmt.Errorf("Signers must be sorted by account without duplicates: %s", base.Signers[i].Signer.Account)
+		}
+	}
+	return nil
+}
+
+// How many ledgers after the last validated one Autofill leaves for a
+// transaction to be included in
+const AutofillLedgerOffset = 20
+
+// Synchronously fills in whichever of the Account, Sequence, Fee and
+// LastLedgerSequence of tx have not been set, using account_info, fee and
+// server_info. The Fee is the one suggested for FeeUrgencyNormal, or the
+// owner reserve increment for an AccountDelete.
+func (r *client) Autofill(tx data.Transaction, account data.Account) error {
+	return r.AutofillContext(context.Background(), tx, account)
+}
+
+// AutofillContext is the context aware version of Autofill
+func (r *client) AutofillContext(ctx context.Context, tx data.Transaction, account data.Account) error {
+	base := tx.GetBase()
+	if base.Account.IsZero() {
+		base.Account = account
+	}
+	if base.Sequence == 0 && base.TicketSequence == nil {
+		info, err := r.AccountInfoContext(ctx, base.Account, "current")
+		if err != nil {
+			return err
+		}
+		if info.AccountData.Sequence == nil {
+			return fmt.Errorf("No sequence for account: %s", base.Account)
+		}
+		base.Sequence = *info.AccountData.Sequence
+	}
+	del, isDelete := tx.(*data.AccountDelete)
+	var server *ServerInfoResult
+	if base.LastLedgerSequence == nil || (base.Fee.IsZero() && isDelete) {
+		var err error
+		if server, err = r.ServerInfoContext(ctx); err != nil {
+			return err
+		}
+		if server.Info.ValidatedLedger == nil {
+			return fmt.Errorf("Server has no validated ledger")
+		}
+	}
+	if base.Fee.IsZero() {
+		var fee *data.Value
+		if isDelete {
+			_, inc := server.Reserves()
+			increment, err := data.NewNativeValue(int64(inc))
+			if err != nil {
+				return err
+			}
+			if fee, err = del.DeletionFee(*increment); err != nil {
+				return err
+			}
+		} else {
+			result, err := r.FeeContext(ctx)
+			if err != nil {
+				return err
+			}
+			if fee, err = result.SuggestedFee(FeeUrgencyNormal); err != nil {
+				return err
+			}
+		}
+		base.Fee = *fee
+	}
+	if base.LastLedgerSequence == nil {
+		last := server.Info.ValidatedLedger.Seq + AutofillLedgerOffset
+		base.LastLedgerSequence = &last
+	}
+	return nil
+}
+
+// How often SubmitReliable checks on a transaction, about once a ledger
+var reliablePollInterval = 4 * time.Second
+
+// Synchronously submits a signed transaction and waits until it is in a
+// validated ledger, or until the last validated ledger is past its
+// LastLedgerSequence, when it can never be applied. Until then it is
+// submitted again whenever the server does not know of it, for instance
+// after a telINSUF_FEE_P, terPRE_SEQ, or being dropped from the queue. The
+// validated transaction is returned whatever its TransactionResult, which
+// should be checked, as a tec result has still claimed the fee.
+func (r *client) SubmitReliable(tx data.Transaction) (*data.TransactionWithMetaData, error) {
+	return r.SubmitReliableContext(context.Background(), tx)
+}
+
+// SubmitReliableContext is the context aware version of SubmitReliable
+func (r *client) SubmitReliableContext(ctx context.Context, tx data.Transaction) (*data.TransactionWithMetaData, error) {
+	last := tx.GetBase().LastLedgerSequence
+	if last == nil {
+		return nil, fmt.Errorf("Reliable submission requires a LastLedgerSequence")
+	}
+	hash, _, err := data.Raw(tx)
+	if err != nil {
+		return nil, err
+	}
+	submit := func() error {
+		result, err := r.SubmitContext(ctx, tx)
+		if err != nil {
+			return err
+		}
+		if result.EngineResult.Final() {
+			return fmt.Errorf("Transaction %s failed: %s %s", hash, result.EngineResult, result.EngineResult.Human())
+		}
+		return nil
+	}
+	if err := submit(); err != nil {
+		return nil, err
+	}
+	for {
+		select {
+		case <-time.After(reliablePollInterval):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		// The validated ledger is looked up first, so that the transaction
+		// cannot be validated in it without the lookup below seeing it
+		server, err := r.ServerInfoContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		txm, err := r.TxContext(ctx, hash)
+		switch {
+		case err == nil && txm.Validated:
+			return &txm.TransactionWithMetaData, nil
+		case err != nil && !isTxnNotFound(err):
+			return nil, err
+		}
+		if validated := server.Info.ValidatedLedger; validated != nil && validated.Seq > *last {
+			return nil, fmt.Errorf("Transaction %s expired: validated ledger %d is past LastLedgerSequence %d", hash, validated.Seq, *last)
+		}
+		if err == nil {
+			continue // Applied to a ledger which is not validated yet
+		}
+		if err := submit(); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func isTxnNotFound(err error) bool {
+	e, ok := err.(*CommandError)
+	return ok && e.Name == "txnNotFound"
+}
+
+// BatchOption configures SubmitBatch
+type BatchOption func(*batch)
+
+type batch struct {
+	key         crypto.Key
+	keySequence *uint32
+}
+
+// BatchSign has SubmitBatch number the transactions itself, which must all
+// be from the same account and not use tickets, and sign them with key.
+// See data.Sign for keySequence.
+func BatchSign(key crypto.Key, keySequence *uint32) BatchOption {
+	return func(b *batch) {
+		b.key = key
+		b.keySequence = keySequence
+	}
+}
+
+// How many times SubmitBatch raises the fee of a transaction the server
+// will neither apply nor queue for its fee, see FeeUrgencyHigh
+const BatchFeeRetries = 3
+
+// Synchronously submit multiple transactions, with a result for each in the
+// same order. They are all sent before any answer is waited for, so must
+// already be signed, unless BatchSign is given.
+func (r *Remote) SubmitBatch(txs []data.Transaction, opts ...BatchOption) ([]*SubmitResult, error) {
+	return r.SubmitBatchContext(context.Background(), txs, opts...)
+}
+
+// SubmitBatchContext is the context aware version of SubmitBatch
+func (r *Remote) SubmitBatchContext(ctx context.Context, txs []data.Transaction, opts ...BatchOption) ([]*SubmitResult, error) {
+	var b batch
+	for _, opt := range opts {
+		opt(&b)
+	}
+	if b.key != nil {
+		return r.submitSequenced(ctx, txs, &b)
+	}
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
 	commands := make([]*SubmitCommand, len(txs))
 	results := make([]*SubmitResult, len(txs))
 	for i := range txs {
@@ -235,44 +1131,137 @@ func (r *Remote) SubmitBatch(txs []data.Transaction) ([]*SubmitResult, error) {
 			Command: newCommand("submit"),
 			TxBlob:  fmt.Sprintf("%X", raw),
 		}
-		r.outgoing <- cmd
+		if err := r.post(ctx, cmd); err != nil {
+			for _, posted := range commands[:i] {
+				r.abandon(posted)
+			}
+			return nil, err
+		}
 		commands[i] = cmd
 	}
-	for i := range commands {
-		<-commands[i].Ready
-		results[i] = commands[i].Result
+	for i, cmd := range commands {
+		if err := r.wait(ctx, cmd); err != nil && ctx.Err() != nil {
+			for _, unanswered := range commands[i+1:] {
+				r.abandon(unanswered)
+			}
+			return nil, err
+		}
+		results[i] = cmd.Result
+	}
+	return results, nil
+}
+
+// submitSequenced submits txs one at a time, each with the sequence after
+// the last one the account used. As ter results are held by the server and
+// may still apply, only a transaction which is rejected outright leaves its
+// sequence to the next, so the account is not left with a gap which would
+// hold up the rest. Any missing Fee or LastLedgerSequence is filled in, see
+// Autofill, and a fee which is too low is raised and the transaction signed
+// and submitted again. On an error the results so far are returned with it.
+func (r *Remote) submitSequenced(ctx context.Context, txs []data.Transaction, b *batch) ([]*SubmitResult, error) {
+	if len(txs) == 0 {
+		return nil, nil
+	}
+	account := txs[0].GetBase().Account
+	for _, tx := range txs {
+		base := tx.GetBase()
+		if !base.Account.Equals(account) {
+			return nil, fmt.Errorf("Batch transactions must all be from %s: %s", account, base.Account)
+		}
+		if base.TicketSequence != nil {
+			return nil, fmt.Errorf("Batch transactions cannot use tickets: %d", *base.TicketSequence)
+		}
+	}
+	info, err := r.AccountInfoContext(ctx, account, "current")
+	if err != nil {
+		return nil, err
+	}
+	if info.AccountData.Sequence == nil {
+		return nil, fmt.Errorf("No sequence for account: %s", account)
+	}
+	next := *info.AccountData.Sequence
+	results := make([]*SubmitResult, len(txs))
+	for i, tx := range txs {
+		base := tx.GetBase()
+		base.Sequence = next
+		if err := r.AutofillContext(ctx, tx, account); err != nil {
+			return results, err
+		}
+		for retry := 0; ; retry++ {
+			if err := data.Sign(tx, b.key, b.keySequence); err != nil {
+				return results, err
+			}
+			result, err := r.SubmitContext(ctx, tx)
+			if err != nil {
+				return results, err
+			}
+			results[i] = result
+			if !result.EngineResult.InsufficientFee() || retry == BatchFeeRetries {
+				break
+			}
+			if err := r.raiseFee(ctx, base, FeeUrgencyHigh+retry); err != nil {
+				return results, err
+			}
+		}
+		if result := results[i].EngineResult; result.Applied() || result.Retry() {
+			next++
+		}
+	}
+	return results, nil
+}
+
+// raiseFee sets the fee of base to the one suggested for urgency, or doubles
+// it if that is no higher
+func (r *Remote) raiseFee(ctx context.Context, base *data.TxBase, urgency int) error {
+	result, err := r.FeeContext(ctx)
+	if err != nil {
+		return err
+	}
+	fee, err := result.SuggestedFee(urgency)
+	if err != nil {
+		return err
 	}
-	return results, nil
+	if !base.Fee.Less(*fee) {
+		if fee, err = base.Fee.Add(base.Fee); err != nil {
+			return err
+		}
+	}
+	base.Fee = *fee
+	return nil
+}
+
+// Synchronously requests a page of the ledger state, starting after marker.
+// A limit of zero leaves the page size to the server. The returned Marker
+// is nil once the final page has been read.
+func (r *client) LedgerData(ledger interface{}, marker *data.Hash256, limit int) (*LedgerDataResult, error) {
+	return r.LedgerDataContext(context.Background(), ledger, marker, limit)
 }
 
-// Synchronously gets ledger entries
-func (r *Remote) LedgerData(ledger interface{}, marker *data.Hash256) (*LedgerDataResult, error) {
+// LedgerDataContext is the context aware version of LedgerData
+func (r *client) LedgerDataContext(ctx context.Context, ledger interface{}, marker *data.Hash256, limit int) (*LedgerDataResult, error) {
 	cmd := &LedgerDataCommand{
 		Command: newCommand("ledger_data"),
 		Ledger:  ledger,
 		Marker:  marker,
+		Limit:   limit,
 	}
-	r.outgoing <- cmd
-	<-cmd.Ready
-	if cmd.CommandError != nil {
-		return nil, cmd.CommandError
+	if err := r.send(ctx, cmd); err != nil {
+		return nil, err
 	}
 	return cmd.Result, nil
 }
 
-func (r *Remote) streamLedgerData(ledger interface{}, start, end string, c chan data.LedgerEntrySlice, wg *sync.WaitGroup) {
+func (r *Remote) streamLedgerData(ctx context.Context, ledger interface{}, start, end string, c chan data.LedgerEntrySlice, wg *sync.WaitGroup) {
 	defer wg.Done()
 	first, err := data.NewHash256(start)
 	if err != nil {
-		glog.Errorln(err.Error())
+		r.logger.Errorf("%s", err)
 	}
 	cmd := newBinaryLedgerDataCommand(ledger, first)
 	var br bytes.Reader
 	for ; ; cmd = newBinaryLedgerDataCommand(ledger, cmd.Result.Marker) {
-		r.outgoing <- cmd
-		<-cmd.Ready
-		if cmd.CommandError != nil {
-			glog.Errorln(cmd.Error())
+		if err := r.send(ctx, cmd); err != nil {
+			r.logger.Errorf("%s", err)
 			return
 		}
 		les := make(data.LedgerEntrySlice, 0, len(cmd.Result.State))
@@ -283,20 +1272,22 @@ func (r *Remote) streamLedgerData(ledger interface{}, start, end string, c chan
 			}
 			b, err := hex.DecodeString(state.Data + state.Index)
 			if err != nil {
-				glog.Errorln(err.Error())
+				r.logger.Errorf("%s", err)
 				return
 			}
 			br.Reset(b)
 			le, err := data.ReadLedgerEntry(&br, data.Hash256{})
 			if err != nil {
-				glog.Errorln(err.Error())
-				glog.Errorln(state.Data)
-				glog.Errorln(state.Index)
+				r.logger.Errorf("%s: %s %s", err, state.Data, state.Index)
 				continue
 			}
 			les = append(les, le)
 		}
-		c <- les
+		select {
+		case c <- les:
+		case <-ctx.Done():
+			return
+		}
 		if cmd.Result.Marker == nil || done {
 			return
 		}
@@ -305,13 +1296,19 @@ func (r *Remote) streamLedgerData(ledger interface{}, start, end string, c chan
 
 // Asynchronously retrieve all data for a ledger using the binary form
 func (r *Remote) StreamLedgerData(ledger interface{}) chan data.LedgerEntrySlice {
+	return r.StreamLedgerDataContext(context.Background(), ledger)
+}
+
+// StreamLedgerDataContext is the context aware version of StreamLedgerData.
+// The channel is closed early when ctx is done.
+func (r *Remote) StreamLedgerDataContext(ctx context.Context, ledger interface{}) chan data.LedgerEntrySlice {
 	c := make(chan data.LedgerEntrySlice, 100)
 	wg := &sync.WaitGroup{}
 	for i := 0; i < 16; i++ {
 		wg.Add(1)
 		start := fmt.Sprintf("%X%s", i, strings.Repeat("0", 63))
 		end := fmt.Sprintf("%X%s", i, strings.Repeat("F", 63))
-		go r.streamLedgerData(ledger, start, end, c, wg)
+		go r.streamLedgerData(ctx, ledger, start, end, c, wg)
 	}
 	go func() {
 		wg.Wait()
@@ -320,86 +1317,120 @@ func (r *Remote) StreamLedgerData(ledger interface{}) chan data.LedgerEntrySlice
 	return c
 }
 
-// Synchronously gets a single ledger
-func (r *Remote) Ledger(ledger interface{}, transactions bool) (*LedgerResult, error) {
+// Synchronously requests a ledger. The ledger can be identified by a data.Hash256,
+// a sequence, "validated", "closed" or "current".
+func (r *client) Ledger(ledger interface{}, opts ...LedgerOption) (*LedgerResult, error) {
+	return r.LedgerContext(context.Background(), ledger, opts...)
+}
+
+// LedgerContext is the context aware version of Ledger
+func (r *client) LedgerContext(ctx context.Context, ledger interface{}, opts ...LedgerOption) (*LedgerResult, error) {
 	cmd := &LedgerCommand{
-		Command:      newCommand("ledger"),
-		LedgerIndex:  ledger,
-		Transactions: transactions,
-		Expand:       true,
+		Command: newCommand("ledger"),
+	}
+	switch hash := ledger.(type) {
+	case data.Hash256:
+		cmd.LedgerHash = &hash
+	case *data.Hash256:
+		cmd.LedgerHash = hash
+	default:
+		cmd.LedgerIndex = ledger
 	}
-	r.outgoing <- cmd
-	<-cmd.Ready
-	if cmd.CommandError != nil {
-		return nil, cmd.CommandError
+	for _, opt := range opts {
+		opt(cmd)
+	}
+	if err := r.send(ctx, cmd); err != nil {
+		return nil, err
 	}
 	cmd.Result.Ledger.Transactions.Sort()
 	return cmd.Result, nil
 }
 
-func (r *Remote) LedgerHeader(ledger interface{}) (*LedgerHeaderResult, error) {
+func (r *client) LedgerHeader(ledger interface{}) (*LedgerHeaderResult, error) {
+	return r.LedgerHeaderContext(context.Background(), ledger)
+}
+
+// LedgerHeaderContext is the context aware version of LedgerHeader
+func (r *client) LedgerHeaderContext(ctx context.Context, ledger interface{}) (*LedgerHeaderResult, error) {
 	cmd := &LedgerHeaderCommand{
 		Command: newCommand("ledger_header"),
 		Ledger:  ledger,
 	}
-	r.outgoing <- cmd
-	<-cmd.Ready
-	if cmd.CommandError != nil {
-		return nil, cmd.CommandError
+	if err := r.send(ctx, cmd); err != nil {
+		return nil, err
 	}
 	return cmd.Result, nil
 }
 
-// Synchronously requests paths
-func (r *Remote) RipplePathFind(src, dest data.Account, amount data.Amount, srcCurr *[]data.Currency) (*RipplePathFindResult, error) {
+// Synchronously requests a single snapshot of paths. Pass nil srcCurr to
+// let the server consider every currency src holds.
+func (r *client) RipplePathFind(src, dest data.Account, amount data.Amount, srcCurr []data.Currency) (*RipplePathFindResult, error) {
+	return r.RipplePathFindContext(context.Background(), src, dest, amount, srcCurr)
+}
+
+// RipplePathFindContext is the context aware version of RipplePathFind
+func (r *client) RipplePathFindContext(ctx context.Context, src, dest data.Account, amount data.Amount, srcCurr []data.Currency) (*RipplePathFindResult, error) {
 	cmd := &RipplePathFindCommand{
-		Command:       newCommand("ripple_path_find"),
-		SrcAccount:    src,
-		SrcCurrencies: srcCurr,
-		DestAccount:   dest,
-		DestAmount:    amount,
+		Command:     newCommand("ripple_path_find"),
+		SrcAccount:  src,
+		DestAccount: dest,
+		DestAmount:  amount,
+	}
+	for _, currency := range srcCurr {
+		cmd.SrcCurrencies = append(cmd.SrcCurrencies, SourceCurrency{Currency: currency.Machine()})
 	}
-	r.outgoing <- cmd
-	<-cmd.Ready
-	if cmd.CommandError != nil {
-		return nil, cmd.CommandError
+	if err := r.send(ctx, cmd); err != nil {
+		return nil, err
 	}
 	return cmd.Result, nil
 }
 
 // Synchronously requests account info
-func (r *Remote) AccountInfo(a data.Account) (*AccountInfoResult, error) {
+// ledgerIndex can be a ledger sequence, "validated", "closed",
+// "current" or nil for the current ledger.
+func (r *client) AccountInfo(a data.Account, ledgerIndex interface{}) (*AccountInfoResult, error) {
+	return r.AccountInfoContext(context.Background(), a, ledgerIndex)
+}
+
+// AccountInfoContext is the context aware version of AccountInfo
+func (r *client) AccountInfoContext(ctx context.Context, a data.Account, ledgerIndex interface{}) (*AccountInfoResult, error) {
 	cmd := &AccountInfoCommand{
-		Command: newCommand("account_info"),
-		Account: a,
+		Command:     newCommand("account_info"),
+		Account:     a,
+		LedgerIndex: ledgerIndex,
 	}
-	r.outgoing <- cmd
-	<-cmd.Ready
-	if cmd.CommandError != nil {
-		return nil, cmd.CommandError
+	if err := r.send(ctx, cmd); err != nil {
+		return nil, err
 	}
 	return cmd.Result, nil
 }
 
-// Synchronously requests account line info
-func (r *Remote) AccountLines(account data.Account, ledgerIndex interface{}) (*AccountLinesResult, error) {
+// Synchronously requests account line info. If peer is not nil,
+// only the trust lines between account and peer are returned.
+// Will call `account_lines` multiple times, if a marker is returned.
+func (r *client) AccountLines(account data.Account, peer *data.Account, ledgerIndex interface{}) (*AccountLinesResult, error) {
+	return r.AccountLinesContext(context.Background(), account, peer, ledgerIndex)
+}
+
+// AccountLinesContext is the context aware version of AccountLines
+func (r *client) AccountLinesContext(ctx context.Context, account data.Account, peer *data.Account, ledgerIndex interface{}) (*AccountLinesResult, error) {
 	var (
 		lines  data.AccountLineSlice
-		marker *data.Hash256
+		marker interface{}
 	)
 	for {
 		cmd := &AccountLinesCommand{
 			Command:     newCommand("account_lines"),
 			Account:     account,
+			Peer:        peer,
 			Limit:       400,
 			Marker:      marker,
 			LedgerIndex: ledgerIndex,
 		}
-		r.outgoing <- cmd
-		<-cmd.Ready
+		if err := r.send(ctx, cmd); err != nil {
+			return nil, err
+		}
 		switch {
-		case cmd.CommandError != nil:
-			return nil, cmd.CommandError
 		case cmd.Result.Marker != nil:
 			lines = append(lines, cmd.Result.Lines...)
 			marker = cmd.Result.Marker
@@ -415,7 +1446,12 @@ func (r *Remote) AccountLines(account data.Account, ledgerIndex interface{}) (*A
 }
 
 // Synchronously requests account offers
-func (r *Remote) AccountOffers(account data.Account, ledgerIndex interface{}) (*AccountOffersResult, error) {
+func (r *client) AccountOffers(account data.Account, ledgerIndex interface{}) (*AccountOffersResult, error) {
+	return r.AccountOffersContext(context.Background(), account, ledgerIndex)
+}
+
+// AccountOffersContext is the context aware version of AccountOffers
+func (r *client) AccountOffersContext(ctx context.Context, account data.Account, ledgerIndex interface{}) (*AccountOffersResult, error) {
 	var (
 		offers data.AccountOfferSlice
 		marker *data.Hash256
@@ -428,11 +1464,10 @@ func (r *Remote) AccountOffers(account data.Account, ledgerIndex interface{}) (*
 			Marker:      marker,
 			LedgerIndex: ledgerIndex,
 		}
-		r.outgoing <- cmd
-		<-cmd.Ready
+		if err := r.send(ctx, cmd); err != nil {
+			return nil, err
+		}
 		switch {
-		case cmd.CommandError != nil:
-			return nil, cmd.CommandError
 		case cmd.Result.Marker != nil:
 			offers = append(offers, cmd.Result.Offers...)
 			marker = cmd.Result.Marker
@@ -447,19 +1482,149 @@ func (r *Remote) AccountOffers(account data.Account, ledgerIndex interface{}) (*
 	}
 }
 
-func (r *Remote) BookOffers(taker data.Account, ledgerIndex interface{}, pays, gets data.Asset) (*BookOffersResult, error) {
+// Synchronously requests the currencies account can send and receive,
+// based on its trust lines
+func (r *client) AccountCurrencies(account data.Account) (*AccountCurrenciesResult, error) {
+	return r.AccountCurrenciesContext(context.Background(), account)
+}
+
+// AccountCurrenciesContext is the context aware version of AccountCurrencies
+func (r *client) AccountCurrenciesContext(ctx context.Context, account data.Account) (*AccountCurrenciesResult, error) {
+	cmd := &AccountCurrenciesCommand{
+		Command: newCommand("account_currencies"),
+		Account: account,
+	}
+	if err := r.send(ctx, cmd); err != nil {
+		return nil, err
+	}
+	return cmd.Result, nil
+}
+
+// Synchronously checks the DefaultRipple and NoRipple flags of account and
+// its trust lines. role is either "gateway" or "user". If transactions is
+// true, the result includes the transactions which would fix the problems.
+// These have their Fee and Sequence filled in, ready to be signed.
+func (r *client) NoRippleCheck(account data.Account, role string, transactions bool, limit int) (*NoRippleCheckResult, error) {
+	return r.NoRippleCheckContext(context.Background(), account, role, transactions, limit)
+}
+
+// NoRippleCheckContext is the context aware version of NoRippleCheck
+func (r *client) NoRippleCheckContext(ctx context.Context, account data.Account, role string, transactions bool, limit int) (*NoRippleCheckResult, error) {
+	cmd := &NoRippleCheckCommand{
+		Command:      newCommand("noripple_check"),
+		Account:      account,
+		Role:         role,
+		Transactions: transactions,
+		Limit:        limit,
+	}
+	if err := r.send(ctx, cmd); err != nil {
+		return nil, err
+	}
+	return cmd.Result, nil
+}
+
+// Synchronously checks whether src may send payments to dst, which only
+// matters if dst has DepositAuth enabled. ledger can be a ledger sequence,
+// "validated", "closed", "current" or nil for the current ledger.
+func (r *client) DepositAuthorized(src, dst data.Account, ledger interface{}) (*DepositAuthorizedResult, error) {
+	return r.DepositAuthorizedContext(context.Background(), src, dst, ledger)
+}
+
+// DepositAuthorizedContext is the context aware version of DepositAuthorized
+func (r *client) DepositAuthorizedContext(ctx context.Context, src, dst data.Account, ledger interface{}) (*DepositAuthorizedResult, error) {
+	cmd := &DepositAuthorizedCommand{
+		Command:            newCommand("deposit_authorized"),
+		SourceAccount:      src,
+		DestinationAccount: dst,
+		LedgerIndex:        ledger,
+	}
+	if err := r.send(ctx, cmd); err != nil {
+		return nil, err
+	}
+	return cmd.Result, nil
+}
+
+// Synchronously requests a single ledger entry, chosen by selector.
+// ledger can be a ledger sequence, "validated", "closed", "current"
+// or nil for the current ledger.
+func (r *client) LedgerEntry(ledger interface{}, selector LedgerEntrySelector) (*LedgerEntryResult, error) {
+	return r.LedgerEntryContext(context.Background(), ledger, selector)
+}
+
+// LedgerEntryContext is the context aware version of LedgerEntry
+func (r *client) LedgerEntryContext(ctx context.Context, ledger interface{}, selector LedgerEntrySelector) (*LedgerEntryResult, error) {
+	cmd := &LedgerEntryCommand{
+		Command:     newCommand("ledger_entry"),
+		LedgerIndex: ledger,
+	}
+	selector(cmd)
+	if err := r.send(ctx, cmd); err != nil {
+		return nil, err
+	}
+	return cmd.Result, nil
+}
+
+// Synchronously requests a page of the ledger entries owned by account.
+// objType filters by the type of entry, for example "offer", "state",
+// "escrow", "check" or "signer_list", and an empty objType returns all of
+// them. marker is the Marker of the previous page, or nil for the first page.
+// A limit of zero leaves the page size to the server.
+func (r *client) AccountObjects(account data.Account, objType string, marker interface{}, limit int) (*AccountObjectsResult, error) {
+	return r.AccountObjectsContext(context.Background(), account, objType, marker, limit)
+}
+
+// AccountObjectsContext is the context aware version of AccountObjects
+func (r *client) AccountObjectsContext(ctx context.Context, account data.Account, objType string, marker interface{}, limit int) (*AccountObjectsResult, error) {
+	cmd := &AccountObjectsCommand{
+		Command: newCommand("account_objects"),
+		Account: account,
+		Type:    objType,
+		Marker:  marker,
+		Limit:   limit,
+	}
+	if err := r.send(ctx, cmd); err != nil {
+		return nil, err
+	}
+	return cmd.Result, nil
+}
+
+// Synchronously requests the obligations of a gateway. The balances of
+// hotwallets are reported separately and excluded from the obligations.
+func (r *client) GatewayBalances(account data.Account, hotwallets []data.Account) (*GatewayBalancesResult, error) {
+	return r.GatewayBalancesContext(context.Background(), account, hotwallets)
+}
+
+// GatewayBalancesContext is the context aware version of GatewayBalances
+func (r *client) GatewayBalancesContext(ctx context.Context, account data.Account, hotwallets []data.Account) (*GatewayBalancesResult, error) {
+	cmd := &GatewayBalancesCommand{
+		Command:   newCommand("gateway_balances"),
+		Account:   account,
+		HotWallet: hotwallets,
+	}
+	if err := r.send(ctx, cmd); err != nil {
+		return nil, err
+	}
+	return cmd.Result, nil
+}
+
+// Synchronously requests the offers in the order book between pays and gets
+func (r *client) BookOffers(pays, gets data.Asset, opts ...BookOption) (*BookOffersResult, error) {
+	return r.BookOffersContext(context.Background(), pays, gets, opts...)
+}
+
+// BookOffersContext is the context aware version of BookOffers
+func (r *client) BookOffersContext(ctx context.Context, pays, gets data.Asset, opts ...BookOption) (*BookOffersResult, error) {
 	cmd := &BookOffersCommand{
-		Command:     newCommand("book_offers"),
-		LedgerIndex: ledgerIndex,
-		Taker:       taker,
-		TakerPays:   pays,
-		TakerGets:   gets,
-		Limit:       5000, // Marker not implemented....
+		Command:   newCommand("book_offers"),
+		TakerPays: pays,
+		TakerGets: gets,
+		Limit:     5000, // Marker not implemented....
+	}
+	for _, opt := range opts {
+		opt(cmd)
 	}
-	r.outgoing <- cmd
-	<-cmd.Ready
-	if cmd.CommandError != nil {
-		return nil, cmd.CommandError
+	if err := r.send(ctx, cmd); err != nil {
+		return nil, err
 	}
 	return cmd.Result, nil
 }
@@ -467,6 +1632,11 @@ func (r *Remote) BookOffers(taker data.Account, ledgerIndex interface{}, pays, g
 // Synchronously subscribe to streams and receive a confirmation message
 // Streams are recived asynchronously over the Incoming channel
 func (r *Remote) Subscribe(ledger, transactions, transactionsProposed, server bool) (*SubscribeResult, error) {
+	return r.SubscribeContext(context.Background(), ledger, transactions, transactionsProposed, server)
+}
+
+// SubscribeContext is the context aware version of Subscribe
+func (r *Remote) SubscribeContext(ctx context.Context, ledger, transactions, transactionsProposed, server bool) (*SubscribeResult, error) {
 	streams := []string{}
 	if ledger {
 		streams = append(streams, "ledger")
@@ -484,10 +1654,8 @@ func (r *Remote) Subscribe(ledger, transactions, transactionsProposed, server bo
 		Command: newCommand("subscribe"),
 		Streams: streams,
 	}
-	r.outgoing <- cmd
-	<-cmd.Ready
-	if cmd.CommandError != nil {
-		return nil, cmd.CommandError
+	if err := r.send(ctx, cmd); err != nil {
+		return nil, err
 	}
 
 	if ledger && cmd.Result.LedgerStreamMsg == nil {
@@ -499,62 +1667,411 @@ func (r *Remote) Subscribe(ledger, transactions, transactionsProposed, server bo
 	return cmd.Result, nil
 }
 
+// Synchronously subscribe to the transactions affecting accounts, and to
+// the proposed transactions affecting accountsProposed. Either may be empty.
+// Streamed transactions are received asynchronously over the Incoming channel
+// as *TransactionStreamMsg
+func (r *Remote) SubscribeAccounts(accounts, accountsProposed []data.Account) (*SubscribeResult, error) {
+	return r.SubscribeAccountsContext(context.Background(), accounts, accountsProposed)
+}
+
+// SubscribeAccountsContext is the context aware version of SubscribeAccounts
+func (r *Remote) SubscribeAccountsContext(ctx context.Context, accounts, accountsProposed []data.Account) (*SubscribeResult, error) {
+	if len(accounts) == 0 && len(accountsProposed) == 0 {
+		return nil, fmt.Errorf("No accounts to subscribe to")
+	}
+	cmd := &SubscribeCommand{
+		Command:          newCommand("subscribe"),
+		Accounts:         accounts,
+		AccountsProposed: accountsProposed,
+	}
+	if err := r.send(ctx, cmd); err != nil {
+		return nil, err
+	}
+	return cmd.Result, nil
+}
+
+// Synchronously subscribe to the validations stream. Validations are
+// received asynchronously over the Incoming channel as *ValidationStreamMsg
+func (r *Remote) SubscribeValidations() (*SubscribeResult, error) {
+	return r.SubscribeValidationsContext(context.Background())
+}
+
+// SubscribeValidationsContext is the context aware version of SubscribeValidations
+func (r *Remote) SubscribeValidationsContext(ctx context.Context) (*SubscribeResult, error) {
+	cmd := &SubscribeCommand{
+		Command: newCommand("subscribe"),
+		Streams: []string{"validations"},
+	}
+	if err := r.send(ctx, cmd); err != nil {
+		return nil, err
+	}
+	return cmd.Result, nil
+}
+
+// Synchronously subscribe to the consensus stream. Changes of consensus phase
+// are received asynchronously over the Incoming channel as *ConsensusStreamMsg
+func (r *Remote) SubscribeConsensus() (*SubscribeResult, error) {
+	return r.SubscribeConsensusContext(context.Background())
+}
+
+// SubscribeConsensusContext is the context aware version of SubscribeConsensus
+func (r *Remote) SubscribeConsensusContext(ctx context.Context) (*SubscribeResult, error) {
+	cmd := &SubscribeCommand{
+		Command: newCommand("subscribe"),
+		Streams: []string{"consensus"},
+	}
+	if err := r.send(ctx, cmd); err != nil {
+		return nil, err
+	}
+	return cmd.Result, nil
+}
+
+// Synchronously subscribe to the manifests stream. Manifests are
+// received asynchronously over the Incoming channel as *ManifestStreamMsg
+func (r *Remote) SubscribeManifests() (*SubscribeResult, error) {
+	return r.SubscribeManifestsContext(context.Background())
+}
+
+// SubscribeManifestsContext is the context aware version of SubscribeManifests
+func (r *Remote) SubscribeManifestsContext(ctx context.Context) (*SubscribeResult, error) {
+	cmd := &SubscribeCommand{
+		Command: newCommand("subscribe"),
+		Streams: []string{"manifests"},
+	}
+	if err := r.send(ctx, cmd); err != nil {
+		return nil, err
+	}
+	return cmd.Result, nil
+}
+
 type OrderBookSubscription struct {
+	TakerGets data.Asset    `json:"taker_gets"`
+	TakerPays data.Asset    `json:"taker_pays"`
+	Taker     *data.Account `json:"taker,omitempty"`
+	Snapshot  bool          `json:"snapshot"`
+	Both      bool          `json:"both"`
+}
+
+type OrderBookUnsubscription struct {
 	TakerGets data.Asset `json:"taker_gets"`
 	TakerPays data.Asset `json:"taker_pays"`
-	Snapshot  bool       `json:"snapshot"`
 	Both      bool       `json:"both"`
 }
 
 func (r *Remote) SubscribeOrderBooks(books []OrderBookSubscription) (*SubscribeResult, error) {
+	return r.SubscribeOrderBooksContext(context.Background(), books)
+}
+
+// SubscribeOrderBooksContext is the context aware version of SubscribeOrderBooks
+func (r *Remote) SubscribeOrderBooksContext(ctx context.Context, books []OrderBookSubscription) (*SubscribeResult, error) {
 	cmd := &SubscribeCommand{
 		Command: newCommand("subscribe"),
 		Streams: []string{"ledger", "server"},
 		Books:   books,
 	}
-	r.outgoing <- cmd
-	<-cmd.Ready
-	if cmd.CommandError != nil {
-		return nil, cmd.CommandError
+	if err := r.send(ctx, cmd); err != nil {
+		return nil, err
 	}
 	return cmd.Result, nil
 }
 
-func (r *Remote) Fee() (*FeeResult, error) {
+// Synchronously unsubscribe from streams, the transactions affecting accounts
+// and order books. Once confirmed the server stops sending the matching
+// messages, and they are no longer renewed after reconnecting.
+func (r *Remote) Unsubscribe(streams []string, accounts []data.Account, books []OrderBookUnsubscription) error {
+	return r.UnsubscribeContext(context.Background(), streams, accounts, books)
+}
+
+// UnsubscribeContext is the context aware version of Unsubscribe
+func (r *Remote) UnsubscribeContext(ctx context.Context, streams []string, accounts []data.Account, books []OrderBookUnsubscription) error {
+	cmd := &UnsubscribeCommand{
+		Command:  newCommand("unsubscribe"),
+		Streams:  streams,
+		Accounts: accounts,
+		Books:    books,
+	}
+	return r.send(ctx, cmd)
+}
+
+// Synchronously unsubscribe from order books. Book updates are streamed as
+// transactions, so use OfferChanges to follow them.
+func (r *Remote) UnsubscribeOrderBooks(books []OrderBookUnsubscription) error {
+	return r.UnsubscribeOrderBooksContext(context.Background(), books)
+}
+
+// UnsubscribeOrderBooksContext is the context aware version of UnsubscribeOrderBooks
+func (r *Remote) UnsubscribeOrderBooksContext(ctx context.Context, books []OrderBookUnsubscription) error {
+	return r.UnsubscribeContext(ctx, nil, nil, books)
+}
+
+func (r *client) Fee() (*FeeResult, error) {
+	return r.FeeContext(context.Background())
+}
+
+// FeeContext is the context aware version of Fee
+func (r *client) FeeContext(ctx context.Context) (*FeeResult, error) {
 	cmd := &FeeCommand{
 		Command: newCommand("fee"),
 	}
-	r.outgoing <- cmd
-	<-cmd.Ready
-	if cmd.CommandError != nil {
-		return nil, cmd.CommandError
+	if err := r.send(ctx, cmd); err != nil {
+		return nil, err
+	}
+	return cmd.Result, nil
+}
+
+// Synchronously requests a human readable summary of the server's status
+func (r *client) ServerInfo() (*ServerInfoResult, error) {
+	return r.ServerInfoContext(context.Background())
+}
+
+// ServerInfoContext is the context aware version of ServerInfo
+func (r *client) ServerInfoContext(ctx context.Context) (*ServerInfoResult, error) {
+	cmd := &ServerInfoCommand{
+		Command: newCommand("server_info"),
+	}
+	if err := r.send(ctx, cmd); err != nil {
+		return nil, err
+	}
+	return cmd.Result, nil
+}
+
+// Synchronously requests a machine readable summary of the server's status
+func (r *client) ServerState() (*ServerStateResult, error) {
+	return r.ServerStateContext(context.Background())
+}
+
+// ServerStateContext is the context aware version of ServerState
+func (r *client) ServerStateContext(ctx context.Context) (*ServerStateResult, error) {
+	cmd := &ServerStateCommand{
+		Command: newCommand("server_state"),
+	}
+	if err := r.send(ctx, cmd); err != nil {
+		return nil, err
+	}
+	return cmd.Result, nil
+}
+
+// Synchronously requests the status of the amendment with name or id, or of
+// every amendment the server knows if name is empty
+func (r *client) Feature(name string) (*FeatureResult, error) {
+	return r.FeatureContext(context.Background(), name)
+}
+
+// FeatureContext is the context aware version of Feature
+func (r *client) FeatureContext(ctx context.Context, name string) (*FeatureResult, error) {
+	cmd := &FeatureCommand{
+		Command: newCommand("feature"),
+		Feature: name,
+	}
+	if err := r.send(ctx, cmd); err != nil {
+		return nil, err
+	}
+	return cmd.Result, nil
+}
+
+// Synchronously requests a page of the payment channels account is the
+// source of, only those to dst if it is not nil. marker is the Marker of the
+// previous page, or nil for the first page.
+func (r *client) AccountChannels(account data.Account, dst *data.Account, marker interface{}) (*AccountChannelsResult, error) {
+	return r.AccountChannelsContext(context.Background(), account, dst, marker)
+}
+
+// AccountChannelsContext is the context aware version of AccountChannels
+func (r *client) AccountChannelsContext(ctx context.Context, account data.Account, dst *data.Account, marker interface{}) (*AccountChannelsResult, error) {
+	cmd := &AccountChannelsCommand{
+		Command:            newCommand("account_channels"),
+		Account:            account,
+		DestinationAccount: dst,
+		Marker:             marker,
+	}
+	if err := r.send(ctx, cmd); err != nil {
+		return nil, err
+	}
+	return cmd.Result, nil
+}
+
+// Synchronously requests a page of the offers to buy the NFToken with id.
+// marker is the Marker of the previous page, or nil for the first page.
+func (r *client) NFTBuyOffers(id data.Hash256, marker interface{}) (*NFTOffersResult, error) {
+	return r.NFTBuyOffersContext(context.Background(), id, marker)
+}
+
+// NFTBuyOffersContext is the context aware version of NFTBuyOffers
+func (r *client) NFTBuyOffersContext(ctx context.Context, id data.Hash256, marker interface{}) (*NFTOffersResult, error) {
+	return r.nftOffers(ctx, "nft_buy_offers", id, marker)
+}
+
+// Synchronously requests a page of the offers to sell the NFToken with id.
+// marker is the Marker of the previous page, or nil for the first page.
+func (r *client) NFTSellOffers(id data.Hash256, marker interface{}) (*NFTOffersResult, error) {
+	return r.NFTSellOffersContext(context.Background(), id, marker)
+}
+
+// NFTSellOffersContext is the context aware version of NFTSellOffers
+func (r *client) NFTSellOffersContext(ctx context.Context, id data.Hash256, marker interface{}) (*NFTOffersResult, error) {
+	return r.nftOffers(ctx, "nft_sell_offers", id, marker)
+}
+
+func (r *client) nftOffers(ctx context.Context, command string, id data.Hash256, marker interface{}) (*NFTOffersResult, error) {
+	cmd := &NFTOffersCommand{
+		Command:   newCommand(command),
+		NFTokenID: id,
+		Marker:    marker,
+	}
+	if err := r.send(ctx, cmd); err != nil {
+		return nil, err
+	}
+	return cmd.Result, nil
+}
+
+// Synchronously requests what is known of the NFToken with id. Only Clio
+// servers answer nft_info, rippled does not. ledger can be a ledger
+// sequence, "validated", "closed", "current" or nil for the server default.
+func (r *client) NFTInfo(id data.Hash256, ledger interface{}) (*NFTInfoResult, error) {
+	return r.NFTInfoContext(context.Background(), id, ledger)
+}
+
+// NFTInfoContext is the context aware version of NFTInfo
+func (r *client) NFTInfoContext(ctx context.Context, id data.Hash256, ledger interface{}) (*NFTInfoResult, error) {
+	cmd := &NFTInfoCommand{
+		Command:     newCommand("nft_info"),
+		NFTokenID:   id,
+		LedgerIndex: ledger,
+	}
+	if err := r.send(ctx, cmd); err != nil {
+		return nil, err
+	}
+	return cmd.Result, nil
+}
+
+// Low level: sends method with params, which are not checked, and returns
+// the result as it was received. This is for commands which the library
+// does not otherwise support, such as the admin commands stop or
+// validation_create, which need an admin connection.
+func (r *client) AdminCommand(method string, params map[string]interface{}) (json.RawMessage, error) {
+	return r.AdminCommandContext(context.Background(), method, params)
+}
+
+// AdminCommandContext is the context aware version of AdminCommand
+func (r *client) AdminCommandContext(ctx context.Context, method string, params map[string]interface{}) (json.RawMessage, error) {
+	cmd := &AdminCommand{
+		Command: newCommand(method),
+		Params:  params,
+	}
+	if err := r.send(ctx, cmd); err != nil {
+		return nil, err
+	}
+	return cmd.Result, nil
+}
+
+// Closes the open ledger of a server in stand-alone mode, and returns the
+// index of the ledger it opens after it. This is an admin command.
+func (r *client) LedgerAccept() (*LedgerAcceptResult, error) {
+	return r.LedgerAcceptContext(context.Background())
+}
+
+// LedgerAcceptContext is the context aware version of LedgerAccept
+func (r *client) LedgerAcceptContext(ctx context.Context) (*LedgerAcceptResult, error) {
+	cmd := &LedgerAcceptCommand{
+		Command: newCommand("ledger_accept"),
+	}
+	if err := r.send(ctx, cmd); err != nil {
+		return nil, err
+	}
+	return cmd.Result, nil
+}
+
+// Asks the server for a new wallet of keyType, which is "secp256k1" or
+// "ed25519". This is an admin command. crypto.Propose does the same locally.
+func (r *client) WalletPropose(keyType string) (*crypto.Wallet, error) {
+	return r.WalletProposeContext(context.Background(), keyType)
+}
+
+// WalletProposeContext is the context aware version of WalletPropose
+func (r *client) WalletProposeContext(ctx context.Context, keyType string) (*crypto.Wallet, error) {
+	cmd := &WalletProposeCommand{
+		Command: newCommand("wallet_propose"),
+		KeyType: keyType,
+	}
+	if err := r.send(ctx, cmd); err != nil {
+		return nil, err
+	}
+	return cmd.Result, nil
+}
+
+// Asks the server to sign a claim for amount drops from channel.
+// This sends secret to the server, so should only be used with a
+// trusted server. data.SignClaim does the same locally.
+func (r *client) ChannelAuthorize(channel data.Hash256, amount data.Value, secret string) (*ChannelAuthorizeResult, error) {
+	return r.ChannelAuthorizeContext(context.Background(), channel, amount, secret)
+}
+
+// ChannelAuthorizeContext is the context aware version of ChannelAuthorize
+func (r *client) ChannelAuthorizeContext(ctx context.Context, channel data.Hash256, amount data.Value, secret string) (*ChannelAuthorizeResult, error) {
+	cmd := &ChannelAuthorizeCommand{
+		Command:   newCommand("channel_authorize"),
+		ChannelID: channel,
+		Amount:    &amount,
+		Secret:    secret,
+	}
+	// rippled assumes secp256k1 unless told otherwise
+	if _, keyType, err := data.ParseSeed(secret); err == nil && keyType == data.Ed25519 {
+		cmd.KeyType = "ed25519"
+	}
+	if err := r.send(ctx, cmd); err != nil {
+		return nil, err
+	}
+	return cmd.Result, nil
+}
+
+// Asks the server to verify a claim signature for amount drops from channel.
+// data.CheckClaimSignature does the same locally.
+func (r *client) ChannelVerify(channel data.Hash256, amount data.Value, publicKey data.PublicKey, signature data.VariableLength) (*ChannelVerifyResult, error) {
+	return r.ChannelVerifyContext(context.Background(), channel, amount, publicKey, signature)
+}
+
+// ChannelVerifyContext is the context aware version of ChannelVerify
+func (r *client) ChannelVerifyContext(ctx context.Context, channel data.Hash256, amount data.Value, publicKey data.PublicKey, signature data.VariableLength) (*ChannelVerifyResult, error) {
+	cmd := &ChannelVerifyCommand{
+		Command:   newCommand("channel_verify"),
+		ChannelID: channel,
+		Amount:    &amount,
+		PublicKey: publicKey,
+		Signature: signature,
+	}
+	if err := r.send(ctx, cmd); err != nil {
+		return nil, err
 	}
 	return cmd.Result, nil
 }
 
 // readPump reads from the websocket and sends to inbound channel.
 // Expects to receive PONGs at specified interval, or logs an error and returns.
-func (r *Remote) readPump(inbound chan<- []byte) {
-	r.ws.SetReadDeadline(time.Now().Add(pongWait))
-	r.ws.SetPongHandler(func(string) error { r.ws.SetReadDeadline(time.Now().Add(pongWait)); return nil })
+func (r *Remote) readPump(ws *websocket.Conn, inbound chan<- []byte) {
+	ws.SetReadDeadline(time.Now().Add(pongWait))
+	ws.SetPongHandler(func(string) error { ws.SetReadDeadline(time.Now().Add(pongWait)); return nil })
 	for {
-		_, message, err := r.ws.ReadMessage()
+		_, message, err := ws.ReadMessage()
 		if err != nil {
-			glog.Errorln(err)
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Timeout() {
+				r.stateChanged(PongTimeout)
+			}
+			r.logger.Errorf("%s", err)
 			return
 		}
-		if glog.V(2) {
-			glog.Infoln(dump(message))
-		}
-		r.ws.SetReadDeadline(time.Now().Add(pongWait))
+		r.logger.Debugf("%s", dumped(message))
+		ws.SetReadDeadline(time.Now().Add(pongWait))
 		inbound <- message
 	}
 }
 
 // Consumes from the outbound channel and sends them over the websocket.
 // Also sends PING messages at the specified interval.
-// Returns when outbound channel is closed, or an error is encountered.
-func (r *Remote) writePump(outbound <-chan interface{}) {
+// Returns when outbound channel is closed, after sending a close frame, or
+// an error is encountered, which is returned.
+func (r *Remote) writePump(ws *websocket.Conn, outbound <-chan interface{}) error {
 	ticker := time.NewTicker(pingPeriod)
 	defer ticker.Stop()
 
@@ -564,29 +2081,27 @@ func (r *Remote) writePump(outbound <-chan interface{}) {
 		// An outbound message is available to send
 		case message, ok := <-outbound:
 			if !ok {
-				r.ws.WriteMessage(websocket.CloseMessage, []byte{})
-				return
+				closing := websocket.FormatCloseMessage(websocket.CloseNormalClosure, "")
+				return ws.WriteControl(websocket.CloseMessage, closing, time.Now().Add(writeWait))
 			}
 
 			b, err := json.Marshal(message)
 			if err != nil {
 				// Outbound message cannot be JSON serialized (log it and continue)
-				glog.Errorln(err)
+				r.logger.Errorf("%s", err)
 				continue
 			}
-			if glog.V(2) {
-				glog.Infoln(dump(b))
-			}
-			if err := r.ws.WriteMessage(websocket.TextMessage, b); err != nil {
-				glog.Errorln(err)
-				return
+			r.logger.Debugf("%s", dumped(b))
+			if err := ws.WriteMessage(websocket.TextMessage, b); err != nil {
+				r.logger.Errorf("%s", err)
+				return err
 			}
 
 		// Time to send a ping
 		case <-ticker.C:
-			if err := r.ws.WriteMessage(websocket.PingMessage, []byte{}); err != nil {
-				glog.Errorln(err)
-				return
+			if err := ws.WriteMessage(websocket.PingMessage, []byte{}); err != nil {
+				r.logger.Errorf("%s", err)
+				return err
 			}
 		}
 	}
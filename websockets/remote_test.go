@@ -0,0 +1,911 @@
+package websockets
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/rubblelabs/ripple/data"
+	. "gopkg.in/check.v1"
+)
+
+type RemoteSuite struct{}
+
+var _ = Suite(&RemoteSuite{})
+
+func newTestServer(c *C, handlers ...func(*websocket.Conn)) *httptest.Server {
+	return httptest.NewServer(newTestHandler(c, handlers...))
+}
+
+// Serves each connection with the next handler in turn
+func newTestHandler(c *C, handlers ...func(*websocket.Conn)) http.Handler {
+	var upgrader websocket.Upgrader
+	conns := make(chan func(*websocket.Conn), len(handlers))
+	for _, h := range handlers {
+		conns <- h
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		ws, err := upgrader.Upgrade(w, req, nil)
+		c.Assert(err, IsNil)
+		defer ws.Close()
+		select {
+		case h := <-conns:
+			h(ws)
+		default:
+		}
+	})
+}
+
+func readRequest(c *C, ws *websocket.Conn) map[string]interface{} {
+	var request map[string]interface{}
+	c.Assert(ws.ReadJSON(&request), IsNil)
+	return request
+}
+
+func (s *RemoteSuite) TestReconnectResendsPending(c *C) {
+	ids := make(chan interface{}, 2)
+	server := newTestServer(c,
+		func(ws *websocket.Conn) {
+			// Drop the connection without answering
+			ids <- readRequest(c, ws)["id"]
+		},
+		func(ws *websocket.Conn) {
+			request := readRequest(c, ws)
+			ids <- request["id"]
+			c.Assert(ws.WriteJSON(map[string]interface{}{
+				"id":     request["id"],
+				"status": "success",
+				"type":   "response",
+				"result": map[string]interface{}{"expected_ledger_size": "24"},
+			}), IsNil)
+			ws.ReadMessage() // Wait for the client to hang up
+		},
+	)
+	defer server.Close()
+
+	r, err := NewRemote(strings.Replace(server.URL, "http", "ws", 1), RemoteReconnect(ReconnectPolicy{
+		MaxRetries: 3,
+		BaseDelay:  10 * time.Millisecond,
+	}))
+	c.Assert(err, IsNil)
+	defer r.Close()
+
+	result, err := r.Fee()
+	c.Assert(err, IsNil)
+	c.Assert(result.ExpectedLedgerSize, Equals, uint32(24))
+	c.Assert(<-ids, Equals, <-ids)
+}
+
+func (s *RemoteSuite) TestNoReconnectFailsPending(c *C) {
+	server := newTestServer(c, func(ws *websocket.Conn) {
+		readRequest(c, ws)
+	})
+	defer server.Close()
+
+	r, err := NewRemote(strings.Replace(server.URL, "http", "ws", 1))
+	c.Assert(err, IsNil)
+	defer r.Close()
+
+	_, err = r.Fee()
+	c.Assert(err, ErrorMatches, ".*Connection Closed.*")
+}
+
+func (s *RemoteSuite) TestCommandIds(c *C) {
+	const senders = 20
+	ids := make(chan float64, senders)
+	server := newTestServer(c, func(ws *websocket.Conn) {
+		for {
+			var request map[string]interface{}
+			if ws.ReadJSON(&request) != nil {
+				return
+			}
+			ids <- request["id"].(float64)
+			c.Assert(ws.WriteJSON(map[string]interface{}{
+				"id":     request["id"],
+				"status": "success",
+				"type":   "response",
+				"result": map[string]interface{}{},
+			}), IsNil)
+		}
+	})
+	defer server.Close()
+
+	r, err := NewRemote(strings.Replace(server.URL, "http", "ws", 1))
+	c.Assert(err, IsNil)
+	defer r.Close()
+
+	// Each id is used once, whichever sender gets it
+	var wg sync.WaitGroup
+	for i := 0; i < senders; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := r.Fee()
+			c.Check(err, IsNil)
+		}()
+	}
+	wg.Wait()
+	close(ids)
+	seen := make(map[float64]bool)
+	for id := range ids {
+		c.Check(seen[id], Equals, false)
+		seen[id] = true
+	}
+	c.Check(seen, HasLen, senders)
+	for i := 1; i <= senders; i++ {
+		c.Check(seen[float64(i)], Equals, true)
+	}
+}
+
+func (s *RemoteSuite) TestClose(c *C) {
+	requests := make(chan struct{})
+	closeCodes := make(chan int, 1)
+	server := newTestServer(c, func(ws *websocket.Conn) {
+		// Never answer, and report how the client hung up
+		for {
+			if _, _, err := ws.ReadMessage(); err != nil {
+				if e, ok := err.(*websocket.CloseError); ok {
+					closeCodes <- e.Code
+				}
+				close(closeCodes)
+				return
+			}
+			requests <- struct{}{}
+		}
+	})
+	defer server.Close()
+
+	r, err := NewRemote(strings.Replace(server.URL, "http", "ws", 1))
+	c.Assert(err, IsNil)
+
+	pending := make(chan error)
+	go func() {
+		_, err := r.Fee()
+		pending <- err
+	}()
+	<-requests
+	c.Assert(r.Close(), IsNil)
+	c.Check(<-closeCodes, Equals, websocket.CloseNormalClosure)
+	c.Check(<-pending, ErrorMatches, "Client Error -1 Connection Closed.*")
+	_, open := <-r.Incoming
+	c.Check(open, Equals, false)
+
+	// Every command fails from now on, and closing again does nothing
+	_, err = r.Fee()
+	c.Check(err, Equals, errConnectionClosed)
+	c.Check(r.Close(), IsNil)
+	c.Check(r.isClosed(), Equals, true)
+}
+
+func (s *RemoteSuite) TestContextCancelsCommand(c *C) {
+	server := newTestServer(c, func(ws *websocket.Conn) {
+		// Never answer
+		for {
+			if _, _, err := ws.ReadMessage(); err != nil {
+				return
+			}
+		}
+	})
+	defer server.Close()
+
+	r, err := NewRemote(strings.Replace(server.URL, "http", "ws", 1))
+	c.Assert(err, IsNil)
+	defer r.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = r.FeeContext(ctx)
+	c.Assert(err, Equals, context.DeadlineExceeded)
+}
+
+// Serves a connection which fills an Incoming of one with ledger stream
+// messages, so that the run loop is held up, and never answers
+func newBlockedServer(c *C) *httptest.Server {
+	ledger, err := ioutil.ReadFile("testdata/ledger_stream.json")
+	c.Assert(err, IsNil)
+	return newTestServer(c, func(ws *websocket.Conn) {
+		readRequest(c, ws)
+		for i := 0; i < 3; i++ {
+			c.Assert(ws.WriteMessage(websocket.TextMessage, ledger), IsNil)
+		}
+		ws.ReadMessage() // Wait for the client to hang up
+	})
+}
+
+func (s *RemoteSuite) TestContextCancelsBlockedCommand(c *C) {
+	server := newBlockedServer(c)
+	defer server.Close()
+
+	r, err := NewRemote(strings.Replace(server.URL, "http", "ws", 1), RemoteBuffers(Buffers{Incoming: 1}))
+	c.Assert(err, IsNil)
+	defer r.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		_, err := r.FeeContext(ctx)
+		done <- err
+	}()
+	for len(r.Incoming) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	select {
+	case err := <-done:
+		c.Assert(err, Equals, context.Canceled)
+	case <-time.After(time.Second):
+		c.Fatal("Cancelled command did not return")
+	}
+}
+
+func (s *RemoteSuite) TestCommandTimeout(c *C) {
+	server := newTestServer(c, func(ws *websocket.Conn) {
+		// Ignore the first request, and answer the second slowly
+		readRequest(c, ws)
+		request := readRequest(c, ws)
+		time.Sleep(50 * time.Millisecond)
+		c.Assert(ws.WriteJSON(map[string]interface{}{
+			"id":     request["id"],
+			"status": "success",
+			"type":   "response",
+			"result": map[string]interface{}{"expected_ledger_size": "24"},
+		}), IsNil)
+		ws.ReadMessage() // Wait for the client to hang up
+	})
+	defer server.Close()
+
+	r, err := NewRemote(strings.Replace(server.URL, "http", "ws", 1), RemoteCommandTimeout(20*time.Millisecond))
+	c.Assert(err, IsNil)
+	defer r.Close()
+
+	_, err = r.Fee()
+	c.Assert(err, Equals, context.DeadlineExceeded)
+
+	// A deadline on the context overrides the timeout
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	result, err := r.FeeContext(ctx)
+	c.Assert(err, IsNil)
+	c.Assert(result.ExpectedLedgerSize, Equals, uint32(24))
+}
+
+func (s *RemoteSuite) TestBlockedCommandTimeout(c *C) {
+	server := newBlockedServer(c)
+	defer server.Close()
+
+	r, err := NewRemote(strings.Replace(server.URL, "http", "ws", 1),
+		RemoteBuffers(Buffers{Incoming: 1}),
+		RemoteCommandTimeout(50*time.Millisecond),
+	)
+	c.Assert(err, IsNil)
+	defer r.Close()
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := r.Fee()
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		c.Assert(err, Equals, context.DeadlineExceeded)
+		c.Assert(len(r.Incoming), Equals, 1)
+	case <-time.After(time.Second):
+		c.Fatal("Timed out command did not return")
+	}
+}
+
+func (s *RemoteSuite) TestSecureEndpoint(c *C) {
+	server := httptest.NewTLSServer(newTestHandler(c, func(ws *websocket.Conn) {
+		request := readRequest(c, ws)
+		c.Assert(ws.WriteJSON(map[string]interface{}{
+			"id":     request["id"],
+			"status": "success",
+			"type":   "response",
+			"result": map[string]interface{}{"expected_ledger_size": "24"},
+		}), IsNil)
+		ws.ReadMessage() // Wait for the client to hang up
+	}))
+	defer server.Close()
+	endpoint := strings.Replace(server.URL, "https", "wss", 1)
+
+	// The test certificate is not trusted by default
+	_, err := NewRemote(endpoint)
+	c.Assert(err, NotNil)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(server.Certificate())
+	r, err := NewRemote(endpoint, RemoteTLSConfig(&tls.Config{RootCAs: roots}))
+	c.Assert(err, IsNil)
+	defer r.Close()
+
+	result, err := r.Fee()
+	c.Assert(err, IsNil)
+	c.Assert(result.ExpectedLedgerSize, Equals, uint32(24))
+}
+
+func (s *RemoteSuite) TestErrorResponses(c *C) {
+	server := newTestServer(c, func(ws *websocket.Conn) {
+		request := readRequest(c, ws)
+		c.Assert(ws.WriteJSON(map[string]interface{}{
+			"id":            request["id"],
+			"status":        "error",
+			"type":          "response",
+			"error":         "actNotFound",
+			"error_code":    19,
+			"error_message": "Account not found.",
+		}), IsNil)
+		request = readRequest(c, ws)
+		c.Assert(ws.WriteJSON(map[string]interface{}{
+			"id":     request["id"],
+			"status": "error",
+			"type":   "response",
+		}), IsNil)
+		request = readRequest(c, ws)
+		c.Assert(ws.WriteJSON(map[string]interface{}{
+			"id":     request["id"],
+			"status": "success",
+			"type":   "response",
+			"result": "not an object",
+		}), IsNil)
+		ws.ReadMessage() // Wait for the client to hang up
+	})
+	defer server.Close()
+
+	r, err := NewRemote(strings.Replace(server.URL, "http", "ws", 1))
+	c.Assert(err, IsNil)
+	defer r.Close()
+
+	var account data.Account
+	_, err = r.AccountInfo(account, nil)
+	c.Assert(err, FitsTypeOf, &CommandError{})
+	c.Assert(err.(*CommandError).Name, Equals, "actNotFound")
+	c.Assert(err.(*CommandError).Message, Equals, "Account not found.")
+
+	_, err = r.AccountInfo(account, nil)
+	c.Assert(err, FitsTypeOf, &CommandError{})
+	c.Assert(err.(*CommandError).Name, Equals, "unknownError")
+
+	_, err = r.Fee()
+	c.Assert(err, FitsTypeOf, &CommandError{})
+	c.Assert(err.(*CommandError).Name, Equals, "Client Error")
+}
+
+func (s *RemoteSuite) TestSubmitMultisigned(c *C) {
+	server := newTestServer(c, func(ws *websocket.Conn) {
+		request := readRequest(c, ws)
+		c.Check(request["command"], Equals, "submit_multisigned")
+		tx := request["tx_json"].(map[string]interface{})
+		c.Check(tx["TransactionType"], Equals, "Payment")
+		c.Check(tx["SigningPubKey"], Equals, "")
+		c.Check(tx["Signers"], HasLen, 2)
+		_, hasHash := tx["hash"]
+		c.Check(hasHash, Equals, false)
+		c.Assert(ws.WriteJSON(map[string]interface{}{
+			"id":     request["id"],
+			"status": "success",
+			"type":   "response",
+			"result": map[string]interface{}{
+				"engine_result":         "tesSUCCESS",
+				"engine_result_code":    0,
+				"engine_result_message": "The transaction was applied. Only final in a validated ledger.",
+			},
+		}), IsNil)
+		ws.ReadMessage() // Wait for the client to hang up
+	})
+	defer server.Close()
+
+	r, err := NewRemote(strings.Replace(server.URL, "http", "ws", 1))
+	c.Assert(err, IsNil)
+	defer r.Close()
+
+	owner, err := data.NewAccountFromAddress("r9cZA1mLK5R5Am25ArfXFmqgNwjZgnfk59")
+	c.Assert(err, IsNil)
+	amount, err := data.NewAmount("1000000")
+	c.Assert(err, IsNil)
+	fee, err := data.NewNativeValue(30)
+	c.Assert(err, IsNil)
+	tx := &data.Payment{
+		TxBase: data.TxBase{
+			TransactionType: data.PAYMENT,
+			Account:         *owner,
+			Sequence:        1,
+			Fee:             *fee,
+		},
+		Destination: *owner,
+		Amount:      *amount,
+	}
+
+	// Not yet signed by anyone
+	_, err = r.SubmitMultisigned(tx)
+	c.Assert(err, ErrorMatches, "Multisigned transaction has no Signers")
+
+	for _, secret := range []string{"snoPBrXtMeMyMHUVTgbuqAfg1SUTb", "sEdVQ4wvD1AaTG6JA54qt38TengAuiz"} {
+		seed, keyType, err := data.ParseSeed(secret)
+		c.Assert(err, IsNil)
+		var sequence *uint32
+		if keyType == data.ECDSA {
+			sequence = new(uint32)
+		}
+		c.Assert(data.AddSigner(tx, seed.Key(keyType), sequence, seed.AccountId(keyType, sequence)), IsNil)
+	}
+
+	// Signers out of order
+	tx.Signers[0], tx.Signers[1] = tx.Signers[1], tx.Signers[0]
+	_, err = r.SubmitMultisigned(tx)
+	c.Assert(err, ErrorMatches, "Signers must be sorted by account.*")
+	tx.Signers[0], tx.Signers[1] = tx.Signers[1], tx.Signers[0]
+
+	result, err := r.SubmitMultisigned(tx)
+	c.Assert(err, IsNil)
+	c.Assert(result.EngineResult.String(), Equals, "tesSUCCESS")
+}
+
+func (s *RemoteSuite) TestAutofill(c *C) {
+	results := map[string]interface{}{
+		"account_info": map[string]interface{}{
+			"ledger_current_index": 7636530,
+			"account_data": map[string]interface{}{
+				"LedgerEntryType": "AccountRoot",
+				"Account":         "rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh",
+				"Sequence":        546,
+			},
+		},
+		"fee": map[string]interface{}{
+			"drops": map[string]interface{}{
+				"minimum_fee":     "10",
+				"open_ledger_fee": "12",
+				"median_fee":      "5000",
+			},
+		},
+		"server_info": map[string]interface{}{
+			"info": map[string]interface{}{
+				"validated_ledger": map[string]interface{}{
+					"seq":              7636529,
+					"reserve_base_xrp": 10,
+					"reserve_inc_xrp":  2,
+				},
+			},
+		},
+	}
+	var commands []string
+	server := newTestServer(c, func(ws *websocket.Conn) {
+		for {
+			var request map[string]interface{}
+			if ws.ReadJSON(&request) != nil {
+				return
+			}
+			command := request["command"].(string)
+			commands = append(commands, command)
+			c.Assert(ws.WriteJSON(map[string]interface{}{
+				"id":     request["id"],
+				"status": "success",
+				"type":   "response",
+				"result": results[command],
+			}), IsNil)
+		}
+	})
+	defer server.Close()
+
+	r, err := NewRemote(strings.Replace(server.URL, "http", "ws", 1))
+	c.Assert(err, IsNil)
+	defer r.Close()
+
+	account, err := data.NewAccountFromAddress("rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh")
+	c.Assert(err, IsNil)
+	payment := data.TxFactory[data.PAYMENT]().(*data.Payment)
+	c.Assert(r.Autofill(payment, *account), IsNil)
+	c.Assert(payment.Account, Equals, *account)
+	c.Assert(payment.Sequence, Equals, uint32(546))
+	c.Assert(payment.Fee.String(), Equals, "0.000012")
+	c.Assert(*payment.LastLedgerSequence, Equals, uint32(7636529+AutofillLedgerOffset))
+
+	// Fields already set are left alone, and nothing is asked for them
+	fee, err := data.NewNativeValue(30)
+	c.Assert(err, IsNil)
+	last := uint32(100)
+	payment = data.TxFactory[data.PAYMENT]().(*data.Payment)
+	payment.Sequence = 7
+	payment.Fee = *fee
+	payment.LastLedgerSequence = &last
+	commands = nil
+	c.Assert(r.Autofill(payment, *account), IsNil)
+	c.Assert(payment.Sequence, Equals, uint32(7))
+	c.Assert(payment.Fee.String(), Equals, "0.00003")
+	c.Assert(*payment.LastLedgerSequence, Equals, uint32(100))
+	c.Assert(commands, HasLen, 0)
+
+	// Deleting an account costs the owner reserve increment
+	del := data.TxFactory[data.ACCOUNT_DELETE]().(*data.AccountDelete)
+	del.Sequence = 8
+	c.Assert(r.Autofill(del, *account), IsNil)
+	c.Assert(del.Fee.String(), Equals, "2")
+	c.Assert(commands, DeepEquals, []string{"server_info"})
+}
+
+// A response to the command expected next, which is an error if it has one
+type scriptedResponse struct {
+	command string
+	result  interface{}
+	err     string
+}
+
+// Answers each request with the next response, checking it is the expected
+// command
+func scripted(c *C, responses ...scriptedResponse) func(*websocket.Conn) {
+	return func(ws *websocket.Conn) {
+		for _, response := range responses {
+			request := readRequest(c, ws)
+			c.Check(request["command"], Equals, response.command)
+			reply := map[string]interface{}{
+				"id":     request["id"],
+				"status": "success",
+				"type":   "response",
+				"result": response.result,
+			}
+			if response.err != "" {
+				reply["status"] = "error"
+				reply["error"] = response.err
+			}
+			c.Assert(ws.WriteJSON(reply), IsNil)
+		}
+		ws.ReadMessage() // Wait for the client to hang up
+	}
+}
+
+func (s *RemoteSuite) TestSubmitReliable(c *C) {
+	defer func(interval time.Duration) { reliablePollInterval = interval }(reliablePollInterval)
+	reliablePollInterval = time.Millisecond
+
+	engineResult := func(result string) scriptedResponse {
+		return scriptedResponse{command: "submit", result: map[string]interface{}{"engine_result": result}}
+	}
+	validatedLedger := func(seq uint32) scriptedResponse {
+		return scriptedResponse{command: "server_info", result: map[string]interface{}{
+			"info": map[string]interface{}{"validated_ledger": map[string]interface{}{"seq": seq}},
+		}}
+	}
+	b, err := ioutil.ReadFile("testdata/tx.json")
+	c.Assert(err, IsNil)
+	var response struct{ Result map[string]interface{} }
+	c.Assert(json.Unmarshal(b, &response), IsNil)
+	validated := response.Result
+	pending := make(map[string]interface{})
+	for k, v := range validated {
+		pending[k] = v
+	}
+	pending["validated"] = false
+
+	server := newTestServer(c,
+		scripted(c,
+			// Resubmitted once the fee has dropped, and returned once validated
+			engineResult("telINSUF_FEE_P"),
+			validatedLedger(10),
+			scriptedResponse{command: "tx", err: "txnNotFound"},
+			engineResult("tesSUCCESS"),
+			validatedLedger(11),
+			scriptedResponse{command: "tx", result: pending},
+			validatedLedger(12),
+			scriptedResponse{command: "tx", result: validated},
+			// Never applied before its last ledger
+			engineResult("terQUEUED"),
+			validatedLedger(101),
+			scriptedResponse{command: "tx", err: "txnNotFound"},
+			// Malformed, so never worth waiting for
+			engineResult("temBAD_FEE"),
+		),
+	)
+	defer server.Close()
+
+	r, err := NewRemote(strings.Replace(server.URL, "http", "ws", 1))
+	c.Assert(err, IsNil)
+	defer r.Close()
+
+	account, err := data.NewAccountFromAddress("rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh")
+	c.Assert(err, IsNil)
+	payment := data.TxFactory[data.PAYMENT]().(*data.Payment)
+	payment.Account = *account
+	payment.Destination = *account
+	amount, err := data.NewAmount("1/XRP")
+	c.Assert(err, IsNil)
+	payment.Amount = *amount
+
+	_, err = r.SubmitReliable(payment)
+	c.Assert(err, ErrorMatches, "Reliable submission requires a LastLedgerSequence")
+
+	last := uint32(100)
+	payment.LastLedgerSequence = &last
+	txm, err := r.SubmitReliable(payment)
+	c.Assert(err, IsNil)
+	c.Assert(txm.Validated, Equals, true)
+	c.Assert(txm.MetaData.TransactionResult.String(), Equals, "tesSUCCESS")
+
+	_, err = r.SubmitReliable(payment)
+	c.Assert(err, ErrorMatches, "Transaction .* expired: validated ledger 101 is past LastLedgerSequence 100")
+
+	_, err = r.SubmitReliable(payment)
+	c.Assert(err, ErrorMatches, "Transaction .* failed: temBAD_FEE .*")
+}
+
+func (s *RemoteSuite) TestSubmitBatch(c *C) {
+	engineResult := func(result string) scriptedResponse {
+		return scriptedResponse{command: "submit", result: map[string]interface{}{"engine_result": result}}
+	}
+	server := newTestServer(c,
+		scripted(c,
+			scriptedResponse{command: "account_info", result: map[string]interface{}{
+				"account_data": map[string]interface{}{"Sequence": 546},
+			}},
+			engineResult("tesSUCCESS"),
+			// Rejected outright, so the next transaction takes its sequence
+			engineResult("temBAD_AMOUNT"),
+			// Signed again with the median fee and queued
+			engineResult("telINSUF_FEE_P"),
+			scriptedResponse{command: "fee", result: map[string]interface{}{
+				"drops": map[string]interface{}{
+					"minimum_fee":     "10",
+					"open_ledger_fee": "12",
+					"median_fee":      "5000",
+				},
+			}},
+			engineResult("terQUEUED"),
+		),
+	)
+	defer server.Close()
+
+	r, err := NewRemote(strings.Replace(server.URL, "http", "ws", 1))
+	c.Assert(err, IsNil)
+	defer r.Close()
+
+	seed, err := data.NewSeedFromAddress("snoPBrXtMeMyMHUVTgbuqAfg1SUTb")
+	c.Assert(err, IsNil)
+	var keySequence uint32
+	key := seed.Key(data.ECDSA)
+	account := seed.AccountId(data.ECDSA, &keySequence)
+	fee, err := data.NewNativeValue(10)
+	c.Assert(err, IsNil)
+	amount, err := data.NewAmount("1/XRP")
+	c.Assert(err, IsNil)
+	last := uint32(100)
+	var payments []*data.Payment
+	var txs []data.Transaction
+	for i := 0; i < 3; i++ {
+		payment := data.TxFactory[data.PAYMENT]().(*data.Payment)
+		payment.Account = account
+		payment.Destination = account
+		payment.Amount = *amount
+		payment.Fee = *fee
+		payment.LastLedgerSequence = &last
+		payments = append(payments, payment)
+		txs = append(txs, payment)
+	}
+
+	results, err := r.SubmitBatch(txs, BatchSign(key, &keySequence))
+	c.Assert(err, IsNil)
+	c.Assert(results, HasLen, 3)
+	c.Check(results[0].EngineResult.String(), Equals, "tesSUCCESS")
+	c.Check(results[1].EngineResult.String(), Equals, "temBAD_AMOUNT")
+	c.Check(results[2].EngineResult.String(), Equals, "terQUEUED")
+	c.Check(payments[0].Sequence, Equals, uint32(546))
+	c.Check(payments[1].Sequence, Equals, uint32(547))
+	c.Check(payments[2].Sequence, Equals, uint32(547))
+	c.Check(payments[0].Fee.String(), Equals, "0.00001")
+	c.Check(payments[2].Fee.String(), Equals, "0.005")
+	ok, err := data.CheckSignature(payments[2])
+	c.Assert(err, IsNil)
+	c.Check(ok, Equals, true)
+
+	other, err := data.NewAccountFromAddress("rrrrrrrrrrrrrrrrrrrrBZbvji")
+	c.Assert(err, IsNil)
+	payments[1].Account = *other
+	_, err = r.SubmitBatch(txs, BatchSign(key, &keySequence))
+	c.Check(err, ErrorMatches, "Batch transactions must all be from rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh: rrrrrrrrrrrrrrrrrrrrBZbvji")
+}
+
+func (s *RemoteSuite) TestUnsubscribeOrderBooks(c *C) {
+	xrp := data.Asset{Currency: "XRP"}
+	usd := data.Asset{Currency: "USD", Issuer: "rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B"}
+	eur := data.Asset{Currency: "EUR", Issuer: "rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B"}
+	session := &session{subscriptions: []*SubscribeCommand{
+		{Streams: []string{"ledger"}},
+		{Books: []OrderBookSubscription{
+			{TakerGets: xrp, TakerPays: usd},
+			{TakerGets: eur, TakerPays: xrp},
+			{TakerGets: usd, TakerPays: eur},
+		}},
+	}}
+	session.unsubscribe(&UnsubscribeCommand{Books: []OrderBookUnsubscription{
+		{TakerGets: xrp, TakerPays: usd},
+		{TakerGets: eur, TakerPays: usd, Both: true},
+	}})
+	c.Assert(session.subscriptions[0].Streams, DeepEquals, []string{"ledger"})
+	c.Assert(session.subscriptions[1].Books, DeepEquals, []OrderBookSubscription{{TakerGets: eur, TakerPays: xrp}})
+}
+
+func (s *RemoteSuite) TestUnsubscribe(c *C) {
+	a, err := data.NewAccountFromAddress("rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh")
+	c.Assert(err, IsNil)
+	b, err := data.NewAccountFromAddress("rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf")
+	c.Assert(err, IsNil)
+	session := &session{subscriptions: []*SubscribeCommand{
+		{Streams: []string{"ledger", "server"}},
+		{Accounts: []data.Account{*a, *b}},
+		{Streams: []string{"transactions"}, AccountsProposed: []data.Account{*a}},
+	}}
+	session.unsubscribe(&UnsubscribeCommand{
+		Streams:          []string{"server", "transactions"},
+		Accounts:         []data.Account{*a},
+		AccountsProposed: []data.Account{*a},
+	})
+	c.Assert(session.subscriptions, HasLen, 2)
+	c.Assert(session.subscriptions[0].Streams, DeepEquals, []string{"ledger"})
+	c.Assert(session.subscriptions[1].Accounts, DeepEquals, []data.Account{*b})
+}
+
+type recordingLogger struct {
+	sync.Mutex
+	lines []string
+}
+
+func (l *recordingLogger) record(level, format string, args ...interface{}) {
+	l.Lock()
+	defer l.Unlock()
+	l.lines = append(l.lines, level+" "+fmt.Sprintf(format, args...))
+}
+
+func (l *recordingLogger) Debugf(format string, args ...interface{}) {
+	l.record("DEBUG", format, args...)
+}
+func (l *recordingLogger) Infof(format string, args ...interface{}) {
+	l.record("INFO", format, args...)
+}
+func (l *recordingLogger) Errorf(format string, args ...interface{}) {
+	l.record("ERROR", format, args...)
+}
+
+func (s *RemoteSuite) TestLogger(c *C) {
+	server := newTestServer(c, func(ws *websocket.Conn) {
+		readRequest(c, ws)
+	})
+	defer server.Close()
+
+	logger := &recordingLogger{}
+	endpoint := strings.Replace(server.URL, "http", "ws", 1)
+	r, err := NewRemote(endpoint, RemoteLogger(logger))
+	c.Assert(err, IsNil)
+	_, err = r.Fee()
+	c.Assert(err, NotNil)
+	r.Close()
+
+	logger.Lock()
+	defer logger.Unlock()
+	c.Assert(logger.lines[0], Equals, "INFO Connecting to "+endpoint)
+	c.Assert(strings.Join(logger.lines, "\n"), Matches, `(?s).*DEBUG \{.*"command": "fee",.*ERROR Connection closed by server.*`)
+}
+
+func (s *RemoteSuite) TestStateChanges(c *C) {
+	server := newTestServer(c,
+		func(ws *websocket.Conn) {
+			// Drop the connection straight away
+		},
+		func(ws *websocket.Conn) {
+			ws.ReadMessage() // Wait for the client to hang up
+		},
+	)
+	defer server.Close()
+
+	states := make(chan State, 10)
+	r, err := NewRemote(strings.Replace(server.URL, "http", "ws", 1),
+		RemoteReconnect(ReconnectPolicy{MaxRetries: 3, BaseDelay: 10 * time.Millisecond}),
+		RemoteOnStateChange(func(state State) { states <- state }),
+	)
+	c.Assert(err, IsNil)
+	for _, expected := range []State{Connected, Disconnected, Reconnecting, Connected} {
+		c.Assert(<-states, Equals, expected)
+	}
+	r.Close()
+	c.Assert(<-states, Equals, Closed)
+	c.Assert(Closed.String(), Equals, "Closed")
+	c.Assert(State(42).String(), Equals, "State(42)")
+}
+
+func (s *RemoteSuite) TestDropWhenFull(c *C) {
+	ledger, err := ioutil.ReadFile("testdata/ledger_stream.json")
+	c.Assert(err, IsNil)
+	server := newTestServer(c, func(ws *websocket.Conn) {
+		request := readRequest(c, ws)
+		for i := 0; i < 3; i++ {
+			c.Assert(ws.WriteMessage(websocket.TextMessage, ledger), IsNil)
+		}
+		c.Assert(ws.WriteJSON(map[string]interface{}{
+			"id":     request["id"],
+			"status": "success",
+			"type":   "response",
+			"result": map[string]interface{}{"expected_ledger_size": "24"},
+		}), IsNil)
+		ws.ReadMessage() // Wait for the client to hang up
+	})
+	defer server.Close()
+
+	r, err := NewRemote(strings.Replace(server.URL, "http", "ws", 1),
+		RemoteBuffers(Buffers{Incoming: 1}),
+		RemoteIncomingPolicy(DropWhenFull),
+	)
+	c.Assert(err, IsNil)
+	defer r.Close()
+	c.Assert(cap(r.Incoming), Equals, 1)
+
+	// Nobody receives from Incoming, which doesn't hold up the response
+	result, err := r.Fee()
+	c.Assert(err, IsNil)
+	c.Assert(result.ExpectedLedgerSize, Equals, uint32(24))
+	c.Assert(len(r.Incoming), Equals, 1)
+}
+
+func (s *RemoteSuite) TestSimulate(c *C) {
+	server := newTestServer(c,
+		scripted(c,
+			scriptedResponse{command: "simulate", result: map[string]interface{}{"engine_result": "tecPATH_DRY", "applied": false}},
+		),
+	)
+	defer server.Close()
+
+	r, err := NewRemote(strings.Replace(server.URL, "http", "ws", 1))
+	c.Assert(err, IsNil)
+	defer r.Close()
+
+	account, err := data.NewAccountFromAddress("rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh")
+	c.Assert(err, IsNil)
+	payment := data.TxFactory[data.PAYMENT]().(*data.Payment)
+	payment.Account = *account
+	payment.Destination = *account
+	amount, err := data.NewAmount("1/XRP")
+	c.Assert(err, IsNil)
+	payment.Amount = *amount
+
+	result, err := r.Simulate(payment)
+	c.Assert(err, IsNil)
+	c.Assert(result.EngineResult.Claimed(), Equals, true)
+	c.Assert(result.Applied, Equals, false)
+
+	payment.TxnSignature = &data.VariableLength{0x30}
+	_, err = r.Simulate(payment)
+	c.Assert(err, ErrorMatches, "Cannot simulate a signed transaction")
+}
+
+func (s *RemoteSuite) TestAdminCommand(c *C) {
+	requests := make(chan map[string]interface{}, 1)
+	server := newTestServer(c, func(ws *websocket.Conn) {
+		request := readRequest(c, ws)
+		requests <- request
+		c.Assert(ws.WriteJSON(map[string]interface{}{
+			"id":     request["id"],
+			"status": "success",
+			"type":   "response",
+			"result": map[string]interface{}{"key_type": "ed25519"},
+		}), IsNil)
+		ws.ReadMessage() // Wait for the client to hang up
+	})
+	defer server.Close()
+
+	r, err := NewRemote(strings.Replace(server.URL, "http", "ws", 1))
+	c.Assert(err, IsNil)
+	defer r.Close()
+
+	result, err := r.AdminCommand("wallet_propose", map[string]interface{}{"id": 0, "key_type": "ed25519"})
+	c.Assert(err, IsNil)
+	c.Check(string(result), Equals, `{"key_type":"ed25519"}`)
+	c.Check(<-requests, DeepEquals, map[string]interface{}{
+		"id":       float64(1),
+		"command":  "wallet_propose",
+		"key_type": "ed25519",
+	})
+}
@@ -44,23 +44,101 @@ type ServerStreamMsg struct {
 	HostID                  string `json:"hostid"`
 }
 
+// Fields from subscribed validation stream messages
+type ValidationStreamMsg struct {
+	Flags               uint32              `json:"flags"`
+	Full                bool                `json:"full"`
+	LedgerHash          data.Hash256        `json:"ledger_hash"`
+	LedgerSequence      uint32              `json:"ledger_index,string"`
+	SigningTime         data.RippleTime     `json:"signing_time"`
+	Signature           data.VariableLength `json:"signature"`
+	ValidationPublicKey data.NodePublicKey  `json:"validation_public_key"`
+	MasterKey           data.NodePublicKey  `json:"master_key"`
+	Cookie              string              `json:"cookie"`
+	BaseFee             uint64              `json:"base_fee"`
+	ReserveBase         uint64              `json:"reserve_base"`
+	ReserveIncrement    uint64              `json:"reserve_inc"`
+	LoadFee             uint64              `json:"load_fee"`
+	Amendments          []data.Hash256      `json:"amendments"`
+}
+
+// Fields from subscribed manifest stream messages
+type ManifestStreamMsg struct {
+	MasterKey       data.NodePublicKey  `json:"master_key"`
+	MasterSignature data.VariableLength `json:"master_signature"`
+	SigningKey      data.NodePublicKey  `json:"signing_key"`
+	Signature       data.VariableLength `json:"signature"`
+	Sequence        uint32              `json:"seq"`
+	Domain          string              `json:"domain"`
+	Manifest        []byte              `json:"manifest"`
+}
+
+// Fields from subscribed consensus stream messages
+type ConsensusStreamMsg struct {
+	Consensus string `json:"consensus"` // open, establish or accepted
+}
+
+// Returns the cost in drops of a reference transaction at the current load
 func (s *ServerStreamMsg) TransactionCost() uint64 {
+	if s.LoadBase == 0 {
+		return 0
+	}
 	return (s.BaseFee * s.LoadFactor) / s.LoadBase
 }
 
-// Map message types to the appropriate data structure
+// Returns the cost in drops of a reference transaction getting into the open
+// ledger, which rises above TransactionCost when the queue is in use
+func (s *ServerStreamMsg) OpenLedgerCost() uint64 {
+	if s.LoadFactorFeeReference == 0 {
+		return s.TransactionCost()
+	}
+	return (s.BaseFee * s.LoadFactorFeeEscalation) / s.LoadFactorFeeReference
+}
+
+// Returns the larger of TransactionCost and OpenLedgerCost as an XRP value,
+// ready to be used as the Fee of a transaction
+func (s *ServerStreamMsg) Fee() (*data.Value, error) {
+	drops := s.TransactionCost()
+	if cost := s.OpenLedgerCost(); cost > drops {
+		drops = cost
+	}
+	return data.NewNativeValue(int64(drops))
+}
+
+// IsLoaded reports whether the server has raised its fee above the base fee
+// because of its own load or that of the network
+func (s *ServerStreamMsg) IsLoaded() bool {
+	return s.LoadFactor > s.LoadBase
+}
+
+// Map message types to the appropriate data structure. These are the
+// types which are sent on the Incoming channel of a Remote.
 var streamMessageFactory = map[string]func() interface{}{
-	"ledgerClosed": func() interface{} { return &LedgerStreamMsg{} },
-	"transaction":  func() interface{} { return &TransactionStreamMsg{} },
-	"serverStatus": func() interface{} { return &ServerStreamMsg{} },
-	"path_find":    func() interface{} { return &PathFindCreateResult{} },
+	"ledgerClosed":       func() interface{} { return &LedgerStreamMsg{} },
+	"transaction":        func() interface{} { return &TransactionStreamMsg{} },
+	"serverStatus":       func() interface{} { return &ServerStreamMsg{} },
+	"consensusPhase":     func() interface{} { return &ConsensusStreamMsg{} },
+	"validationReceived": func() interface{} { return &ValidationStreamMsg{} },
+	"manifestReceived":   func() interface{} { return &ManifestStreamMsg{} },
+	"path_find":          func() interface{} { return &PathFindCreateResult{} },
 }
 
 type SubscribeCommand struct {
 	*Command
-	Streams []string                `json:"streams"`
-	Books   []OrderBookSubscription `json:"books,omitempty"`
-	Result  *SubscribeResult        `json:"result,omitempty"`
+	Streams          []string                `json:"streams,omitempty"`
+	Accounts         []data.Account          `json:"accounts,omitempty"`
+	AccountsProposed []data.Account          `json:"accounts_proposed,omitempty"`
+	Books            []OrderBookSubscription `json:"books,omitempty"`
+	Result           *SubscribeResult        `json:"result,omitempty"`
+}
+
+type UnsubscribeCommand struct {
+	*Command
+	Streams          []string                  `json:"streams,omitempty"`
+	Accounts         []data.Account            `json:"accounts,omitempty"`
+	AccountsProposed []data.Account            `json:"accounts_proposed,omitempty"`
+	Books            []OrderBookUnsubscription `json:"books,omitempty"`
+	Result           *struct{}                 `json:"result,omitempty"`
 }
 
 type SubscribeResult struct {
@@ -86,3 +164,40 @@ func (msg *TransactionStreamMsg) UnmarshalJSON(b []byte) error {
 	extract.MetaData = &msg.Transaction.MetaData
 	return json.Unmarshal(b, &extract)
 }
+
+// An offer created, modified or deleted by a streamed transaction, with its
+// amounts as they were left by that transaction
+type OfferChange struct {
+	State         data.LedgerEntryState
+	Account       data.Account
+	Sequence      uint32
+	BookDirectory data.Hash256
+	TakerPays     data.Amount
+	TakerGets     data.Amount
+}
+
+// OfferChanges returns the offers affected by the transaction, in metadata order
+func (msg *TransactionStreamMsg) OfferChanges() []OfferChange {
+	var changes []OfferChange
+	for i := range msg.Transaction.MetaData.AffectedNodes {
+		_, final, _, state := msg.Transaction.MetaData.AffectedNodes[i].AffectedNode()
+		offer, ok := final.(*data.Offer)
+		if !ok || offer.Account == nil || offer.TakerPays == nil || offer.TakerGets == nil {
+			continue
+		}
+		change := OfferChange{
+			State:     state,
+			Account:   *offer.Account,
+			TakerPays: *offer.TakerPays,
+			TakerGets: *offer.TakerGets,
+		}
+		if offer.Sequence != nil {
+			change.Sequence = *offer.Sequence
+		}
+		if offer.BookDirectory != nil {
+			change.BookDirectory = *offer.BookDirectory
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
@@ -5,6 +5,7 @@ import (
 	"io/ioutil"
 	"testing"
 
+	"github.com/rubblelabs/ripple/crypto"
 	"github.com/rubblelabs/ripple/data"
 	. "gopkg.in/check.v1"
 )
@@ -117,6 +118,69 @@ func (s *MessagesSuite) TestServerStreamMsg(c *C) {
 	c.Assert(msg.Status, Equals, "syncing")
 	c.Assert(msg.LoadBase, Equals, uint64(256))
 	c.Assert(msg.LoadFactor, Equals, uint64(256))
+	c.Assert(msg.TransactionCost(), Equals, uint64(0))
+	c.Assert(msg.IsLoaded(), Equals, false)
+}
+
+func (s *MessagesSuite) TestServerStreamMsgLoaded(c *C) {
+	msg := streamMessageFactory["serverStatus"]().(*ServerStreamMsg)
+	readResponseFile(c, msg, "testdata/server_stream_loaded.json")
+
+	c.Assert(msg.Status, Equals, "full")
+	c.Assert(msg.BaseFee, Equals, uint64(10))
+	c.Assert(msg.LoadFactorServer, Equals, uint64(512))
+	c.Assert(msg.LoadFactorFeeQueue, Equals, uint64(256))
+	c.Assert(msg.IsLoaded(), Equals, true)
+	c.Assert(msg.TransactionCost(), Equals, uint64(20))
+	c.Assert(msg.OpenLedgerCost(), Equals, uint64(60))
+	fee, err := msg.Fee()
+	c.Assert(err, IsNil)
+	c.Assert(fee.String(), Equals, "0.00006")
+	c.Assert(fee.IsNative(), Equals, true)
+}
+
+func (s *MessagesSuite) TestConsensusStreamMsg(c *C) {
+	msg := streamMessageFactory["consensusPhase"]().(*ConsensusStreamMsg)
+	readResponseFile(c, msg, "testdata/consensus_stream.json")
+
+	c.Assert(msg.Consensus, Equals, "accepted")
+}
+
+func (s *MessagesSuite) TestValidationStreamMsg(c *C) {
+	msg := streamMessageFactory["validationReceived"]().(*ValidationStreamMsg)
+	readResponseFile(c, msg, "testdata/validation_stream.json")
+
+	c.Assert(msg.Flags, Equals, uint32(0x80000001))
+	c.Assert(msg.Full, Equals, true)
+	c.Assert(msg.LedgerHash.String(), Equals, "EC02890710AAA2B71221B0D560CFB22D64317C07B7406B02959AD84BAD33E602")
+	c.Assert(msg.LedgerSequence, Equals, uint32(6))
+	c.Assert(msg.SigningTime.String(), Equals, "2016-Apr-27 23:35:22 UTC")
+	c.Assert(msg.Signature.String(), Equals, "3045022100E199B55643F66BC6B37DBC5E185321CF952FD35D13D9E8001EB2564FFB94A07602201746C9A4F7A93647131A2DEB03B76F05E426EC67A5A27D77F4FF2603B9A528E6")
+	c.Assert(msg.ValidationPublicKey.String(), Equals, "n94Gnc6svmaPPRHUAyyib1gQUov8sYbjLoEwUBYPH39qHZXuo8ZT")
+	c.Assert(msg.MasterKey.String(), Equals, "nHUon2tpyJEHHYGmxqeGu37cvPYHzrMtUNQFVdCgGNvEkjmCpTqK")
+	c.Assert(msg.BaseFee, Equals, uint64(10))
+	c.Assert(msg.ReserveBase, Equals, uint64(10000000))
+	c.Assert(msg.ReserveIncrement, Equals, uint64(2000000))
+	c.Assert(msg.LoadFee, Equals, uint64(256000))
+	c.Assert(msg.Amendments, HasLen, 2)
+	c.Assert(msg.Amendments[1].String(), Equals, "4C97EBA926031A7CF7D7B36FDE3ED66DDA5421192D63DE53FFB46E43B9DC8373")
+}
+
+func (s *MessagesSuite) TestManifestStreamMsg(c *C) {
+	msg := streamMessageFactory["manifestReceived"]().(*ManifestStreamMsg)
+	readResponseFile(c, msg, "testdata/manifest_stream.json")
+
+	c.Assert(msg.MasterKey.String(), Equals, "nHUon2tpyJEHHYGmxqeGu37cvPYHzrMtUNQFVdCgGNvEkjmCpTqK")
+	c.Assert(msg.SigningKey.String(), Equals, "n94Gnc6svmaPPRHUAyyib1gQUov8sYbjLoEwUBYPH39qHZXuo8ZT")
+	c.Assert(msg.SigningKey.PublicKey().String(), Equals, "03E9813C272B691058F2A850E092B454A43A3506EEF3BC77D712318707B569F7D2")
+	c.Assert(msg.Sequence, Equals, uint32(1))
+	c.Assert(msg.Domain, Equals, "example.com")
+	c.Assert(msg.Signature.String(), Equals, "3045022100E9E0BBDB002AA5D8A2E27E9765745BEB003A4541F99452E8F4A194901F52C5DF02206C54919EB1A69D38973F680668912D6FCA61A656B9473CA597DD8CB0BBA8A7F8")
+	c.Assert(msg.Manifest, HasLen, 215)
+
+	hash, err := msg.MasterKey.Hash()
+	c.Assert(err, IsNil)
+	c.Assert(hash.Version(), Equals, crypto.RIPPLE_NODE_PUBLIC)
 }
 
 func (s *MessagesSuite) TestProposedTransactionStreamMsg(c *C) {
@@ -144,6 +208,89 @@ func (s *MessagesSuite) TestProposedTransactionStreamMsg(c *C) {
 	c.Assert(offer.TakerPays.String(), Equals, "4285.465077979/CNY/razqQKzJRdB4UxFPWf5NEpEG3WMkmwgcXA")
 }
 
+func (s *MessagesSuite) TestAccountsSubscribeRequest(c *C) {
+	src, err := data.NewAccountFromAddress("rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh")
+	c.Assert(err, IsNil)
+	dst, err := data.NewAccountFromAddress("rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf")
+	c.Assert(err, IsNil)
+	cmd := &SubscribeCommand{
+		Command:          newCommand("subscribe"),
+		Accounts:         []data.Account{*src},
+		AccountsProposed: []data.Account{*dst},
+	}
+	b, err := json.Marshal(cmd)
+	c.Assert(err, IsNil)
+	c.Assert(string(b), Matches, `.*"command":"subscribe","accounts":\["rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh"\],"accounts_proposed":\["rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf"\]\}`)
+}
+
+func (s *MessagesSuite) TestAccountStreamMsg(c *C) {
+	msg := streamMessageFactory["transaction"]().(*TransactionStreamMsg)
+	readResponseFile(c, msg, "testdata/account_stream.json")
+
+	c.Assert(msg.EngineResult.String(), Equals, "tesSUCCESS")
+	c.Assert(msg.LedgerSequence, Equals, uint32(7201383))
+	c.Assert(msg.Status, Equals, "closed")
+	c.Assert(msg.Validated, Equals, true)
+
+	payment := msg.Transaction.Transaction.(*data.Payment)
+	c.Assert(payment.Account.String(), Equals, "rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh")
+	c.Assert(payment.Destination.String(), Equals, "rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf")
+	c.Assert(*payment.DestinationTag, Equals, uint32(42))
+	c.Assert(payment.Amount.String(), Equals, "10/XRP")
+
+	c.Assert(msg.Transaction.MetaData.TransactionResult.String(), Equals, "tesSUCCESS")
+	c.Assert(msg.Transaction.MetaData.DeliveredAmount.String(), Equals, "10/XRP")
+	c.Assert(msg.Transaction.MetaData.AffectedNodes, HasLen, 2)
+	root := msg.Transaction.MetaData.AffectedNodes[1].ModifiedNode.FinalFields.(*data.AccountRoot)
+	c.Assert(root.Account.String(), Equals, "rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf")
+	c.Assert(root.Balance.String(), Equals, "30")
+}
+
+func (s *MessagesSuite) TestUnsubscribeRequest(c *C) {
+	account, err := data.NewAccountFromAddress("rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh")
+	c.Assert(err, IsNil)
+	cmd := &UnsubscribeCommand{
+		Command:  newCommand("unsubscribe"),
+		Streams:  []string{"ledger", "server"},
+		Accounts: []data.Account{*account},
+	}
+	b, err := json.Marshal(cmd)
+	c.Assert(err, IsNil)
+	c.Assert(string(b), Matches, `.*"command":"unsubscribe","streams":\["ledger","server"\],"accounts":\["rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh"\]\}`)
+}
+
+func (s *MessagesSuite) TestOrderBookUnsubscribeRequest(c *C) {
+	cmd := &UnsubscribeCommand{
+		Command: newCommand("unsubscribe"),
+		Books: []OrderBookUnsubscription{{
+			TakerGets: data.Asset{Currency: "XRP"},
+			TakerPays: data.Asset{Currency: "USD", Issuer: "rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B"},
+			Both:      true,
+		}},
+	}
+	b, err := json.Marshal(cmd)
+	c.Assert(err, IsNil)
+	c.Assert(string(b), Matches, `.*"command":"unsubscribe","books":\[\{"taker_gets":\{"currency":"XRP"\},"taker_pays":\{"currency":"USD","issuer":"rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B"\},"both":true\}\]\}`)
+}
+
+func (s *MessagesSuite) TestOfferChanges(c *C) {
+	msg := streamMessageFactory["transaction"]().(*TransactionStreamMsg)
+	readResponseFile(c, msg, "testdata/transactions_stream.json")
+
+	changes := msg.OfferChanges()
+	c.Assert(changes, HasLen, 2)
+	c.Assert(changes[0].State, Equals, data.Created)
+	c.Assert(changes[0].Account.String(), Equals, "rPEZyTnSyQyXBCwMVYyaafSVPL8oMtfG6a")
+	c.Assert(changes[0].Sequence, Equals, uint32(753273))
+	c.Assert(changes[0].BookDirectory.String(), Equals, "7254404DF6B7FBFFEF34DC38867A7E7DE610B513997B78804D09B2E54D0BD965")
+	c.Assert(changes[0].TakerGets.String(), Equals, "6400.064/XRP")
+	c.Assert(changes[0].TakerPays.String(), Equals, "174.72/CNY/razqQKzJRdB4UxFPWf5NEpEG3WMkmwgcXA")
+	c.Assert(changes[1].State, Equals, data.Deleted)
+	c.Assert(changes[1].Sequence, Equals, uint32(753240))
+	c.Assert(changes[1].TakerGets.String(), Equals, "0.00275/BTC/rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B")
+	c.Assert(changes[1].TakerPays.String(), Equals, "414.380928/XRP")
+}
+
 func BenchmarkProposedTransactionStreamJSON(b *testing.B) {
 	bites, err := ioutil.ReadFile("testdata/proposed_transaction_stream.json")
 	if err != nil {